@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"strings"
+
+	"mkp-boarding-test/test/mockidp"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Runs the mock identity provider for trying the OIDC login locally, the defaults match config.json
+func main() {
+	addr := flag.String("addr", ":9000", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9000", "issuer URL, must match auth.oidc.issuer")
+	clientID := flag.String("client-id", "mkp-boarding", "client id, must match auth.oidc.client_id")
+	clientSecret := flag.String("client-secret", "mock-secret", "client secret, must match auth.oidc.client_secret")
+	subject := flag.String("subject", "mock-user-1", "subject of the user that is logged in")
+	email := flag.String("email", "mock.user@example.com", "email of the user that is logged in")
+	username := flag.String("username", "mock.user", "preferred username of the user that is logged in")
+	groups := flag.String("groups", "maritime-admins", "comma separated groups of the user that is logged in")
+	flag.Parse()
+
+	logger := logrus.New()
+
+	provider, err := mockidp.New(*issuer, *clientID, *clientSecret)
+	if err != nil {
+		logger.Fatalf("Failed to create mock identity provider: %v", err)
+	}
+
+	user := mockidp.User{
+		Subject:           *subject,
+		Email:             *email,
+		EmailVerified:     true,
+		PreferredUsername: *username,
+		GivenName:         "Mock",
+		FamilyName:        "User",
+	}
+	if *groups != "" {
+		user.Groups = strings.Split(*groups, ",")
+	}
+	provider.AddUser(user)
+
+	logger.Infof("Mock identity provider %s listening on %s", *issuer, *addr)
+	if err := http.ListenAndServe(*addr, provider); err != nil {
+		logger.Fatalf("Failed to start mock identity provider: %v", err)
+	}
+}
@@ -19,6 +19,11 @@
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Service account API key, accepted instead of a bearer token.
+
 package main
 
 import (
@@ -34,6 +39,7 @@ func main() {
 	validate := config.NewValidator(viperConfig)
 	app := config.NewFiber(viperConfig)
 	producer := config.NewKafkaProducer(viperConfig, log)
+	mailer := config.NewMailer(viperConfig, log)
 
 	config.Bootstrap(&config.BootstrapConfig{
 		DB:       db,
@@ -42,6 +48,7 @@ func main() {
 		Validate: validate,
 		Config:   viperConfig,
 		Producer: producer,
+		Mailer:   mailer,
 	})
 
 	webPort := viperConfig.GetInt("web.port")
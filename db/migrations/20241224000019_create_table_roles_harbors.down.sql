@@ -0,0 +1,2 @@
+-- Drop role_harbors junction table
+DROP TABLE IF EXISTS role_harbors;
\ No newline at end of file
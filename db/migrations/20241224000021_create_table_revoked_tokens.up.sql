@@ -0,0 +1,14 @@
+-- Create revoked_tokens table
+-- Denylist of access tokens (keyed on the token_id claim) that were revoked before their expiry
+CREATE TABLE revoked_tokens (
+    id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    expires_at BIGINT NOT NULL,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_revoked_tokens_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX idx_revoked_tokens_user_id ON revoked_tokens (user_id);
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens (expires_at);
@@ -0,0 +1,20 @@
+-- Create user_sessions table
+-- One row per login, the session owns the refresh token family issued at login
+CREATE TABLE user_sessions (
+    id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    family_id VARCHAR(36) NOT NULL UNIQUE,
+    user_agent VARCHAR(500) NULL,
+    ip_address VARCHAR(45) NULL,
+    last_seen_at BIGINT NOT NULL,
+    revoked_at BIGINT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_user_sessions_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX idx_user_sessions_user_id ON user_sessions (user_id);
+CREATE INDEX idx_user_sessions_family_id ON user_sessions (family_id);
+CREATE INDEX idx_user_sessions_revoked_at ON user_sessions (revoked_at);
@@ -0,0 +1,2 @@
+-- Drop email_verification_tokens table
+DROP TABLE IF EXISTS email_verification_tokens;
\ No newline at end of file
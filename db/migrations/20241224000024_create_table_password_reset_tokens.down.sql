@@ -0,0 +1,2 @@
+-- Drop password_reset_tokens table
+DROP TABLE IF EXISTS password_reset_tokens;
\ No newline at end of file
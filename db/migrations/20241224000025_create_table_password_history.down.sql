@@ -0,0 +1,2 @@
+-- Drop password_history table
+DROP TABLE IF EXISTS password_history;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- Create password_history table
+-- Keeps the hashes of recent passwords so they can not be reused
+CREATE TABLE password_history (
+    id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_password_history_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX idx_password_history_user_id_created_at ON password_history (user_id, created_at);
\ No newline at end of file
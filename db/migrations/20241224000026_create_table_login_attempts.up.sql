@@ -0,0 +1,13 @@
+-- Create login_attempts table
+-- Failed login counters keyed by account (username:<name>) or client address (ip:<addr>)
+CREATE TABLE login_attempts (
+    attempt_key VARCHAR(150) NOT NULL,
+    failed_count INT NOT NULL DEFAULT 0,
+    last_failed_at BIGINT NOT NULL,
+    locked_until BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (attempt_key)
+);
+
+-- Create indexes
+CREATE INDEX idx_login_attempts_locked_until ON login_attempts (locked_until);
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- Drop service account tables
+DROP TABLE IF EXISTS api_key_permissions;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS service_accounts;
\ No newline at end of file
@@ -0,0 +1,49 @@
+-- Create service_accounts table
+-- Non-human principals used by integration jobs, they authenticate with API keys instead of passwords
+CREATE TABLE service_accounts (
+    id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(36) NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    deleted_at BIGINT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_service_accounts_created_by FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
+-- Create api_keys table
+-- Only the SHA-256 hash of a key is stored, the prefix identifies the key without revealing it
+CREATE TABLE api_keys (
+    id VARCHAR(36) NOT NULL,
+    service_account_id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL UNIQUE,
+    key_hash VARCHAR(64) NOT NULL,
+    expires_at BIGINT NULL,
+    last_used_at BIGINT NULL,
+    last_used_ip VARCHAR(45) NULL,
+    revoked_at BIGINT NULL,
+    created_by VARCHAR(36) NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_api_keys_service_account_id FOREIGN KEY (service_account_id) REFERENCES service_accounts (id) ON DELETE CASCADE,
+    CONSTRAINT fk_api_keys_created_by FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
+-- Create api_key_permissions junction table
+CREATE TABLE api_key_permissions (
+    api_key_id VARCHAR(36) NOT NULL,
+    permission_id VARCHAR(36) NOT NULL,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (api_key_id, permission_id),
+    CONSTRAINT fk_api_key_permissions_api_key_id FOREIGN KEY (api_key_id) REFERENCES api_keys (id) ON DELETE CASCADE,
+    CONSTRAINT fk_api_key_permissions_permission_id FOREIGN KEY (permission_id) REFERENCES permissions (id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_service_accounts_name ON service_accounts (name) WHERE deleted_at IS NULL;
+CREATE INDEX idx_api_keys_service_account_id ON api_keys (service_account_id);
+CREATE INDEX idx_api_key_permissions_permission_id ON api_key_permissions (permission_id);
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- Remove seed data for service account permissions
+
+DELETE FROM role_permissions WHERE permission_id IN (
+    '660e8400-e29b-41d4-a716-446655440026',
+    '660e8400-e29b-41d4-a716-446655440027',
+    '660e8400-e29b-41d4-a716-446655440028',
+    '660e8400-e29b-41d4-a716-446655440029'
+);
+
+DELETE FROM permissions WHERE id IN (
+    '660e8400-e29b-41d4-a716-446655440026',
+    '660e8400-e29b-41d4-a716-446655440027',
+    '660e8400-e29b-41d4-a716-446655440028',
+    '660e8400-e29b-41d4-a716-446655440029'
+);
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- Seed data for service account permissions
+-- Managing service accounts and their API keys is reserved to the super admin
+
+INSERT INTO permissions (id, name, display_name, description, resource, action, is_active, is_system, created_at, updated_at, deleted_at) VALUES
+('660e8400-e29b-41d4-a716-446655440026', 'service_account.index', 'View Service Accounts', 'View and list service accounts and their API keys', 'service_account', 'index', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440027', 'service_account.store', 'Create Service Accounts', 'Create new service accounts', 'service_account', 'store', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440028', 'service_account.update', 'Update Service Accounts', 'Issue and revoke service account API keys', 'service_account', 'update', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440029', 'service_account.destroy', 'Delete Service Accounts', 'Delete service accounts', 'service_account', 'destroy', true, true, 1735027200, 1735027200, NULL);
+
+INSERT INTO role_permissions (role_id, permission_id, created_at) VALUES
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440026', 1735027200), -- service_account.index
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440027', 1735027200), -- service_account.store
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440028', 1735027200), -- service_account.update
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440029', 1735027200); -- service_account.destroy
\ No newline at end of file
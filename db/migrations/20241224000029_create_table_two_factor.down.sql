@@ -0,0 +1,6 @@
+-- Drop two-factor authentication tables
+DROP TABLE IF EXISTS two_factor_challenges;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS user_two_factors;
+
+ALTER TABLE users DROP COLUMN IF EXISTS two_factor_enabled;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- Revert role_harbors to a plain table
+DROP INDEX IF EXISTS idx_role_harbors_harbor_id;
+
+ALTER TABLE role_harbors DROP CONSTRAINT IF EXISTS fk_role_harbors_harbor_id;
+ALTER TABLE role_harbors DROP CONSTRAINT IF EXISTS fk_role_harbors_role_id;
+ALTER TABLE role_harbors DROP CONSTRAINT IF EXISTS pk_role_harbors;
+ALTER TABLE role_harbors DROP COLUMN IF EXISTS created_at;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- Turn role_harbors into a proper junction table
+-- Duplicate and dangling rows are dropped before the primary key and foreign keys are added
+DELETE FROM role_harbors a USING role_harbors b
+WHERE a.ctid < b.ctid AND a.role_id = b.role_id AND a.harbor_id = b.harbor_id;
+
+DELETE FROM role_harbors
+WHERE role_id NOT IN (SELECT id FROM roles) OR harbor_id NOT IN (SELECT id FROM harbors);
+
+ALTER TABLE role_harbors ADD COLUMN created_at BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE role_harbors ADD CONSTRAINT pk_role_harbors PRIMARY KEY (role_id, harbor_id);
+ALTER TABLE role_harbors ADD CONSTRAINT fk_role_harbors_role_id FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE;
+ALTER TABLE role_harbors ADD CONSTRAINT fk_role_harbors_harbor_id FOREIGN KEY (harbor_id) REFERENCES harbors (id) ON DELETE CASCADE;
+
+-- Create indexes
+CREATE INDEX idx_role_harbors_harbor_id ON role_harbors (harbor_id);
+
+-- Super Admin: all existing harbors, harbors created later are granted to the roles of their creator
+INSERT INTO role_harbors (role_id, harbor_id, created_at)
+SELECT '550e8400-e29b-41d4-a716-446655440001', id, 1735027200 FROM harbors WHERE deleted_at IS NULL
+ON CONFLICT (role_id, harbor_id) DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,30 @@
+-- Remove wildcard permissions and role inheritance
+DELETE FROM role_permissions WHERE permission_id IN (
+    '660e8400-e29b-41d4-a716-446655440030',
+    '660e8400-e29b-41d4-a716-446655440031',
+    '660e8400-e29b-41d4-a716-446655440032',
+    '660e8400-e29b-41d4-a716-446655440033',
+    '660e8400-e29b-41d4-a716-446655440034',
+    '660e8400-e29b-41d4-a716-446655440035',
+    '660e8400-e29b-41d4-a716-446655440036',
+    '660e8400-e29b-41d4-a716-446655440037',
+    '660e8400-e29b-41d4-a716-446655440038'
+);
+
+DELETE FROM permissions WHERE id IN (
+    '660e8400-e29b-41d4-a716-446655440030',
+    '660e8400-e29b-41d4-a716-446655440031',
+    '660e8400-e29b-41d4-a716-446655440032',
+    '660e8400-e29b-41d4-a716-446655440033',
+    '660e8400-e29b-41d4-a716-446655440034',
+    '660e8400-e29b-41d4-a716-446655440035',
+    '660e8400-e29b-41d4-a716-446655440036',
+    '660e8400-e29b-41d4-a716-446655440037',
+    '660e8400-e29b-41d4-a716-446655440038'
+);
+
+DROP INDEX IF EXISTS idx_roles_parent_id;
+
+ALTER TABLE roles DROP CONSTRAINT IF EXISTS chk_roles_parent_id;
+ALTER TABLE roles DROP CONSTRAINT IF EXISTS fk_roles_parent_id;
+ALTER TABLE roles DROP COLUMN IF EXISTS parent_id;
\ No newline at end of file
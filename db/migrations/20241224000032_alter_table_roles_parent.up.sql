@@ -0,0 +1,23 @@
+-- Role inheritance: a role holds every permission of its parent role and of the parent's ancestors
+ALTER TABLE roles ADD COLUMN parent_id VARCHAR(36) NULL;
+ALTER TABLE roles ADD CONSTRAINT fk_roles_parent_id FOREIGN KEY (parent_id) REFERENCES roles (id) ON DELETE SET NULL;
+ALTER TABLE roles ADD CONSTRAINT chk_roles_parent_id CHECK (parent_id <> id);
+
+-- Create indexes
+CREATE INDEX idx_roles_parent_id ON roles (parent_id);
+
+-- Wildcard permissions use * as resource or action and grant every matching permission, including ones added later
+INSERT INTO permissions (id, name, display_name, description, resource, action, is_active, is_system, created_at, updated_at, deleted_at) VALUES
+('660e8400-e29b-41d4-a716-446655440030', '*.*', 'All Permissions', 'Every action on every resource', '*', '*', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440031', '*.index', 'View Everything', 'View and list every resource', '*', 'index', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440032', 'user.*', 'Manage Users', 'Every action on users', 'user', '*', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440033', 'role.*', 'Manage Roles', 'Every action on roles', 'role', '*', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440034', 'permission.*', 'Manage Permissions', 'Every action on permissions', 'permission', '*', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440035', 'operator.*', 'Manage Operators', 'Every action on operators', 'operator', '*', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440036', 'ship.*', 'Manage Ships', 'Every action on ships', 'ship', '*', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440037', 'harbor.*', 'Manage Harbors', 'Every action on harbors', 'harbor', '*', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440038', 'service_account.*', 'Manage Service Accounts', 'Every action on service accounts and their API keys', 'service_account', '*', true, true, 1735027200, 1735027200, NULL);
+
+-- Super Admin: every permission, so permissions added later do not have to be granted one by one
+INSERT INTO role_permissions (role_id, permission_id, created_at) VALUES
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440030', 1735027200); -- *.*
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- Remove seed data for the authorization check permission
+
+DELETE FROM role_permissions WHERE permission_id = '660e8400-e29b-41d4-a716-446655440039';
+
+DELETE FROM permissions WHERE id = '660e8400-e29b-41d4-a716-446655440039';
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- Seed data for the authorization check permission
+-- Explaining authorization decisions of other users is reserved to the super admin
+
+INSERT INTO permissions (id, name, display_name, description, resource, action, is_active, is_system, created_at, updated_at, deleted_at) VALUES
+('660e8400-e29b-41d4-a716-446655440039', 'authz.check', 'Check Authorization', 'Explain whether a user may perform an action', 'authz', 'check', true, true, 1735027200, 1735027200, NULL);
+
+INSERT INTO role_permissions (role_id, permission_id, created_at) VALUES
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440039', 1735027200); -- authz.check
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Drop impersonation_audit_logs table
+DROP TABLE IF EXISTS impersonation_audit_logs;
\ No newline at end of file
@@ -0,0 +1,24 @@
+-- Create impersonation_audit_logs table
+-- One row when an admin starts impersonating a user and one row per write request made with the impersonation token,
+-- the row is written before the request runs and completed with the response status afterwards
+CREATE TABLE impersonation_audit_logs (
+    id VARCHAR(36) NOT NULL,
+    actor_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    token_id VARCHAR(100) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path VARCHAR(500) NOT NULL,
+    reason VARCHAR(255) NULL,
+    ip_address VARCHAR(45) NULL,
+    status_code INT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_impersonation_audit_logs_actor_id FOREIGN KEY (actor_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_impersonation_audit_logs_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX idx_impersonation_audit_logs_actor_id ON impersonation_audit_logs (actor_id);
+CREATE INDEX idx_impersonation_audit_logs_user_id ON impersonation_audit_logs (user_id);
+CREATE INDEX idx_impersonation_audit_logs_token_id ON impersonation_audit_logs (token_id);
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- Seed data for the impersonation permission
+-- Acting as another user is reserved to the super admin
+
+INSERT INTO permissions (id, name, display_name, description, resource, action, is_active, is_system, created_at, updated_at, deleted_at) VALUES
+('660e8400-e29b-41d4-a716-446655440040', 'user.impersonate', 'Impersonate User', 'Issue a short-lived token to act as another user', 'user', 'impersonate', true, true, 1735027200, 1735027200, NULL);
+
+INSERT INTO role_permissions (role_id, permission_id, created_at) VALUES
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440040', 1735027200); -- user.impersonate
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Drop boardings table
+DROP TABLE IF EXISTS boardings;
\ No newline at end of file
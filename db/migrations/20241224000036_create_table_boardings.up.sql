@@ -0,0 +1,33 @@
+-- Create boardings table
+-- A boarding is the inspection of a ship in a harbor by an inspecting user, it moves from planned to in_progress
+-- and ends completed with an outcome or aborted. Times are Unix milliseconds.
+CREATE TABLE boardings (
+    id VARCHAR(36) NOT NULL,
+    ship_id VARCHAR(36) NOT NULL,
+    harbor_id VARCHAR(36) NOT NULL,
+    inspector_id VARCHAR(36) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'planned',
+    planned_at BIGINT NOT NULL,
+    started_at BIGINT NULL,
+    completed_at BIGINT NULL,
+    aborted_at BIGINT NULL,
+    latitude DECIMAL(10,8) NULL,
+    longitude DECIMAL(11,8) NULL,
+    outcome VARCHAR(20) NULL,
+    remarks TEXT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_boardings_ship_id FOREIGN KEY (ship_id) REFERENCES ships (id) ON DELETE CASCADE,
+    CONSTRAINT fk_boardings_harbor_id FOREIGN KEY (harbor_id) REFERENCES harbors (id) ON DELETE CASCADE,
+    CONSTRAINT fk_boardings_inspector_id FOREIGN KEY (inspector_id) REFERENCES users (id),
+    CONSTRAINT chk_boardings_status CHECK (status IN ('planned', 'in_progress', 'completed', 'aborted')),
+    CONSTRAINT chk_boardings_outcome CHECK (outcome IS NULL OR outcome IN ('satisfactory', 'deficiencies', 'detained'))
+);
+
+-- Create indexes
+CREATE INDEX idx_boardings_ship_id ON boardings (ship_id);
+CREATE INDEX idx_boardings_harbor_id ON boardings (harbor_id);
+CREATE INDEX idx_boardings_inspector_id ON boardings (inspector_id);
+CREATE INDEX idx_boardings_status ON boardings (status);
+CREATE INDEX idx_boardings_planned_at ON boardings (planned_at);
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- Drop checklist template tables
+DROP TABLE IF EXISTS checklist_items;
+DROP TABLE IF EXISTS checklist_sections;
+DROP TABLE IF EXISTS checklist_templates;
\ No newline at end of file
@@ -0,0 +1,46 @@
+-- Create checklist template tables
+-- A checklist template is the list of items an inspector goes through when boarding a ship of a type. Templates are
+-- versioned per ship type and never change once created, a changed checklist is published as the next version.
+CREATE TABLE checklist_templates (
+    id VARCHAR(36) NOT NULL,
+    ship_type VARCHAR(100) NOT NULL,
+    version INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT chk_checklist_templates_version CHECK (version > 0)
+);
+
+CREATE TABLE checklist_sections (
+    id VARCHAR(36) NOT NULL,
+    template_id VARCHAR(36) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    position INT NOT NULL,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_checklist_sections_template_id FOREIGN KEY (template_id) REFERENCES checklist_templates (id) ON DELETE CASCADE
+);
+
+CREATE TABLE checklist_items (
+    id VARCHAR(36) NOT NULL,
+    section_id VARCHAR(36) NOT NULL,
+    prompt VARCHAR(1000) NOT NULL,
+    guidance TEXT NULL,
+    answer_type VARCHAR(20) NOT NULL,
+    is_required BOOLEAN NOT NULL DEFAULT TRUE,
+    position INT NOT NULL,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_checklist_items_section_id FOREIGN KEY (section_id) REFERENCES checklist_sections (id) ON DELETE CASCADE,
+    CONSTRAINT chk_checklist_items_answer_type CHECK (answer_type IN ('yes_no', 'yes_no_na', 'numeric', 'photo'))
+);
+
+-- Create indexes
+-- Ship types are free text, a version is unique per ship type regardless of case
+CREATE UNIQUE INDEX uq_checklist_templates_ship_type_version ON checklist_templates (LOWER(ship_type), version);
+CREATE INDEX idx_checklist_templates_is_active ON checklist_templates (is_active);
+CREATE INDEX idx_checklist_sections_template_id ON checklist_sections (template_id);
+CREATE INDEX idx_checklist_items_section_id ON checklist_items (section_id);
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- Remove the checklist of a boarding
+DROP TABLE IF EXISTS boarding_checklist_answers;
+
+DROP INDEX IF EXISTS idx_boardings_checklist_template_id;
+
+ALTER TABLE boardings
+DROP COLUMN IF EXISTS checklist_template_id;
\ No newline at end of file
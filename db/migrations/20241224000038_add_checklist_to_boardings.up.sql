@@ -0,0 +1,29 @@
+-- Add the checklist of a boarding
+-- The template version is chosen by ship type when the boarding starts, answers reference the items of that version
+ALTER TABLE boardings
+ADD COLUMN checklist_template_id VARCHAR(36) NULL;
+
+ALTER TABLE boardings
+ADD CONSTRAINT fk_boardings_checklist_template_id FOREIGN KEY (checklist_template_id) REFERENCES checklist_templates (id);
+
+-- Create boarding_checklist_answers table
+CREATE TABLE boarding_checklist_answers (
+    id VARCHAR(36) NOT NULL,
+    boarding_id VARCHAR(36) NOT NULL,
+    item_id VARCHAR(36) NOT NULL,
+    value VARCHAR(255) NULL,
+    photo_url VARCHAR(2048) NULL,
+    remarks TEXT NULL,
+    answered_by VARCHAR(36) NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT uq_boarding_checklist_answers_boarding_item UNIQUE (boarding_id, item_id),
+    CONSTRAINT fk_boarding_checklist_answers_boarding_id FOREIGN KEY (boarding_id) REFERENCES boardings (id) ON DELETE CASCADE,
+    CONSTRAINT fk_boarding_checklist_answers_item_id FOREIGN KEY (item_id) REFERENCES checklist_items (id),
+    CONSTRAINT fk_boarding_checklist_answers_answered_by FOREIGN KEY (answered_by) REFERENCES users (id)
+);
+
+-- Create indexes
+CREATE INDEX idx_boardings_checklist_template_id ON boardings (checklist_template_id);
+CREATE INDEX idx_boarding_checklist_answers_item_id ON boarding_checklist_answers (item_id);
\ No newline at end of file
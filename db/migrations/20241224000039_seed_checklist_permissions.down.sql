@@ -0,0 +1,15 @@
+-- Remove seed data for the checklist template permissions
+
+DELETE FROM role_permissions WHERE permission_id IN (
+    '660e8400-e29b-41d4-a716-446655440041',
+    '660e8400-e29b-41d4-a716-446655440042',
+    '660e8400-e29b-41d4-a716-446655440043',
+    '660e8400-e29b-41d4-a716-446655440044'
+);
+
+DELETE FROM permissions WHERE id IN (
+    '660e8400-e29b-41d4-a716-446655440041',
+    '660e8400-e29b-41d4-a716-446655440042',
+    '660e8400-e29b-41d4-a716-446655440043',
+    '660e8400-e29b-41d4-a716-446655440044'
+);
\ No newline at end of file
@@ -0,0 +1,23 @@
+-- Seed data for the checklist template permissions
+-- Templates are maintained by the super admin and the port authority, boarding officers read them
+
+INSERT INTO permissions (id, name, display_name, description, resource, action, is_active, is_system, created_at, updated_at, deleted_at) VALUES
+('660e8400-e29b-41d4-a716-446655440041', 'checklist.index', 'View Checklist Templates', 'View inspection checklist templates', 'checklist', 'index', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440042', 'checklist.store', 'Create Checklist Templates', 'Publish new inspection checklist template versions', 'checklist', 'store', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440043', 'checklist.update', 'Update Checklist Templates', 'Rename, activate and deactivate inspection checklist templates', 'checklist', 'update', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440044', 'checklist.destroy', 'Delete Checklist Templates', 'Delete unused inspection checklist templates', 'checklist', 'destroy', true, true, 1735027200, 1735027200, NULL);
+
+INSERT INTO role_permissions (role_id, permission_id, created_at) VALUES
+-- Super Admin
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440041', 1735027200), -- checklist.index
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440042', 1735027200), -- checklist.store
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440043', 1735027200), -- checklist.update
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440044', 1735027200), -- checklist.destroy
+
+-- Port Authority
+('550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440041', 1735027200), -- checklist.index
+('550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440042', 1735027200), -- checklist.store
+('550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440043', 1735027200), -- checklist.update
+
+-- Boarding Officer
+('550e8400-e29b-41d4-a716-446655440003', '660e8400-e29b-41d4-a716-446655440041', 1735027200); -- checklist.index
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Drop deficiencies table
+DROP TABLE IF EXISTS deficiencies;
\ No newline at end of file
@@ -0,0 +1,37 @@
+-- Create deficiencies table
+-- A deficiency is a finding of a boarding with a deadline for the corrective action. It moves from open to rectified
+-- when the ship reports the fix and to verified once an inspector confirmed it; an open deficiency past its deadline
+-- becomes overdue. Detainable deficiencies that are not verified flag the ship. Times are Unix milliseconds.
+CREATE TABLE deficiencies (
+    id VARCHAR(36) NOT NULL,
+    boarding_id VARCHAR(36) NOT NULL,
+    ship_id VARCHAR(36) NOT NULL,
+    code VARCHAR(20) NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    description TEXT NOT NULL,
+    deadline BIGINT NULL,
+    is_detainable BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    rectified_at BIGINT NULL,
+    rectified_by VARCHAR(36) NULL,
+    rectification_notes TEXT NULL,
+    verified_at BIGINT NULL,
+    verified_by VARCHAR(36) NULL,
+    verification_notes TEXT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_deficiencies_boarding_id FOREIGN KEY (boarding_id) REFERENCES boardings (id) ON DELETE CASCADE,
+    CONSTRAINT fk_deficiencies_ship_id FOREIGN KEY (ship_id) REFERENCES ships (id) ON DELETE CASCADE,
+    CONSTRAINT fk_deficiencies_rectified_by FOREIGN KEY (rectified_by) REFERENCES users (id),
+    CONSTRAINT fk_deficiencies_verified_by FOREIGN KEY (verified_by) REFERENCES users (id),
+    CONSTRAINT fk_deficiencies_created_by FOREIGN KEY (created_by) REFERENCES users (id),
+    CONSTRAINT chk_deficiencies_severity CHECK (severity IN ('minor', 'major', 'critical')),
+    CONSTRAINT chk_deficiencies_status CHECK (status IN ('open', 'rectified', 'verified', 'overdue'))
+);
+
+-- Create indexes
+CREATE INDEX idx_deficiencies_boarding_id ON deficiencies (boarding_id);
+CREATE INDEX idx_deficiencies_ship_id_status ON deficiencies (ship_id, status);
+CREATE INDEX idx_deficiencies_status_deadline ON deficiencies (status, deadline);
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- Remove seed data for the deficiency permissions
+
+DELETE FROM role_permissions WHERE permission_id IN (
+    '660e8400-e29b-41d4-a716-446655440045',
+    '660e8400-e29b-41d4-a716-446655440046'
+);
+
+DELETE FROM permissions WHERE id IN (
+    '660e8400-e29b-41d4-a716-446655440045',
+    '660e8400-e29b-41d4-a716-446655440046'
+);
\ No newline at end of file
@@ -0,0 +1,26 @@
+-- Seed data for the deficiency permissions
+-- Inspectors record and verify deficiencies with boarding.conduct, operators follow up on the deficiencies of their
+-- ships and report the corrective action
+
+INSERT INTO permissions (id, name, display_name, description, resource, action, is_active, is_system, created_at, updated_at, deleted_at) VALUES
+('660e8400-e29b-41d4-a716-446655440045', 'deficiency.index', 'View Deficiencies', 'View the deficiencies found on ships', 'deficiency', 'index', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440046', 'deficiency.rectify', 'Rectify Deficiencies', 'Report the corrective action of a deficiency', 'deficiency', 'rectify', true, true, 1735027200, 1735027200, NULL);
+
+INSERT INTO role_permissions (role_id, permission_id, created_at) VALUES
+-- Super Admin
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440045', 1735027200), -- deficiency.index
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440046', 1735027200), -- deficiency.rectify
+
+-- Port Authority
+('550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440045', 1735027200), -- deficiency.index
+
+-- Boarding Officer
+('550e8400-e29b-41d4-a716-446655440003', '660e8400-e29b-41d4-a716-446655440045', 1735027200), -- deficiency.index
+
+-- Ship Captain
+('550e8400-e29b-41d4-a716-446655440004', '660e8400-e29b-41d4-a716-446655440045', 1735027200), -- deficiency.index
+('550e8400-e29b-41d4-a716-446655440004', '660e8400-e29b-41d4-a716-446655440046', 1735027200), -- deficiency.rectify
+
+-- Operator Manager
+('550e8400-e29b-41d4-a716-446655440005', '660e8400-e29b-41d4-a716-446655440045', 1735027200), -- deficiency.index
+('550e8400-e29b-41d4-a716-446655440005', '660e8400-e29b-41d4-a716-446655440046', 1735027200); -- deficiency.rectify
\ No newline at end of file
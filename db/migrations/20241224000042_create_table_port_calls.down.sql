@@ -0,0 +1,2 @@
+-- Drop port_calls table
+DROP TABLE IF EXISTS port_calls;
\ No newline at end of file
@@ -0,0 +1,34 @@
+-- Create port_calls table
+-- A port call is the visit of a ship to a harbor. It is declared as expected with its estimated times, is in_port
+-- from the actual time of arrival and departed from the actual time of departure; a call closed before the ship
+-- arrived is cancelled. Times are Unix milliseconds.
+CREATE TABLE port_calls (
+    id VARCHAR(36) NOT NULL,
+    ship_id VARCHAR(36) NOT NULL,
+    harbor_id VARCHAR(36) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'expected',
+    eta BIGINT NOT NULL,
+    etd BIGINT NULL,
+    ata BIGINT NULL,
+    atd BIGINT NULL,
+    previous_port VARCHAR(255) NULL,
+    next_port VARCHAR(255) NULL,
+    purpose VARCHAR(30) NOT NULL,
+    remarks TEXT NULL,
+    declared_by VARCHAR(36) NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_port_calls_ship_id FOREIGN KEY (ship_id) REFERENCES ships (id) ON DELETE CASCADE,
+    CONSTRAINT fk_port_calls_harbor_id FOREIGN KEY (harbor_id) REFERENCES harbors (id) ON DELETE CASCADE,
+    CONSTRAINT fk_port_calls_declared_by FOREIGN KEY (declared_by) REFERENCES users (id),
+    CONSTRAINT chk_port_calls_status CHECK (status IN ('expected', 'in_port', 'departed', 'cancelled')),
+    CONSTRAINT chk_port_calls_purpose CHECK (purpose IN ('cargo', 'passengers', 'bunkering', 'crew_change', 'repairs', 'supplies', 'other'))
+);
+
+-- Create indexes
+-- A ship is in one port at a time
+CREATE UNIQUE INDEX uq_port_calls_ship_id_in_port ON port_calls (ship_id) WHERE status = 'in_port';
+CREATE INDEX idx_port_calls_ship_id ON port_calls (ship_id);
+CREATE INDEX idx_port_calls_harbor_id_status ON port_calls (harbor_id, status);
+CREATE INDEX idx_port_calls_eta ON port_calls (eta);
\ No newline at end of file
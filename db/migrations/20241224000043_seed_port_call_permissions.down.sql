@@ -0,0 +1,13 @@
+-- Remove seed data for the port call permissions
+
+DELETE FROM role_permissions WHERE permission_id IN (
+    '660e8400-e29b-41d4-a716-446655440047',
+    '660e8400-e29b-41d4-a716-446655440048',
+    '660e8400-e29b-41d4-a716-446655440049'
+);
+
+DELETE FROM permissions WHERE id IN (
+    '660e8400-e29b-41d4-a716-446655440047',
+    '660e8400-e29b-41d4-a716-446655440048',
+    '660e8400-e29b-41d4-a716-446655440049'
+);
\ No newline at end of file
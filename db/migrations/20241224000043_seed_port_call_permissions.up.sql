@@ -0,0 +1,32 @@
+-- Seed data for the port call permissions
+-- Port calls are declared and followed by the port authority and by the operators of the ships, boarding officers
+-- read them to plan boardings
+
+INSERT INTO permissions (id, name, display_name, description, resource, action, is_active, is_system, created_at, updated_at, deleted_at) VALUES
+('660e8400-e29b-41d4-a716-446655440047', 'port_call.index', 'View Port Calls', 'View the port calls of harbors', 'port_call', 'index', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440048', 'port_call.store', 'Declare Port Calls', 'Declare the expected port call of a ship', 'port_call', 'store', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440049', 'port_call.update', 'Update Port Calls', 'Update, record the arrival of and close port calls', 'port_call', 'update', true, true, 1735027200, 1735027200, NULL);
+
+INSERT INTO role_permissions (role_id, permission_id, created_at) VALUES
+-- Super Admin
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440047', 1735027200), -- port_call.index
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440048', 1735027200), -- port_call.store
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440049', 1735027200), -- port_call.update
+
+-- Port Authority
+('550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440047', 1735027200), -- port_call.index
+('550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440048', 1735027200), -- port_call.store
+('550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440049', 1735027200), -- port_call.update
+
+-- Boarding Officer
+('550e8400-e29b-41d4-a716-446655440003', '660e8400-e29b-41d4-a716-446655440047', 1735027200), -- port_call.index
+
+-- Ship Captain
+('550e8400-e29b-41d4-a716-446655440004', '660e8400-e29b-41d4-a716-446655440047', 1735027200), -- port_call.index
+('550e8400-e29b-41d4-a716-446655440004', '660e8400-e29b-41d4-a716-446655440048', 1735027200), -- port_call.store
+('550e8400-e29b-41d4-a716-446655440004', '660e8400-e29b-41d4-a716-446655440049', 1735027200), -- port_call.update
+
+-- Operator Manager
+('550e8400-e29b-41d4-a716-446655440005', '660e8400-e29b-41d4-a716-446655440047', 1735027200), -- port_call.index
+('550e8400-e29b-41d4-a716-446655440005', '660e8400-e29b-41d4-a716-446655440048', 1735027200), -- port_call.store
+('550e8400-e29b-41d4-a716-446655440005', '660e8400-e29b-41d4-a716-446655440049', 1735027200); -- port_call.update
\ No newline at end of file
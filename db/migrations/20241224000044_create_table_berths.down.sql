@@ -0,0 +1,3 @@
+-- Drop berth tables
+DROP TABLE IF EXISTS berth_ship_types;
+DROP TABLE IF EXISTS berths;
\ No newline at end of file
@@ -0,0 +1,32 @@
+-- Create berth tables
+-- A berth is a mooring place of a harbor with the length and depth of water it offers. A berth that lists ship types
+-- only takes ships of those types, a berth without ship types takes any ship.
+CREATE TABLE berths (
+    id VARCHAR(36) NOT NULL,
+    harbor_id VARCHAR(36) NOT NULL,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    length DECIMAL(10,2) NOT NULL,
+    depth DECIMAL(10,2) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    notes TEXT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_berths_harbor_id FOREIGN KEY (harbor_id) REFERENCES harbors (id) ON DELETE CASCADE,
+    CONSTRAINT chk_berths_length CHECK (length > 0),
+    CONSTRAINT chk_berths_depth CHECK (depth > 0)
+);
+
+CREATE TABLE berth_ship_types (
+    berth_id VARCHAR(36) NOT NULL,
+    ship_type VARCHAR(100) NOT NULL,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (berth_id, ship_type),
+    CONSTRAINT fk_berth_ship_types_berth_id FOREIGN KEY (berth_id) REFERENCES berths (id) ON DELETE CASCADE
+);
+
+-- Create indexes
+-- Berth codes are unique within a harbor regardless of case
+CREATE UNIQUE INDEX uq_berths_harbor_id_code ON berths (harbor_id, LOWER(code));
+CREATE INDEX idx_berths_is_active ON berths (is_active);
\ No newline at end of file
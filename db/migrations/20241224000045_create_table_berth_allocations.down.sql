@@ -0,0 +1,2 @@
+-- Drop berth_allocations table
+DROP TABLE IF EXISTS berth_allocations;
\ No newline at end of file
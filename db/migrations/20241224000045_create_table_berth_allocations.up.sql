@@ -0,0 +1,24 @@
+-- Create berth_allocations table
+-- A berth allocation reserves a berth for a port call from starts_at until ends_at (Unix milliseconds). The windows
+-- of one berth never overlap, allocations of cancelled port calls no longer hold the berth and allocations of departed
+-- port calls release it at the actual time of departure.
+CREATE TABLE berth_allocations (
+    id VARCHAR(36) NOT NULL,
+    berth_id VARCHAR(36) NOT NULL,
+    port_call_id VARCHAR(36) NOT NULL,
+    starts_at BIGINT NOT NULL,
+    ends_at BIGINT NOT NULL,
+    remarks TEXT NULL,
+    allocated_by VARCHAR(36) NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_berth_allocations_berth_id FOREIGN KEY (berth_id) REFERENCES berths (id) ON DELETE CASCADE,
+    CONSTRAINT fk_berth_allocations_port_call_id FOREIGN KEY (port_call_id) REFERENCES port_calls (id) ON DELETE CASCADE,
+    CONSTRAINT fk_berth_allocations_allocated_by FOREIGN KEY (allocated_by) REFERENCES users (id),
+    CONSTRAINT chk_berth_allocations_window CHECK (ends_at > starts_at)
+);
+
+-- Create indexes
+CREATE INDEX idx_berth_allocations_berth_id_window ON berth_allocations (berth_id, starts_at, ends_at);
+CREATE INDEX idx_berth_allocations_port_call_id ON berth_allocations (port_call_id);
\ No newline at end of file
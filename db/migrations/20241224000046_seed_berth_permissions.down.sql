@@ -0,0 +1,17 @@
+-- Remove seed data for the berth permissions
+
+DELETE FROM role_permissions WHERE permission_id IN (
+    '660e8400-e29b-41d4-a716-446655440050',
+    '660e8400-e29b-41d4-a716-446655440051',
+    '660e8400-e29b-41d4-a716-446655440052',
+    '660e8400-e29b-41d4-a716-446655440053',
+    '660e8400-e29b-41d4-a716-446655440054'
+);
+
+DELETE FROM permissions WHERE id IN (
+    '660e8400-e29b-41d4-a716-446655440050',
+    '660e8400-e29b-41d4-a716-446655440051',
+    '660e8400-e29b-41d4-a716-446655440052',
+    '660e8400-e29b-41d4-a716-446655440053',
+    '660e8400-e29b-41d4-a716-446655440054'
+);
\ No newline at end of file
@@ -0,0 +1,33 @@
+-- Seed data for the berth permissions
+-- The port authority keeps the berth registry and allocates berths, the other roles read it
+
+INSERT INTO permissions (id, name, display_name, description, resource, action, is_active, is_system, created_at, updated_at, deleted_at) VALUES
+('660e8400-e29b-41d4-a716-446655440050', 'berth.index', 'View Berths', 'View the berths of harbors and their allocations', 'berth', 'index', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440051', 'berth.store', 'Create Berths', 'Register berths of harbors', 'berth', 'store', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440052', 'berth.update', 'Update Berths', 'Update berths of harbors', 'berth', 'update', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440053', 'berth.destroy', 'Delete Berths', 'Delete berths that were never allocated', 'berth', 'destroy', true, true, 1735027200, 1735027200, NULL),
+('660e8400-e29b-41d4-a716-446655440054', 'berth.allocate', 'Allocate Berths', 'Allocate berths to port calls and release them', 'berth', 'allocate', true, true, 1735027200, 1735027200, NULL);
+
+INSERT INTO role_permissions (role_id, permission_id, created_at) VALUES
+-- Super Admin
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440050', 1735027200), -- berth.index
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440051', 1735027200), -- berth.store
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440052', 1735027200), -- berth.update
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440053', 1735027200), -- berth.destroy
+('550e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440054', 1735027200), -- berth.allocate
+
+-- Port Authority
+('550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440050', 1735027200), -- berth.index
+('550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440051', 1735027200), -- berth.store
+('550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440052', 1735027200), -- berth.update
+('550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440053', 1735027200), -- berth.destroy
+('550e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440054', 1735027200), -- berth.allocate
+
+-- Boarding Officer
+('550e8400-e29b-41d4-a716-446655440003', '660e8400-e29b-41d4-a716-446655440050', 1735027200), -- berth.index
+
+-- Ship Captain
+('550e8400-e29b-41d4-a716-446655440004', '660e8400-e29b-41d4-a716-446655440050', 1735027200), -- berth.index
+
+-- Operator Manager
+('550e8400-e29b-41d4-a716-446655440005', '660e8400-e29b-41d4-a716-446655440050', 1735027200); -- berth.index
\ No newline at end of file
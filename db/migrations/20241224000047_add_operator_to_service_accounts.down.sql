@@ -0,0 +1,5 @@
+-- Remove the operator scope of service accounts
+DROP INDEX IF EXISTS idx_service_accounts_operator_id;
+
+ALTER TABLE service_accounts
+DROP COLUMN IF EXISTS operator_id;
\ No newline at end of file
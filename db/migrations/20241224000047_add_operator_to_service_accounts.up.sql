@@ -0,0 +1,15 @@
+-- Add the operator scope of service accounts
+-- A service account only sees the ships of the operator of the user that created it, accounts created by staff users
+-- are not linked to an operator and see no tenant-scoped records
+ALTER TABLE service_accounts
+ADD COLUMN operator_id VARCHAR(36) NULL;
+
+ALTER TABLE service_accounts
+ADD CONSTRAINT fk_service_accounts_operator_id FOREIGN KEY (operator_id) REFERENCES operators (id) ON DELETE SET NULL;
+
+UPDATE service_accounts SET operator_id = operators.id
+FROM operators
+WHERE operators.user_id = service_accounts.created_by AND operators.deleted_at IS NULL;
+
+-- Create indexes
+CREATE INDEX idx_service_accounts_operator_id ON service_accounts (operator_id);
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Allow the stored overdue status of deficiencies again
+ALTER TABLE deficiencies DROP CONSTRAINT IF EXISTS chk_deficiencies_status;
+ALTER TABLE deficiencies ADD CONSTRAINT chk_deficiencies_status CHECK (status IN ('open', 'rectified', 'verified', 'overdue'));
\ No newline at end of file
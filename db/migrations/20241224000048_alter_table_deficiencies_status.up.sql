@@ -0,0 +1,6 @@
+-- Derive overdue deficiencies when they are read
+-- An open deficiency past its deadline is reported as overdue without being written, the stored status stays open
+UPDATE deficiencies SET status = 'open' WHERE status = 'overdue';
+
+ALTER TABLE deficiencies DROP CONSTRAINT IF EXISTS chk_deficiencies_status;
+ALTER TABLE deficiencies ADD CONSTRAINT chk_deficiencies_status CHECK (status IN ('open', 'rectified', 'verified'));
\ No newline at end of file
@@ -0,0 +1,16 @@
+-- Cascade boardings, deficiencies, port calls and berths with their ship or harbor again
+ALTER TABLE boardings DROP CONSTRAINT IF EXISTS fk_boardings_ship_id;
+ALTER TABLE boardings ADD CONSTRAINT fk_boardings_ship_id FOREIGN KEY (ship_id) REFERENCES ships (id) ON DELETE CASCADE;
+ALTER TABLE boardings DROP CONSTRAINT IF EXISTS fk_boardings_harbor_id;
+ALTER TABLE boardings ADD CONSTRAINT fk_boardings_harbor_id FOREIGN KEY (harbor_id) REFERENCES harbors (id) ON DELETE CASCADE;
+
+ALTER TABLE deficiencies DROP CONSTRAINT IF EXISTS fk_deficiencies_ship_id;
+ALTER TABLE deficiencies ADD CONSTRAINT fk_deficiencies_ship_id FOREIGN KEY (ship_id) REFERENCES ships (id) ON DELETE CASCADE;
+
+ALTER TABLE port_calls DROP CONSTRAINT IF EXISTS fk_port_calls_ship_id;
+ALTER TABLE port_calls ADD CONSTRAINT fk_port_calls_ship_id FOREIGN KEY (ship_id) REFERENCES ships (id) ON DELETE CASCADE;
+ALTER TABLE port_calls DROP CONSTRAINT IF EXISTS fk_port_calls_harbor_id;
+ALTER TABLE port_calls ADD CONSTRAINT fk_port_calls_harbor_id FOREIGN KEY (harbor_id) REFERENCES harbors (id) ON DELETE CASCADE;
+
+ALTER TABLE berths DROP CONSTRAINT IF EXISTS fk_berths_harbor_id;
+ALTER TABLE berths ADD CONSTRAINT fk_berths_harbor_id FOREIGN KEY (harbor_id) REFERENCES harbors (id) ON DELETE CASCADE;
\ No newline at end of file
@@ -0,0 +1,18 @@
+-- Keep the inspection history when a ship or harbor is deleted
+-- Boardings, deficiencies, port calls and berths no longer cascade with their ship or harbor, a ship or harbor that
+-- still has any of them can not be deleted
+ALTER TABLE boardings DROP CONSTRAINT IF EXISTS fk_boardings_ship_id;
+ALTER TABLE boardings ADD CONSTRAINT fk_boardings_ship_id FOREIGN KEY (ship_id) REFERENCES ships (id) ON DELETE RESTRICT;
+ALTER TABLE boardings DROP CONSTRAINT IF EXISTS fk_boardings_harbor_id;
+ALTER TABLE boardings ADD CONSTRAINT fk_boardings_harbor_id FOREIGN KEY (harbor_id) REFERENCES harbors (id) ON DELETE RESTRICT;
+
+ALTER TABLE deficiencies DROP CONSTRAINT IF EXISTS fk_deficiencies_ship_id;
+ALTER TABLE deficiencies ADD CONSTRAINT fk_deficiencies_ship_id FOREIGN KEY (ship_id) REFERENCES ships (id) ON DELETE RESTRICT;
+
+ALTER TABLE port_calls DROP CONSTRAINT IF EXISTS fk_port_calls_ship_id;
+ALTER TABLE port_calls ADD CONSTRAINT fk_port_calls_ship_id FOREIGN KEY (ship_id) REFERENCES ships (id) ON DELETE RESTRICT;
+ALTER TABLE port_calls DROP CONSTRAINT IF EXISTS fk_port_calls_harbor_id;
+ALTER TABLE port_calls ADD CONSTRAINT fk_port_calls_harbor_id FOREIGN KEY (harbor_id) REFERENCES harbors (id) ON DELETE RESTRICT;
+
+ALTER TABLE berths DROP CONSTRAINT IF EXISTS fk_berths_harbor_id;
+ALTER TABLE berths ADD CONSTRAINT fk_berths_harbor_id FOREIGN KEY (harbor_id) REFERENCES harbors (id) ON DELETE RESTRICT;
\ No newline at end of file
@@ -24,14 +24,34 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/harbors": {
+        "/.well-known/jwks.json": {
             "get": {
+                "description": "Get the public keys used to verify access tokens. The set is empty when tokens are signed with a shared secret (HS256)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get JSON Web Key Set",
+                "responses": {
+                    "200": {
+                        "description": "JSON Web Key Set",
+                        "schema": {
+                            "$ref": "#/definitions/service.JSONWebKeySet"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/authz/check": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of harbors with optional filtering",
+                "description": "Explain whether a user may perform an action, optionally on one ship, harbor, boarding, deficiency, port call or berth: which roles grant it, whether the two-factor policy blocks it and whether the record is in the user's scope",
                 "consumes": [
                     "application/json"
                 ],
@@ -39,61 +59,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Harbors"
+                    "Authorization"
                 ],
-                "summary": "List harbors",
+                "summary": "Check authorization",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by harbor name",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by country",
-                        "name": "country",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by province",
-                        "name": "province",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by city",
-                        "name": "city",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "default": true,
-                        "description": "Filter by active status",
-                        "name": "is_active",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "size",
-                        "in": "query"
+                        "description": "Authorization check request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AuthzCheckRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of harbors",
+                        "description": "Authorization checked successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.SwaggerPageResponse"
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
                     "401": {
@@ -102,6 +92,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -109,14 +111,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/berth-allocations/{allocationId}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new harbor with detailed information",
+                "description": "Release a berth allocation so that the berth is free again during its window",
                 "consumes": [
                     "application/json"
                 ],
@@ -124,35 +128,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Harbors"
+                    "Berths"
                 ],
-                "summary": "Create a new harbor",
+                "summary": "Release berth allocation",
                 "parameters": [
                     {
-                        "description": "Create harbor request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreateHarborRequest"
-                        }
+                        "type": "string",
+                        "description": "Berth allocation ID",
+                        "name": "allocationId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Harbor created successfully",
+                        "description": "Berth allocation released successfully",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Berth allocation not found",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -166,14 +174,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/harbors/{harborId}": {
+        "/api/berths/{berthId}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get harbor details by harbor ID",
+                "description": "Get a berth with the ship types it takes",
                 "consumes": [
                     "application/json"
                 ],
@@ -181,21 +189,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Harbors"
+                    "Berths"
                 ],
-                "summary": "Get harbor by ID",
+                "summary": "Get berth",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Harbor ID",
-                        "name": "harborId",
+                        "description": "Berth ID",
+                        "name": "berthId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Harbor details",
+                        "description": "Berth details",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -206,8 +214,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Harbor not found",
+                        "description": "Berth not found",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -226,7 +240,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update harbor information by harbor ID",
+                "description": "Update a berth. Ship types replace the ones of the berth when given, an empty list lets the berth take any ship",
                 "consumes": [
                     "application/json"
                 ],
@@ -234,30 +248,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Harbors"
+                    "Berths"
                 ],
-                "summary": "Update harbor",
+                "summary": "Update berth",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Harbor ID",
-                        "name": "harborId",
+                        "description": "Berth ID",
+                        "name": "berthId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update harbor request",
+                        "description": "Update berth request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateHarborRequest"
+                            "$ref": "#/definitions/model.UpdateBerthRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Harbor updated successfully",
+                        "description": "Berth updated successfully",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -274,8 +288,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Harbor not found",
+                        "description": "Berth not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Berth code already exists in the harbor",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -294,7 +320,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete harbor by harbor ID",
+                "description": "Delete a berth that was never allocated, allocated berths are deactivated instead to keep their history",
                 "consumes": [
                     "application/json"
                 ],
@@ -302,21 +328,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Harbors"
+                    "Berths"
                 ],
-                "summary": "Delete harbor",
+                "summary": "Delete berth",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Harbor ID",
-                        "name": "harborId",
+                        "description": "Berth ID",
+                        "name": "berthId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Harbor deleted successfully",
+                        "description": "Berth deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -327,8 +353,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Harbor not found",
+                        "description": "Berth not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Berth has allocations",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -342,14 +380,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/operators": {
+        "/api/berths/{berthId}/allocations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of operators with optional filtering",
+                "description": "Get the allocations of a berth with their port calls in the order of their windows, optionally only those overlapping from..to (Unix milliseconds)",
                 "consumes": [
                     "application/json"
                 ],
@@ -357,51 +395,111 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Operators"
+                    "Berths"
                 ],
-                "summary": "List operators",
+                "summary": "List berth allocations",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by company name",
-                        "name": "company_name",
-                        "in": "query"
+                        "description": "Berth ID",
+                        "name": "berthId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by operator type",
-                        "name": "operator_type",
+                        "type": "integer",
+                        "description": "Only allocations ending after this time",
+                        "name": "from",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
+                        "type": "integer",
+                        "description": "Only allocations starting before this time",
+                        "name": "to",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of berth allocations",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Berth not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/boardings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of boardings in the harbors and of the ships in the scope of the current user, latest planned first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Boardings"
+                ],
+                "summary": "List boardings",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by country",
-                        "name": "country",
+                        "description": "Filter by ship ID",
+                        "name": "ship_id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by province",
-                        "name": "province",
+                        "description": "Filter by harbor ID",
+                        "name": "harbor_id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by city",
-                        "name": "city",
+                        "description": "Filter by inspector ID",
+                        "name": "inspector_id",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "default": true,
-                        "description": "Filter by active status",
-                        "name": "is_active",
+                        "type": "string",
+                        "description": "Filter by status (planned, in_progress, completed, aborted)",
+                        "name": "status",
                         "in": "query"
                     },
                     {
@@ -421,7 +519,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of operators",
+                        "description": "List of boardings",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerPageResponse"
                         }
@@ -432,6 +530,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -446,7 +550,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new operator with company information",
+                "description": "Plan the boarding of a ship in a harbor, the inspector defaults to the current user. Ship and harbor must be in the scope of the current user",
                 "consumes": [
                     "application/json"
                 ],
@@ -454,23 +558,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Operators"
+                    "Boardings"
                 ],
-                "summary": "Create a new operator",
+                "summary": "Plan a boarding",
                 "parameters": [
                     {
-                        "description": "Create operator request",
+                        "description": "Create boarding request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateOperatorRequest"
+                            "$ref": "#/definitions/model.CreateBoardingRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Operator created successfully",
+                        "description": "Boarding created successfully",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -487,23 +591,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/api/operators/{operatorId}": {
+                    },
+                    "404": {
+                        "description": "Ship, harbor or inspector not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/boardings/{boardingId}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get operator details by operator ID",
+                "description": "Get boarding details with its ship and harbor by boarding ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -511,21 +627,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Operators"
+                    "Boardings"
                 ],
-                "summary": "Get operator by ID",
+                "summary": "Get boarding by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Operator ID",
-                        "name": "operatorId",
+                        "description": "Boarding ID",
+                        "name": "boardingId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Operator details",
+                        "description": "Boarding details",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -536,8 +652,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Operator not found",
+                        "description": "Boarding not found",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -556,7 +678,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update operator information by operator ID",
+                "description": "Change the harbor, inspector, planned time or remarks of a planned boarding",
                 "consumes": [
                     "application/json"
                 ],
@@ -564,30 +686,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Operators"
+                    "Boardings"
                 ],
-                "summary": "Update operator",
+                "summary": "Update boarding",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Operator ID",
-                        "name": "operatorId",
+                        "description": "Boarding ID",
+                        "name": "boardingId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update operator request",
+                        "description": "Update boarding request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateOperatorRequest"
+                            "$ref": "#/definitions/model.UpdateBoardingRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Operator updated successfully",
+                        "description": "Boarding updated successfully",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -604,8 +726,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Operator not found",
+                        "description": "Boarding, harbor or inspector not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Boarding is no longer planned",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -624,7 +758,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete operator by operator ID",
+                "description": "Delete a planned boarding, boardings that started are kept as inspection records and can only be aborted",
                 "consumes": [
                     "application/json"
                 ],
@@ -632,21 +766,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Operators"
+                    "Boardings"
                 ],
-                "summary": "Delete operator",
+                "summary": "Delete boarding",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Operator ID",
-                        "name": "operatorId",
+                        "description": "Boarding ID",
+                        "name": "boardingId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Operator deleted successfully",
+                        "description": "Boarding deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -657,89 +791,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
-                    "404": {
-                        "description": "Operator not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Boarding not found",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/api/permissions": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get list of permissions with optional filtering",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Permissions"
-                ],
-                "summary": "List permissions",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filter by permission name",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by resource",
-                        "name": "resource",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by action",
-                        "name": "action",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "default": true,
-                        "description": "Filter by active status",
-                        "name": "is_active",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "size",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "List of permissions",
-                        "schema": {
-                            "$ref": "#/definitions/model.SwaggerPageResponse"
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Boarding is no longer planned",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -751,14 +816,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/boardings/{boardingId}/abort": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new permission with name, resource, and action",
+                "description": "End a planned boarding or a boarding in progress without an outcome, the remarks explain why",
                 "consumes": [
                     "application/json"
                 ],
@@ -766,23 +833,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permissions"
+                    "Boardings"
                 ],
-                "summary": "Create a new permission",
+                "summary": "Abort boarding",
                 "parameters": [
                     {
-                        "description": "Create permission request",
+                        "type": "string",
+                        "description": "Boarding ID",
+                        "name": "boardingId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Abort boarding request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreatePermissionRequest"
+                            "$ref": "#/definitions/model.AbortBoardingRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Permission created successfully",
+                        "description": "Boarding aborted successfully",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -799,6 +873,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Boarding not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Boarding is already completed or aborted",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -808,14 +900,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/permissions/{permissionId}": {
+        "/api/boardings/{boardingId}/checklist": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get permission details by permission ID",
+                "description": "Get the checklist version chosen for the boarding when it started, with the answers given so far and the number of required items still unanswered",
                 "consumes": [
                     "application/json"
                 ],
@@ -823,21 +915,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permissions"
+                    "Boardings"
                 ],
-                "summary": "Get permission by ID",
+                "summary": "Get boarding checklist",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Permission ID",
-                        "name": "permissionId",
+                        "description": "Boarding ID",
+                        "name": "boardingId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Permission details",
+                        "description": "Boarding checklist",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -848,8 +940,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Permission not found",
+                        "description": "Boarding not found",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -868,7 +966,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update permission information by permission ID",
+                "description": "Answer items of the checklist of a boarding in progress, an item answered again is overwritten. Answers are frozen once the boarding is completed or aborted",
                 "consumes": [
                     "application/json"
                 ],
@@ -876,30 +974,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permissions"
+                    "Boardings"
                 ],
-                "summary": "Update permission",
+                "summary": "Answer boarding checklist",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Permission ID",
-                        "name": "permissionId",
+                        "description": "Boarding ID",
+                        "name": "boardingId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update permission request",
+                        "description": "Answer boarding checklist request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdatePermissionRequest"
+                            "$ref": "#/definitions/model.AnswerBoardingChecklistRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Permission updated successfully",
+                        "description": "Boarding checklist answered successfully",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -916,8 +1014,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Permission not found",
+                        "description": "Boarding not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Boarding is not in progress or has no checklist",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -929,14 +1039,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/boardings/{boardingId}/complete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete permission by permission ID",
+                "description": "Close a boarding in progress with its outcome (satisfactory, deficiencies or detained) and remarks. Every required item of its checklist must be answered",
                 "consumes": [
                     "application/json"
                 ],
@@ -944,21 +1056,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Permissions"
+                    "Boardings"
                 ],
-                "summary": "Delete permission",
+                "summary": "Complete boarding",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Permission ID",
-                        "name": "permissionId",
+                        "description": "Boarding ID",
+                        "name": "boardingId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Complete boarding request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CompleteBoardingRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Permission deleted successfully",
+                        "description": "Boarding completed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -969,8 +1096,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Permission not found",
+                        "description": "Boarding not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Boarding is not in progress or required checklist items are not answered",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -984,14 +1123,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/roles": {
+        "/api/boardings/{boardingId}/deficiencies": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of roles with optional filtering",
+                "description": "Get all deficiencies found during a boarding in the order they were recorded",
                 "consumes": [
                     "application/json"
                 ],
@@ -999,43 +1138,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Deficiencies"
                 ],
-                "summary": "List roles",
+                "summary": "List boarding deficiencies",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by role name",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "default": true,
-                        "description": "Filter by active status",
-                        "name": "is_active",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "size",
-                        "in": "query"
+                        "description": "Boarding ID",
+                        "name": "boardingId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of roles",
+                        "description": "List of deficiencies",
                         "schema": {
-                            "$ref": "#/definitions/model.SwaggerPageResponse"
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
                     "401": {
@@ -1044,6 +1163,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Boarding not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1058,7 +1189,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new role with name and description",
+                "description": "Record a deficiency found during a boarding in progress or completed, with its code, severity, corrective action deadline and whether it is detainable",
                 "consumes": [
                     "application/json"
                 ],
@@ -1066,23 +1197,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Deficiencies"
                 ],
-                "summary": "Create a new role",
+                "summary": "Record deficiency",
                 "parameters": [
                     {
-                        "description": "Create role request",
+                        "type": "string",
+                        "description": "Boarding ID",
+                        "name": "boardingId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create deficiency request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateRoleRequest"
+                            "$ref": "#/definitions/model.CreateDeficiencyRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Role created successfully",
+                        "description": "Deficiency created successfully",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1099,6 +1237,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Boarding not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Boarding is planned or aborted",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1108,14 +1264,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/roles/{roleId}": {
-            "get": {
+        "/api/boardings/{boardingId}/start": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get role details by role ID",
+                "description": "Record that the inspectors went on board of the ship, optionally with the position. The latest active checklist version for the ship type is chosen for the boarding. A ship can only be boarded by one team at a time",
                 "consumes": [
                     "application/json"
                 ],
@@ -1123,21 +1279,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Boardings"
                 ],
-                "summary": "Get role by ID",
+                "summary": "Start boarding",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Role ID",
-                        "name": "roleId",
+                        "description": "Boarding ID",
+                        "name": "boardingId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Start boarding request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StartBoardingRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Role details",
+                        "description": "Boarding started successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1148,8 +1319,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Role not found",
+                        "description": "Boarding not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Boarding is not planned or the ship is already being boarded",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1161,14 +1344,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/checklist-templates": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update role information by role ID",
+                "description": "Get list of checklist template versions without their items, ordered by ship type and latest version first",
                 "consumes": [
                     "application/json"
                 ],
@@ -1176,38 +1361,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Checklist Templates"
                 ],
-                "summary": "Update role",
+                "summary": "List checklist templates",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Role ID",
-                        "name": "roleId",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by ship type",
+                        "name": "ship_type",
+                        "in": "query"
                     },
                     {
-                        "description": "Update role request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdateRoleRequest"
-                        }
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Filter by active status",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Role updated successfully",
-                        "schema": {
-                            "$ref": "#/definitions/model.SwaggerWebResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                        "description": "List of checklist templates",
                         "schema": {
-                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                            "$ref": "#/definitions/model.SwaggerPageResponse"
                         }
                     },
                     "401": {
@@ -1216,8 +1406,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
-                    "404": {
-                        "description": "Role not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1230,13 +1420,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete role by role ID",
+                "description": "Publish the next version of the inspection checklist for a ship type with its sections and items. Published versions never change, a changed checklist is created as the next version",
                 "consumes": [
                     "application/json"
                 ],
@@ -1244,21 +1434,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Checklist Templates"
                 ],
-                "summary": "Delete role",
+                "summary": "Create checklist template version",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Role ID",
-                        "name": "roleId",
-                        "in": "path",
-                        "required": true
+                        "description": "Create checklist template request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateChecklistTemplateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Role deleted successfully",
+                        "description": "Checklist template created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1269,8 +1467,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
-                    "404": {
-                        "description": "Role not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1284,14 +1482,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/roles/{roleId}/permissions": {
-            "post": {
+        "/api/checklist-templates/{templateId}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Assign multiple permissions to a specific role",
+                "description": "Get a checklist template version with its sections and items",
                 "consumes": [
                     "application/json"
                 ],
@@ -1299,48 +1497,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Checklist Templates"
                 ],
-                "summary": "Assign permissions to role",
+                "summary": "Get checklist template by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Role ID",
-                        "name": "roleId",
+                        "description": "Checklist template ID",
+                        "name": "templateId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Assign permissions request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.AssignPermissionsRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Permissions assigned successfully",
+                        "description": "Checklist template details",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
                     "404": {
-                        "description": "Role not found",
+                        "description": "Checklist template not found",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1353,13 +1542,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove multiple permissions from a specific role",
+                "description": "Rename, activate or deactivate a checklist template version. Inactive versions are no longer chosen for new boardings",
                 "consumes": [
                     "application/json"
                 ],
@@ -1367,30 +1556,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Checklist Templates"
                 ],
-                "summary": "Remove permissions from role",
+                "summary": "Update checklist template",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Role ID",
-                        "name": "roleId",
+                        "description": "Checklist template ID",
+                        "name": "templateId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Remove permissions request",
+                        "description": "Update checklist template request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.RemovePermissionsRequest"
+                            "$ref": "#/definitions/model.UpdateChecklistTemplateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Permissions removed successfully",
+                        "description": "Checklist template updated successfully",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1407,8 +1596,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Role not found",
+                        "description": "Checklist template not found",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1420,16 +1615,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/ships": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of ships with optional filtering",
+                "description": "Delete a checklist template version no boarding used, versions in use can only be deactivated",
                 "consumes": [
                     "application/json"
                 ],
@@ -1437,67 +1630,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ships"
+                    "Checklist Templates"
                 ],
-                "summary": "List ships",
+                "summary": "Delete checklist template",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by operator ID",
-                        "name": "operator_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by ship name",
-                        "name": "ship_name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by flag state",
-                        "name": "flag_state",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by ship type",
-                        "name": "ship_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "default": true,
-                        "description": "Filter by active status",
-                        "name": "is_active",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "size",
-                        "in": "query"
+                        "description": "Checklist template ID",
+                        "name": "templateId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of ships",
+                        "description": "Checklist template deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.SwaggerPageResponse"
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
                     "401": {
@@ -1506,6 +1655,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Checklist template not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Checklist template is used by boardings",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1513,14 +1680,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/deficiencies/{deficiencyId}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new ship with detailed information",
+                "description": "Get deficiency details with its corrective action follow-up",
                 "consumes": [
                     "application/json"
                 ],
@@ -1528,35 +1697,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ships"
+                    "Deficiencies"
                 ],
-                "summary": "Create a new ship",
+                "summary": "Get deficiency by ID",
                 "parameters": [
                     {
-                        "description": "Create ship request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreateShipRequest"
-                        }
+                        "type": "string",
+                        "description": "Deficiency ID",
+                        "name": "deficiencyId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Ship created successfully",
+                        "description": "Deficiency details",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Deficiency not found",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1568,16 +1741,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/ships/{shipId}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get ship details by ship ID",
+                "description": "Correct the code, severity, description, deadline or detainable flag of an open or overdue deficiency",
                 "consumes": [
                     "application/json"
                 ],
@@ -1585,21 +1756,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ships"
+                    "Deficiencies"
                 ],
-                "summary": "Get ship by ID",
+                "summary": "Update deficiency",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Ship ID",
-                        "name": "shipId",
+                        "description": "Deficiency ID",
+                        "name": "deficiencyId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update deficiency request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateDeficiencyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Ship details",
+                        "description": "Deficiency updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1610,8 +1796,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Ship not found",
+                        "description": "Deficiency not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Deficiency is rectified or verified",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1623,14 +1821,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/deficiencies/{deficiencyId}/rectify": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update ship information by ship ID",
+                "description": "Report the corrective action of an open or overdue deficiency of a ship, it stays open until an inspector verifies it",
                 "consumes": [
                     "application/json"
                 ],
@@ -1638,30 +1838,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ships"
+                    "Deficiencies"
                 ],
-                "summary": "Update ship",
+                "summary": "Rectify deficiency",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Ship ID",
-                        "name": "shipId",
+                        "description": "Deficiency ID",
+                        "name": "deficiencyId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update ship request",
+                        "description": "Rectify deficiency request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateShipRequest"
+                            "$ref": "#/definitions/model.RectifyDeficiencyRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Ship updated successfully",
+                        "description": "Deficiency rectified successfully",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1678,8 +1878,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Ship not found",
+                        "description": "Deficiency not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Deficiency is already rectified or verified",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1691,14 +1903,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/deficiencies/{deficiencyId}/reopen": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete ship by ship ID",
+                "description": "Reject the corrective action of a rectified deficiency, the notes explain why. It becomes open again, or overdue when its deadline passed",
                 "consumes": [
                     "application/json"
                 ],
@@ -1706,21 +1920,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ships"
+                    "Deficiencies"
                 ],
-                "summary": "Delete ship",
+                "summary": "Reopen deficiency",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Ship ID",
-                        "name": "shipId",
+                        "description": "Deficiency ID",
+                        "name": "deficiencyId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Reopen deficiency request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReopenDeficiencyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Ship deleted successfully",
+                        "description": "Deficiency reopened successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1731,8 +1960,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Ship not found",
+                        "description": "Deficiency not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Deficiency is not rectified",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1746,14 +1987,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users": {
-            "delete": {
+        "/api/deficiencies/{deficiencyId}/verify": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Logout current authenticated user",
+                "description": "Confirm the corrective action of a rectified deficiency, which closes it",
                 "consumes": [
                     "application/json"
                 ],
@@ -1761,12 +2002,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Deficiencies"
+                ],
+                "summary": "Verify deficiency",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deficiency ID",
+                        "name": "deficiencyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Verify deficiency request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.VerifyDeficiencyRequest"
+                        }
+                    }
                 ],
-                "summary": "User logout",
                 "responses": {
                     "200": {
-                        "description": "Logout successful",
+                        "description": "Deficiency verified successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1777,6 +2042,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Deficiency not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Deficiency is not rectified",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1786,14 +2069,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/_current": {
+        "/api/harbors": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get current authenticated user information",
+                "description": "Get list of harbors granted to any of the caller's roles with optional filtering",
                 "consumes": [
                     "application/json"
                 ],
@@ -1801,14 +2084,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Harbors"
+                ],
+                "summary": "List harbors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by harbor name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by country",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by province",
+                        "name": "province",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by city",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Filter by active status",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get current user",
                 "responses": {
                     "200": {
-                        "description": "Current user details",
+                        "description": "List of harbors",
                         "schema": {
-                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                            "$ref": "#/definitions/model.SwaggerPageResponse"
                         }
                     },
                     "401": {
@@ -1817,6 +2147,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1825,13 +2161,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update current authenticated user information",
+                "description": "Create a new harbor with detailed information, the harbor is granted to every role of the creator",
                 "consumes": [
                     "application/json"
                 ],
@@ -1839,23 +2175,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Harbors"
                 ],
-                "summary": "Update current user",
+                "summary": "Create a new harbor",
                 "parameters": [
                     {
-                        "description": "Update user request",
+                        "description": "Create harbor request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateUserRequest"
+                            "$ref": "#/definitions/model.CreateHarborRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User updated successfully",
+                        "description": "Harbor created successfully",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1872,6 +2208,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1881,14 +2223,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/roles/{roleId}": {
+        "/api/harbors/{harborId}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of users by role ID",
+                "description": "Get harbor details by harbor ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1896,27 +2238,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Harbors"
                 ],
-                "summary": "Get users by role ID",
+                "summary": "Get harbor by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Role ID",
-                        "name": "roleId",
+                        "description": "Harbor ID",
+                        "name": "harborId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Users retrieved successfully",
+                        "description": "Harbor details",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Harbor not found or not granted to the caller's roles",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1928,13 +2282,4982 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/login": {
-            "post": {
-                "description": "Authenticate user with username/email and password",
-                "consumes": [
-                    "application/json"
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update harbor information by harbor ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harbors"
+                ],
+                "summary": "Update harbor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Harbor ID",
+                        "name": "harborId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update harbor request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateHarborRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Harbor updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Harbor not found or not granted to the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete harbor by harbor ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Harbors"
+                ],
+                "summary": "Delete harbor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Harbor ID",
+                        "name": "harborId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Harbor deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Harbor not found or not granted to the caller's roles",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Harbor has boardings, port calls or berths",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/harbors/{harborId}/berths": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all berths of a harbor ordered by code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Berths"
+                ],
+                "summary": "List harbor berths",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Harbor ID",
+                        "name": "harborId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of berths",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Harbor not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a berth of a harbor with its length and depth in meters and the ship types it takes. A berth without ship types takes any ship",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Berths"
+                ],
+                "summary": "Register berth",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Harbor ID",
+                        "name": "harborId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create berth request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateBerthRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Berth created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Harbor not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Berth code already exists in the harbor",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/harbors/{harborId}/port-calls": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the port calls of a harbor. Ships in port are listed by latest arrival, expected ships by earliest ETA and departed ships by latest departure",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Port Calls"
+                ],
+                "summary": "List harbor port calls",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Harbor ID",
+                        "name": "harborId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (expected, in_port, departed, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ship ID",
+                        "name": "ship_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of port calls",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerPageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Harbor not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/operators": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of operators with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operators"
+                ],
+                "summary": "List operators",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by company name",
+                        "name": "company_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by operator type",
+                        "name": "operator_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by country",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by province",
+                        "name": "province",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by city",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Filter by active status",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of operators",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerPageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new operator with company information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operators"
+                ],
+                "summary": "Create a new operator",
+                "parameters": [
+                    {
+                        "description": "Create operator request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateOperatorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Operator created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/operators/{operatorId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get operator details by operator ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operators"
+                ],
+                "summary": "Get operator by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operator ID",
+                        "name": "operatorId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Operator details",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Operator not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update operator information by operator ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operators"
+                ],
+                "summary": "Update operator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operator ID",
+                        "name": "operatorId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update operator request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateOperatorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Operator updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Operator not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete operator by operator ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operators"
+                ],
+                "summary": "Delete operator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operator ID",
+                        "name": "operatorId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Operator deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Operator not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of permissions with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Permissions"
+                ],
+                "summary": "List permissions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by permission name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by resource",
+                        "name": "resource",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Filter by active status",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of permissions",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerPageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new permission with name, resource, and action",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Permissions"
+                ],
+                "summary": "Create a new permission",
+                "parameters": [
+                    {
+                        "description": "Create permission request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreatePermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Permission created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/permissions/{permissionId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get permission details by permission ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Permissions"
+                ],
+                "summary": "Get permission by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Permission ID",
+                        "name": "permissionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Permission details",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Permission not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update permission information by permission ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Permissions"
+                ],
+                "summary": "Update permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Permission ID",
+                        "name": "permissionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update permission request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdatePermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Permission updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Permission not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete permission by permission ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Permissions"
+                ],
+                "summary": "Delete permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Permission ID",
+                        "name": "permissionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Permission deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Permission not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/port-calls": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Declare the expected visit of a ship to a harbor with its ETA, ETD, previous and next port and purpose. Times are Unix milliseconds",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Port Calls"
+                ],
+                "summary": "Declare port call",
+                "parameters": [
+                    {
+                        "description": "Create port call request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreatePortCallRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Port call declared successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Ship or harbor not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/port-calls/{portCallId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a port call with its ship and harbor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Port Calls"
+                ],
+                "summary": "Get port call",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Port call ID",
+                        "name": "portCallId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Port call details",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Port call not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the schedule, previous and next port, purpose or remarks of a port call that is expected or in port",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Port Calls"
+                ],
+                "summary": "Update port call",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Port call ID",
+                        "name": "portCallId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update port call request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdatePortCallRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Port call updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Port call not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Port call is closed",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/port-calls/{portCallId}/arrive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record that an expected ship arrived in port. The ATA defaults to now, a ship can only be in port at one harbor at a time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Port Calls"
+                ],
+                "summary": "Record arrival",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Port call ID",
+                        "name": "portCallId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Arrive port call request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ArrivePortCallRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Arrival recorded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Port call not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Port call is not expected or ship is in port elsewhere",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/port-calls/{portCallId}/berth-allocations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the berths allocated to a port call in the order of their windows",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Berths"
+                ],
+                "summary": "List port call berth allocations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Port call ID",
+                        "name": "portCallId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of berth allocations",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Port call not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allocate a berth of the harbor to a port call for a time window. The window starts at the ATA or ETA and ends at the ETD unless given. The berth must be active, take the length, draft and type of the ship and be free during the window",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Berths"
+                ],
+                "summary": "Allocate berth",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Port call ID",
+                        "name": "portCallId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Allocate berth request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AllocateBerthRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Berth allocated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Port call or berth not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Berth does not take the ship or is already allocated",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/port-calls/{portCallId}/berth-suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the active berths of the harbor that take the ship of a port call and are free during the window, the tightest fit first. The window defaults to the ATA or ETA until the ETD (Unix milliseconds)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Berths"
+                ],
+                "summary": "Suggest berths",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Port call ID",
+                        "name": "portCallId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start of the window",
+                        "name": "starts_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End of the window",
+                        "name": "ends_at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of free berths",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Port call not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Port call is closed",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/port-calls/{portCallId}/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close a port call. A ship in port departs at the ATD which defaults to now, an expected call is cancelled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Port Calls"
+                ],
+                "summary": "Close port call",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Port call ID",
+                        "name": "portCallId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Close port call request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ClosePortCallRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Port call closed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Port call not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Port call is already closed",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of roles with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "List roles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by role name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Filter by active status",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of roles",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerPageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new role with name and description",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Create a new role",
+                "parameters": [
+                    {
+                        "description": "Create role request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Parent role not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Role already exists",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/roles/{roleId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get role details by role ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get role by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role details",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update role information by role ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Update role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update role request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete role by role ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Delete role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/roles/{roleId}/effective-permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Resolve the permissions holders of the role get, including the ones inherited from its parent roles, with wildcard grants expanded to the existing permissions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get effective permissions of role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Effective permissions",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/roles/{roleId}/harbors": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Grant harbors to a role, holders of the role can then see and manage them. Only harbors granted to one of the caller's roles can be assigned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Assign harbors to role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assign harbors request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AssignHarborsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Harbors assigned successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role or harbor not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Take harbors away from a role. Only harbors granted to one of the caller's roles can be removed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Remove harbors from role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Remove harbors request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RemoveHarborsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Harbors removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role or harbor not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/roles/{roleId}/permissions": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the whole permission set of a role, permissions missing from the list are removed and an empty list removes all of them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Replace permissions of role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Set permissions request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SetPermissionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Permissions replaced successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role or permission not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assign multiple permissions to a specific role, permissions the role already has are skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Assign permissions to role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assign permissions request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AssignPermissionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Permissions assigned successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role or permission not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove multiple permissions from a specific role, permissions the role does not have are skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Remove permissions from role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Remove permissions request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RemovePermissionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Permissions removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service-accounts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of service accounts with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "List service accounts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by active status",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of service accounts",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerPageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a service account for machine-to-machine access, it authenticates with API keys issued separately",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Create a new service account",
+                "parameters": [
+                    {
+                        "description": "Create service account request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateServiceAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Service account created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Service account name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service-accounts/{serviceAccountId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get service account details by service account ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Get service account by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account ID",
+                        "name": "serviceAccountId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service account details",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service account not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete a service account and revoke all of its API keys",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Delete service account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account ID",
+                        "name": "serviceAccountId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service account deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service account not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service-accounts/{serviceAccountId}/keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the API keys of a service account including revoked and expired keys, the key secret is never returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "List API keys",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account ID",
+                        "name": "serviceAccountId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API keys retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service account not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue an API key for a service account scoped to the given permissions. The caller must hold every requested permission. The plain key is only returned in this response",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Issue an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account ID",
+                        "name": "serviceAccountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create API key request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "API key created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service account not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service-accounts/{serviceAccountId}/keys/{keyId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke an API key of a service account, requests with the key are rejected immediately",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Service Accounts"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service account ID",
+                        "name": "serviceAccountId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "API key revoked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ships": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of ships with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ships"
+                ],
+                "summary": "List ships",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by operator ID",
+                        "name": "operator_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ship name",
+                        "name": "ship_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by flag state",
+                        "name": "flag_state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ship type",
+                        "name": "ship_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Filter by active status",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of ships",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerPageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new ship with detailed information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ships"
+                ],
+                "summary": "Create a new ship",
+                "parameters": [
+                    {
+                        "description": "Create ship request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateShipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ship created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Operator not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Ship already exists",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ships/{shipId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get ship details by ship ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ships"
+                ],
+                "summary": "Get ship by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ship ID",
+                        "name": "shipId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ship details",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Ship not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update ship information by ship ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ships"
+                ],
+                "summary": "Update ship",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ship ID",
+                        "name": "shipId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update ship request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateShipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ship updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Ship not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete ship by ship ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ships"
+                ],
+                "summary": "Delete ship",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ship ID",
+                        "name": "shipId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ship deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Ship not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Ship has boardings or port calls",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ships/{shipId}/deficiencies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the deficiencies of a ship with the nearest deadline first. Without a status only the deficiencies that are not verified yet are listed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Deficiencies"
+                ],
+                "summary": "List ship deficiencies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ship ID",
+                        "name": "shipId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (open, rectified, verified, overdue)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of deficiencies",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerPageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Ship not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of users with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by username",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by active status",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by verification status",
+                        "name": "is_verified",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of users",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerPageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Logout current authenticated user. An impersonation token only revokes itself and leaves the sessions of the user untouched",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "User logout",
+                "responses": {
+                    "200": {
+                        "description": "Logout successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/_current": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get current authenticated user information, while impersonating the admin acting as the user is listed in impersonated_by",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get current user",
+                "responses": {
+                    "200": {
+                        "description": "Current user details",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update current authenticated user information, the password is changed with PATCH /api/users/_current/password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update current user",
+                "parameters": [
+                    {
+                        "description": "Update user request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/_current/2fa": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a TOTP secret for the current user. Add it to an authenticator app (the otpauth URI can be rendered as a QR code) and confirm it with a code to enable two-factor authentication",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Start two-factor enrollment",
+                "responses": {
+                    "200": {
+                        "description": "Two-factor enrollment started",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication is already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Disable two-factor authentication of the current user, requires the password and a TOTP or recovery code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Disable two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "Disable two-factor request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.DisableTwoFactorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Two-factor authentication disabled",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Not enabled or incorrect password or code",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/_current/2fa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirm the pending enrollment with a code from the authenticator app. Two-factor authentication is enabled and a set of single-use recovery codes is returned, they are not shown again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Confirm two-factor enrollment",
+                "parameters": [
+                    {
+                        "description": "Confirm two-factor request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ConfirmTwoFactorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Two-factor authentication enabled",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "No pending enrollment or invalid code",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/_current/2fa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace all recovery codes of the current user, requires a TOTP or recovery code. The previous codes stop working",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Regenerate recovery codes",
+                "parameters": [
+                    {
+                        "description": "Regenerate recovery codes request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RegenerateRecoveryCodesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recovery codes regenerated",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Not enabled or invalid code",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/_current/password": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the password of the current authenticated user. The new password has to satisfy the password policy and must not match a recently used one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change current user password",
+                "parameters": [
+                    {
+                        "description": "Change password request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Incorrect current password or password policy violation",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/_current/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Resolve what the current user may do: roles, granted permissions including inherited and wildcard grants, the expanded permission names, the harbor scope and the operator the user's ships are limited to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get current user permissions",
+                "responses": {
+                    "200": {
+                        "description": "Permissions retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/_current/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List active login sessions of the current authenticated user, the session of the presented token is flagged as current",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List current user sessions",
+                "responses": {
+                    "200": {
+                        "description": "Sessions retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/_current/sessions/{sessionId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke one login session of the current authenticated user, its refresh tokens and access tokens stop working immediately",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Revoke current user session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session revoked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/roles/{roleId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of users by role ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get users by role ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{userId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get user details, including roles, by user ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User details",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete a user and revoke all of its sessions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Activate or deactivate a user and force the email verification status. Deactivating a user revokes all of its sessions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update user request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AdminUpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{userId}/2fa": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Disable two-factor authentication of a user who lost the authenticator and the recovery codes, the user can enroll again afterwards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Reset user two-factor authentication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Two-factor authentication reset successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{userId}/impersonate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a short-lived token to act as another user, for example to reproduce what an operator user sees. The token carries the admin in the act claim, can not be refreshed and every write made with it is recorded in the impersonation audit log together with the admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Impersonate user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Impersonate user request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ImpersonateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Impersonation started successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{userId}/roles": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Grant one or more roles to a user, roles the user already has are left untouched. The caller must hold every permission of the roles",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Assign roles to user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assign roles request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AssignRolesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Roles assigned successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden or role grants permissions the caller does not hold",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User or role not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke one or more roles from a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Remove roles from user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Remove roles request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RemoveRolesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Roles removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{userId}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clear the failed login counter of a user so a locked account can log in again right away",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Unlock user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User unlocked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Authenticate user with username/email and password. Repeated failures per account and per client address are throttled with exponential backoff and a temporary lockout. Accounts with two-factor authentication get a two_factor_token instead of tokens and finish at /login/2fa",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "Login user request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed login attempts",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/2fa": {
+            "post": {
+                "description": "Submit the two_factor_token returned by /login together with a TOTP code or an unused recovery code to finish the login. Wrong codes count as failed logins",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Complete a two-factor login",
+                "parameters": [
+                    {
+                        "description": "Two-factor login request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginTwoFactorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired challenge or code",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed login attempts",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oidc/callback": {
+            "get": {
+                "description": "Redirect target of the identity provider. The authorization code is exchanged, the identity is mapped to a user (and its groups to roles) and the usual token pair is issued. Accounts with two-factor authentication get a two_factor_token and finish at /login/2fa",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Finish an identity provider login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State of the started login",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Login rejected by the identity provider or invalid state",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "No account for this identity",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "OIDC login is not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oidc/login": {
+            "get": {
+                "description": "Redirect to the OpenID Connect identity provider (authorization code flow with PKCE). The provider sends the user back to /oidc/callback",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Start an identity provider login",
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the identity provider"
+                    },
+                    "404": {
+                        "description": "OIDC login is not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Identity provider is not available",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password/forgot": {
+            "post": {
+                "description": "Mail a single-use password reset token valid for one hour. The response is the same whether or not the address belongs to an account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Request password reset",
+                "parameters": [
+                    {
+                        "description": "Forgot password request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Password reset email sent",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password/reset": {
+            "post": {
+                "description": "Set a new password with a password reset token. All sessions of the user are revoked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Reset password request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password reset successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired reset token, or password policy violation",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Register a new user with username, email, and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "Register user request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RegisterUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User registered successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request or password policy violation",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/token/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access/refresh token pair. The presented refresh token is invalidated and replaying it revokes the whole token family",
+                "consumes": [
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -1942,21 +7265,21 @@ const docTemplate = `{
                 "tags": [
                     "Auth"
                 ],
-                "summary": "User login",
+                "summary": "Refresh access token",
                 "parameters": [
                     {
-                        "description": "Login user request",
+                        "description": "Refresh token request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.LoginUserRequest"
+                            "$ref": "#/definitions/request.RefreshTokenRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful",
+                        "description": "Token refreshed successfully",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1968,7 +7291,7 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials",
+                        "description": "Invalid refresh token",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -1982,9 +7305,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/register": {
+        "/verify-email": {
             "post": {
-                "description": "Register a new user with username, email, and password",
+                "description": "Confirm the email address of a user with the token sent on registration. Tokens expire after 24 hours and can be used once",
                 "consumes": [
                     "application/json"
                 ],
@@ -1994,21 +7317,67 @@ const docTemplate = `{
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Register a new user",
+                "summary": "Verify email address",
                 "parameters": [
                     {
-                        "description": "Register user request",
+                        "description": "Verify email request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.RegisterUserRequest"
+                            "$ref": "#/definitions/request.VerifyEmailRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User registered successfully",
+                        "description": "Email verified successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired verification token",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.SwaggerWebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify-email/resend": {
+            "post": {
+                "description": "Send a new verification token to the given address. The response is the same whether or not the address belongs to an unverified account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Resend verification email",
+                "parameters": [
+                    {
+                        "description": "Resend verification request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Verification email sent",
                         "schema": {
                             "$ref": "#/definitions/model.SwaggerWebResponse"
                         }
@@ -2027,20 +7396,390 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.AssignPermissionsRequest": {
+        }
+    },
+    "definitions": {
+        "model.AbortBoardingRequest": {
+            "type": "object",
+            "required": [
+                "remarks"
+            ],
+            "properties": {
+                "remarks": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "model.AllocateBerthRequest": {
+            "type": "object",
+            "required": [
+                "berth_id"
+            ],
+            "properties": {
+                "berth_id": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "remarks": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "starts_at": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "model.AnswerBoardingChecklistRequest": {
+            "type": "object",
+            "required": [
+                "answers"
+            ],
+            "properties": {
+                "answers": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.ChecklistAnswerRequest"
+                    }
+                }
+            }
+        },
+        "model.ArrivePortCallRequest": {
+            "type": "object",
+            "properties": {
+                "ata": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "model.AssignHarborsRequest": {
+            "type": "object",
+            "required": [
+                "harbor_ids"
+            ],
+            "properties": {
+                "harbor_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.AssignPermissionsRequest": {
+            "type": "object",
+            "required": [
+                "permission_ids"
+            ],
+            "properties": {
+                "permission_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.AuthzCheckRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "user_id"
+            ],
+            "properties": {
+                "action": {
+                    "description": "Action is the ` + "`" + `resource.action` + "`" + ` permission, for example ` + "`" + `ship.destroy` + "`" + `",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "resource_id": {
+                    "description": "ResourceID is the record the action targets, ships, harbors, boardings, deficiencies, port calls and berths are\nchecked against the scope of the user",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "model.ChecklistAnswerRequest": {
+            "type": "object",
+            "required": [
+                "item_id"
+            ],
+            "properties": {
+                "item_id": {
+                    "type": "string"
+                },
+                "photo_url": {
+                    "type": "string",
+                    "maxLength": 2048
+                },
+                "remarks": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "value": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "model.ClosePortCallRequest": {
+            "type": "object",
+            "properties": {
+                "atd": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "remarks": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "model.CompleteBoardingRequest": {
+            "type": "object",
+            "required": [
+                "outcome"
+            ],
+            "properties": {
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "outcome": {
+                    "type": "string",
+                    "enum": [
+                        "satisfactory",
+                        "deficiencies",
+                        "detained"
+                    ]
+                },
+                "remarks": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "model.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "permissions"
+            ],
+            "properties": {
+                "expires_at": {
+                    "description": "Unix timestamp in seconds, keys without expiry stay valid until revoked",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "permissions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.CreateBerthRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "depth",
+                "length",
+                "name",
+                "ship_types"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "depth": {
+                    "type": "number"
+                },
+                "length": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "ship_types": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.CreateBoardingRequest": {
+            "type": "object",
+            "required": [
+                "harbor_id",
+                "planned_at",
+                "ship_id"
+            ],
+            "properties": {
+                "harbor_id": {
+                    "type": "string"
+                },
+                "inspector_id": {
+                    "description": "InspectorID defaults to the current user",
+                    "type": "string"
+                },
+                "planned_at": {
+                    "description": "PlannedAt is the planned start in Unix milliseconds",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "remarks": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "ship_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateChecklistItemRequest": {
+            "type": "object",
+            "required": [
+                "answer_type",
+                "prompt"
+            ],
+            "properties": {
+                "answer_type": {
+                    "description": "AnswerType is yes_no, yes_no_na, numeric or photo, a photo answer needs a photo_url",
+                    "type": "string",
+                    "enum": [
+                        "yes_no",
+                        "yes_no_na",
+                        "numeric",
+                        "photo"
+                    ]
+                },
+                "guidance": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "is_required": {
+                    "description": "IsRequired defaults to true, a boarding can only be completed once every required item is answered",
+                    "type": "boolean"
+                },
+                "prompt": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "model.CreateChecklistSectionRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "title"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 200,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.CreateChecklistItemRequest"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "model.CreateChecklistTemplateRequest": {
             "type": "object",
             "required": [
-                "permission_ids"
+                "name",
+                "sections",
+                "ship_type"
             ],
             "properties": {
-                "permission_ids": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "sections": {
                     "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/model.CreateChecklistSectionRequest"
                     }
+                },
+                "ship_type": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "model.CreateDeficiencyRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "description",
+                "severity"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "deadline": {
+                    "description": "Deadline is the date the corrective action is due in Unix milliseconds",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "is_detainable": {
+                    "type": "boolean"
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "minor",
+                        "major",
+                        "critical"
+                    ]
                 }
             }
         },
@@ -2325,6 +8064,55 @@ const docTemplate = `{
                 }
             }
         },
+        "model.CreatePortCallRequest": {
+            "type": "object",
+            "required": [
+                "eta",
+                "harbor_id",
+                "purpose",
+                "ship_id"
+            ],
+            "properties": {
+                "eta": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "etd": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "harbor_id": {
+                    "type": "string"
+                },
+                "next_port": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "previous_port": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "purpose": {
+                    "type": "string",
+                    "enum": [
+                        "cargo",
+                        "passengers",
+                        "bunkering",
+                        "crew_change",
+                        "repairs",
+                        "supplies",
+                        "other"
+                    ]
+                },
+                "remarks": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "ship_id": {
+                    "type": "string"
+                }
+            }
+        },
         "model.CreateRoleRequest": {
             "type": "object",
             "required": [
@@ -2343,6 +8131,25 @@ const docTemplate = `{
                 "is_active": {
                     "type": "boolean"
                 },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateServiceAccountRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
                 "name": {
                     "type": "string",
                     "maxLength": 100
@@ -2467,6 +8274,23 @@ const docTemplate = `{
                 }
             }
         },
+        "model.LoginTwoFactorRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "two_factor_token"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "two_factor_token": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
         "model.LoginUserRequest": {
             "type": "object",
             "required": [
@@ -2507,6 +8331,33 @@ const docTemplate = `{
                 }
             }
         },
+        "model.RectifyDeficiencyRequest": {
+            "type": "object",
+            "required": [
+                "notes"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "model.RemoveHarborsRequest": {
+            "type": "object",
+            "required": [
+                "harbor_ids"
+            ],
+            "properties": {
+                "harbor_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "model.RemovePermissionsRequest": {
             "type": "object",
             "required": [
@@ -2521,42 +8372,182 @@ const docTemplate = `{
                 }
             }
         },
-        "model.SwaggerPageResponse": {
+        "model.ReopenDeficiencyRequest": {
+            "type": "object",
+            "required": [
+                "notes"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "model.SetPermissionsRequest": {
+            "type": "object",
+            "required": [
+                "permission_ids"
+            ],
+            "properties": {
+                "permission_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.StartBoardingRequest": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                }
+            }
+        },
+        "model.SwaggerPageResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {}
+                },
+                "errors": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PageMetadata"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.SwaggerWebResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "errors": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.PageMetadata"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.UpdateBerthRequest": {
+            "type": "object",
+            "required": [
+                "ship_types"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "depth": {
+                    "type": "number"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "length": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "ship_types": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.UpdateBoardingRequest": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {}
-                },
-                "errors": {
+                "harbor_id": {
                     "type": "string"
                 },
-                "message": {
+                "inspector_id": {
                     "type": "string"
                 },
-                "meta": {
-                    "$ref": "#/definitions/model.PageMetadata"
+                "planned_at": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "success": {
+                "remarks": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "model.UpdateChecklistTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "is_active": {
                     "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "model.SwaggerWebResponse": {
+        "model.UpdateDeficiencyRequest": {
             "type": "object",
             "properties": {
-                "data": {},
-                "errors": {
-                    "type": "string"
+                "code": {
+                    "type": "string",
+                    "maxLength": 20
                 },
-                "message": {
-                    "type": "string"
+                "deadline": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "meta": {
-                    "$ref": "#/definitions/model.PageMetadata"
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000
                 },
-                "success": {
+                "is_detainable": {
                     "type": "boolean"
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "minor",
+                        "major",
+                        "critical"
+                    ]
                 }
             }
         },
@@ -2824,6 +8815,43 @@ const docTemplate = `{
                 }
             }
         },
+        "model.UpdatePortCallRequest": {
+            "type": "object",
+            "properties": {
+                "eta": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "etd": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "next_port": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "previous_port": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "purpose": {
+                    "type": "string",
+                    "enum": [
+                        "cargo",
+                        "passengers",
+                        "bunkering",
+                        "crew_change",
+                        "repairs",
+                        "supplies",
+                        "other"
+                    ]
+                },
+                "remarks": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
         "model.UpdateRoleRequest": {
             "type": "object",
             "properties": {
@@ -2841,6 +8869,9 @@ const docTemplate = `{
                 "name": {
                     "type": "string",
                     "maxLength": 100
+                },
+                "parent_id": {
+                    "type": "string"
                 }
             }
         },
@@ -2963,6 +8994,134 @@ const docTemplate = `{
                 }
             }
         },
+        "model.VerifyDeficiencyRequest": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "request.AdminUpdateUserRequest": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "request.AssignRolesRequest": {
+            "type": "object",
+            "required": [
+                "role_ids"
+            ],
+            "properties": {
+                "role_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 8
+                }
+            }
+        },
+        "request.ConfirmTwoFactorRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.DisableTwoFactorRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "request.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "request.ImpersonateUserRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "request.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request.RegenerateRecoveryCodesRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
         "request.RegisterUserRequest": {
             "type": "object",
             "required": [
@@ -2998,6 +9157,50 @@ const docTemplate = `{
                 }
             }
         },
+        "request.RemoveRolesRequest": {
+            "type": "object",
+            "required": [
+                "role_ids"
+            ],
+            "properties": {
+                "role_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.ResendVerificationRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "request.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 8
+                },
+                "token": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
         "request.UpdateUserRequest": {
             "type": "object",
             "properties": {
@@ -3020,11 +9223,6 @@ const docTemplate = `{
                     "type": "string",
                     "maxLength": 100
                 },
-                "password": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 8
-                },
                 "phone": {
                     "type": "string",
                     "maxLength": 20
@@ -3034,9 +9232,70 @@ const docTemplate = `{
                     "maxLength": 100
                 }
             }
+        },
+        "request.VerifyEmailRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "service.JSONWebKey": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                },
+                "y": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.JSONWebKeySet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.JSONWebKey"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "Service account API key, accepted instead of a bearer token.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "description": "Type \"Bearer\" followed by a space and JWT token.",
             "type": "apiKey",
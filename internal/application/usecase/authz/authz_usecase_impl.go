@@ -0,0 +1,352 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/domain/repository"
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/pkg/service"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type AuthzUseCaseImpl struct {
+	DB                   *gorm.DB
+	Log                  *logrus.Logger
+	Validate             *validator.Validate
+	UserRepository       repository.UserRepository
+	UserRoleRepository   repository.UserRoleRepository
+	PermissionRepository repository.PermissionRepository
+	HarborRepository     repository.HarborRepository
+	OperatorRepository   repository.OperatorRepository
+	ShipRepository       repository.ShipRepository
+	BoardingRepository   repository.BoardingRepository
+	DeficiencyRepository repository.DeficiencyRepository
+	PortCallRepository   repository.PortCallRepository
+	BerthRepository      repository.BerthRepository
+	TwoFactorPolicy      *service.TwoFactorPolicy
+}
+
+func NewAuthzUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
+	userRepository repository.UserRepository, userRoleRepository repository.UserRoleRepository,
+	permissionRepository repository.PermissionRepository, harborRepository repository.HarborRepository,
+	operatorRepository repository.OperatorRepository, shipRepository repository.ShipRepository,
+	boardingRepository repository.BoardingRepository, deficiencyRepository repository.DeficiencyRepository,
+	portCallRepository repository.PortCallRepository, berthRepository repository.BerthRepository,
+	twoFactorPolicy *service.TwoFactorPolicy) usecase.AuthzUseCase {
+	return &AuthzUseCaseImpl{
+		DB:                   db,
+		Log:                  logger,
+		Validate:             validate,
+		UserRepository:       userRepository,
+		UserRoleRepository:   userRoleRepository,
+		PermissionRepository: permissionRepository,
+		HarborRepository:     harborRepository,
+		OperatorRepository:   operatorRepository,
+		ShipRepository:       shipRepository,
+		BoardingRepository:   boardingRepository,
+		DeficiencyRepository: deficiencyRepository,
+		PortCallRepository:   portCallRepository,
+		BerthRepository:      berthRepository,
+		TwoFactorPolicy:      twoFactorPolicy,
+	}
+}
+
+func (c *AuthzUseCaseImpl) CurrentPermissions(ctx context.Context, request *model.CurrentPermissionsRequest) (*model.CurrentPermissionsResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserID); err != nil || user.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find user")
+		return nil, fiber.ErrNotFound
+	}
+
+	userRoles, err := c.UserRoleRepository.FindAllByUserID(tx, user.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find user roles")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	granted, err := c.PermissionRepository.FindAllByUserID(tx, user.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find user permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	catalog, err := c.PermissionRepository.FindAllActive(tx)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	var harborIDs []string
+	if err := tx.Model(&entity.Harbor{}).
+		Where("deleted_at IS NULL AND id IN (?)", c.HarborRepository.SelectIDsInScope(tx, user.ID)).
+		Order("id").
+		Pluck("id", &harborIDs).Error; err != nil {
+		c.Log.WithError(err).Error("failed to find user harbors")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	operator := new(entity.Operator)
+	if err := c.OperatorRepository.FindByUserID(tx, operator, user.ID); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.Log.WithError(err).Error("failed to find operator of user")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	roles := make([]string, 0, len(userRoles))
+	for _, userRole := range userRoles {
+		if userRole.Role.IsActive && userRole.Role.DeletedAt == nil {
+			roles = append(roles, userRole.Role.Name)
+		}
+	}
+
+	grants := permissionNames(granted)
+	permissions := service.NewPermissionSet(grants).Expand(permissionNames(catalog))
+	sort.Strings(roles)
+	sort.Strings(grants)
+	sort.Strings(permissions)
+
+	response := &model.CurrentPermissionsResponse{
+		Roles:                       roles,
+		Grants:                      grants,
+		Permissions:                 permissions,
+		HarborIDs:                   harborIDs,
+		TwoFactorEnrollmentRequired: !user.TwoFactorEnabled && c.TwoFactorPolicy.RequiresEnrollment(grants),
+	}
+	if operator.ID != "" {
+		response.OperatorID = &operator.ID
+	}
+
+	return response, nil
+}
+
+// Check replays the decision the API takes for the user, role permissions first, then the two-factor policy and
+// finally the record scope of ships, harbors and the records that belong to them
+func (c *AuthzUseCaseImpl) Check(ctx context.Context, request *model.AuthzCheckRequest) (*model.AuthzCheckResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserID); err != nil || user.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find user")
+		return nil, fiber.NewError(fiber.StatusNotFound, "user not found")
+	}
+
+	response := &model.AuthzCheckResponse{
+		UserID:     user.ID,
+		Action:     request.Action,
+		ResourceID: request.ResourceID,
+		GrantedBy:  []model.AuthzGrantResponse{},
+		Steps:      []string{},
+	}
+	deny := func(reason string) (*model.AuthzCheckResponse, error) {
+		response.Reason = reason
+		response.Steps = append(response.Steps, reason)
+		return response, nil
+	}
+
+	if !user.IsActive {
+		return deny(fmt.Sprintf("denied: user %s is inactive and can not sign in", user.Username))
+	}
+
+	userRoles, err := c.UserRoleRepository.FindAllByUserID(tx, user.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find user roles")
+		return nil, fiber.ErrInternalServerError
+	}
+	if len(userRoles) == 0 {
+		return deny(fmt.Sprintf("denied: user %s has no roles", user.Username))
+	}
+
+	// Each role is resolved on its own, with its ancestors, to tell which of them grants the action
+	for _, userRole := range userRoles {
+		role := userRole.Role
+		if !role.IsActive || role.DeletedAt != nil {
+			response.Steps = append(response.Steps, fmt.Sprintf("role %s is inactive and grants nothing", role.Name))
+			continue
+		}
+
+		granted, err := c.PermissionRepository.FindAllByRoleID(tx, role.ID)
+		if err != nil {
+			c.Log.WithError(err).Error("failed to find role permissions")
+			return nil, fiber.ErrInternalServerError
+		}
+
+		if grant, ok := service.NewPermissionSet(permissionNames(granted)).Match(request.Action); ok {
+			response.GrantedBy = append(response.GrantedBy, model.AuthzGrantResponse{Role: role.Name, Grant: grant})
+			response.Steps = append(response.Steps, fmt.Sprintf("role %s grants %s through %s", role.Name, request.Action, grant))
+		} else {
+			response.Steps = append(response.Steps, fmt.Sprintf("role %s does not grant %s", role.Name, request.Action))
+		}
+	}
+
+	if len(response.GrantedBy) == 0 {
+		return deny(fmt.Sprintf("denied: no role of user %s grants %s, the API answers 403 Forbidden", user.Username, request.Action))
+	}
+
+	// The policy blocks every permission of a privileged user until two-factor authentication is enabled
+	if !user.TwoFactorEnabled && c.TwoFactorPolicy.MandatoryForPrivileged {
+		granted, err := c.PermissionRepository.FindAllByUserID(tx, user.ID)
+		if err != nil {
+			c.Log.WithError(err).Error("failed to find user permissions")
+			return nil, fiber.ErrInternalServerError
+		}
+		if c.TwoFactorPolicy.RequiresEnrollment(permissionNames(granted)) {
+			return deny(fmt.Sprintf("denied: user %s holds privileged permissions and must enable two-factor authentication first", user.Username))
+		}
+	}
+
+	if request.ResourceID != nil && *request.ResourceID != "" {
+		var reason string
+		allowed := true
+		switch resource, _, _ := strings.Cut(request.Action, "."); resource {
+		case "harbor":
+			reason, allowed, err = c.checkHarbor(tx, user.ID, *request.ResourceID)
+		case "ship":
+			reason, allowed, err = c.checkShip(tx, user.ID, *request.ResourceID)
+		case "boarding":
+			reason, allowed, err = c.checkBoarding(tx, user.ID, *request.ResourceID)
+		case "deficiency":
+			reason, allowed, err = c.checkDeficiency(tx, user.ID, *request.ResourceID)
+		case "port_call":
+			reason, allowed, err = c.checkPortCall(tx, user.ID, *request.ResourceID)
+		case "berth":
+			reason, allowed, err = c.checkBerth(tx, user.ID, *request.ResourceID)
+		case "user", "role", "permission", "operator", "checklist", "service_account", "authz":
+			reason = fmt.Sprintf("%s records are not scoped per user, the resource id is not checked", resource)
+		default:
+			reason = fmt.Sprintf("denied: the scope of %s records is not evaluated, the resource id can not be checked", resource)
+			allowed = false
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return deny(reason)
+		}
+		response.Steps = append(response.Steps, reason)
+	}
+
+	response.Allowed = true
+	response.Reason = fmt.Sprintf("allowed: %s is granted by role %s", request.Action, response.GrantedBy[0].Role)
+	response.Steps = append(response.Steps, response.Reason)
+	return response, nil
+}
+
+// checkHarbor explains the harbor scope of role_harbors
+func (c *AuthzUseCaseImpl) checkHarbor(tx *gorm.DB, userID string, harborID string) (string, bool, error) {
+	if err := c.HarborRepository.FindByIdInScope(tx, new(entity.Harbor), harborID, userID); err == nil {
+		return fmt.Sprintf("harbor %s is granted to a role of the user", harborID), true, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.Log.WithError(err).Error("failed to find harbor")
+		return "", false, fiber.ErrInternalServerError
+	}
+
+	harbor := new(entity.Harbor)
+	if err := c.HarborRepository.FindById(tx, harbor, harborID); err != nil || harbor.DeletedAt != nil {
+		return fmt.Sprintf("denied: harbor %s does not exist, the API answers 404 Not Found", harborID), false, nil
+	}
+	return fmt.Sprintf("denied: harbor %s is not granted to any role of the user, the API answers 404 Not Found", harborID), false, nil
+}
+
+// checkShip explains the operator tenancy of ships
+func (c *AuthzUseCaseImpl) checkShip(tx *gorm.DB, userID string, shipID string) (string, bool, error) {
+	ship := new(entity.Ship)
+	if err := c.ShipRepository.FindById(tx, ship, shipID); err != nil || ship.DeletedAt != nil {
+		return fmt.Sprintf("denied: ship %s does not exist, the API answers 404 Not Found", shipID), false, nil
+	}
+
+	if err := c.ShipRepository.FindByIdInScope(tx, new(entity.Ship), shipID, userID); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.WithError(err).Error("failed to find ship")
+			return "", false, fiber.ErrInternalServerError
+		}
+		return fmt.Sprintf("denied: ship %s belongs to operator %s outside the tenant scope of the user, the API answers 404 Not Found", shipID, ship.OperatorID), false, nil
+	}
+	return fmt.Sprintf("ship %s belongs to operator %s in the tenant scope of the user", shipID, ship.OperatorID), true, nil
+}
+
+// checkBoarding explains the scope of boardings, a boarding is visible while both its harbor and its ship are
+func (c *AuthzUseCaseImpl) checkBoarding(tx *gorm.DB, userID string, boardingID string) (string, bool, error) {
+	boarding := new(entity.Boarding)
+	if err := c.BoardingRepository.FindById(tx, boarding, boardingID); err != nil {
+		return fmt.Sprintf("denied: boarding %s does not exist, the API answers 404 Not Found", boardingID), false, nil
+	}
+	return c.checkRecord(tx, userID, "boarding", boardingID, boarding.HarborID, boarding.ShipID)
+}
+
+// checkDeficiency explains the scope of deficiencies, a deficiency is visible while its ship is
+func (c *AuthzUseCaseImpl) checkDeficiency(tx *gorm.DB, userID string, deficiencyID string) (string, bool, error) {
+	deficiency := new(entity.Deficiency)
+	if err := c.DeficiencyRepository.FindById(tx, deficiency, deficiencyID); err != nil {
+		return fmt.Sprintf("denied: deficiency %s does not exist, the API answers 404 Not Found", deficiencyID), false, nil
+	}
+	return c.checkRecord(tx, userID, "deficiency", deficiencyID, "", deficiency.ShipID)
+}
+
+// checkPortCall explains the scope of port calls, a port call is visible while both its harbor and its ship are
+func (c *AuthzUseCaseImpl) checkPortCall(tx *gorm.DB, userID string, portCallID string) (string, bool, error) {
+	portCall := new(entity.PortCall)
+	if err := c.PortCallRepository.FindById(tx, portCall, portCallID); err != nil {
+		return fmt.Sprintf("denied: port call %s does not exist, the API answers 404 Not Found", portCallID), false, nil
+	}
+	return c.checkRecord(tx, userID, "port call", portCallID, portCall.HarborID, portCall.ShipID)
+}
+
+// checkBerth explains the scope of berths, a berth is visible while its harbor is
+func (c *AuthzUseCaseImpl) checkBerth(tx *gorm.DB, userID string, berthID string) (string, bool, error) {
+	berth := new(entity.Berth)
+	if err := c.BerthRepository.FindById(tx, berth, berthID); err != nil {
+		return fmt.Sprintf("denied: berth %s does not exist, the API answers 404 Not Found", berthID), false, nil
+	}
+	return c.checkRecord(tx, userID, "berth", berthID, berth.HarborID, "")
+}
+
+// checkRecord explains the scope of a record that belongs to a harbor and/or a ship, it is visible while every one of
+// them is, an empty id is not checked
+func (c *AuthzUseCaseImpl) checkRecord(tx *gorm.DB, userID string, kind string, id string, harborID string, shipID string) (string, bool, error) {
+	reasons := make([]string, 0, 2)
+	for _, check := range []struct {
+		id    string
+		check func(tx *gorm.DB, userID string, id string) (string, bool, error)
+	}{{harborID, c.checkHarbor}, {shipID, c.checkShip}} {
+		if check.id == "" {
+			continue
+		}
+		reason, allowed, err := check.check(tx, userID, check.id)
+		if err != nil {
+			return "", false, err
+		}
+		if !allowed {
+			return fmt.Sprintf("denied: %s %s is out of scope, %s", kind, id, strings.TrimPrefix(reason, "denied: ")), false, nil
+		}
+		reasons = append(reasons, reason)
+	}
+	return fmt.Sprintf("%s %s is in scope, %s", kind, id, strings.Join(reasons, " and ")), true, nil
+}
+
+func permissionNames(permissions []entity.Permission) []string {
+	names := make([]string, len(permissions))
+	for i, permission := range permissions {
+		names[i] = permission.Name
+	}
+	return names
+}
@@ -0,0 +1,302 @@
+package berth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/internal/model/converter"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// window resolves the time window of an allocation for a port call, it starts at the ATA of a ship in port or the ETA
+// of an expected ship and ends at the ETD unless given
+func window(portCall *entity.PortCall, startsAt *int64, endsAt *int64) (int64, int64, error) {
+	start := portCall.ETA
+	if portCall.ATA != nil {
+		start = *portCall.ATA
+	}
+	if startsAt != nil {
+		start = *startsAt
+	}
+
+	if endsAt == nil && portCall.ETD == nil {
+		return 0, 0, fiber.NewError(fiber.StatusBadRequest, "ends_at is required when the port call has no etd")
+	}
+	end := portCall.ETD
+	if endsAt != nil {
+		end = endsAt
+	}
+
+	if *end <= start {
+		return 0, 0, fiber.NewError(fiber.StatusBadRequest, "ends_at must be after starts_at")
+	}
+	return start, *end, nil
+}
+
+// checkOpen makes sure berths are only allocated to ships that are expected or in port
+func checkOpen(portCall *entity.PortCall) error {
+	if portCall.Status != entity.PortCallStatusExpected && portCall.Status != entity.PortCallStatusInPort {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("a %s port call can not be allocated a berth", portCall.Status))
+	}
+	return nil
+}
+
+// checkFit makes sure the berth takes the ship, a length or draft the registry does not know does not rule it out
+func checkFit(berth *entity.Berth, ship *entity.Ship) error {
+	if ship.Length != nil && *ship.Length > berth.Length {
+		return fmt.Errorf("the ship is %.2f m long and berth %s only takes %.2f m", *ship.Length, berth.Code, berth.Length)
+	}
+	if ship.Draft != nil && *ship.Draft > berth.Depth {
+		return fmt.Errorf("the ship draws %.2f m and berth %s is only %.2f m deep", *ship.Draft, berth.Code, berth.Depth)
+	}
+
+	if len(berth.ShipTypes) == 0 {
+		return nil
+	}
+	for _, shipType := range berth.ShipTypes {
+		if strings.EqualFold(shipType.ShipType, ship.ShipType) {
+			return nil
+		}
+	}
+	return fmt.Errorf("berth %s does not take %s ships", berth.Code, ship.ShipType)
+}
+
+// Allocate reserves a berth of the harbor of a port call for a time window. The berth must be active, take the ship
+// and be free during the whole window.
+func (c *BerthUseCaseImpl) Allocate(ctx context.Context, request *model.AllocateBerthRequest, userId string) (*model.BerthAllocationResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	portCall := new(entity.PortCall)
+	if err := c.findPortCall(tx, portCall, request.PortCallID, userId); err != nil {
+		return nil, err
+	}
+
+	if err := checkOpen(portCall); err != nil {
+		return nil, err
+	}
+
+	startsAt, endsAt, err := window(portCall, request.StartsAt, request.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// The berth row stays locked until commit, concurrent allocations of one berth are checked one after the other
+	berth := new(entity.Berth)
+	if err := c.BerthRepository.FindByIdWithShipTypes(tx.Clauses(clause.Locking{Strength: "UPDATE"}), berth, request.BerthID); err != nil || berth.HarborID != portCall.HarborID {
+		c.Log.WithError(err).Error("failed to find berth")
+		return nil, fiber.NewError(fiber.StatusNotFound, "berth not found in the harbor of the port call")
+	}
+
+	if !berth.IsActive {
+		return nil, fiber.NewError(fiber.StatusConflict, "the berth is not active")
+	}
+
+	if err := checkFit(berth, &portCall.Ship); err != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, err.Error())
+	}
+
+	if count, err := c.BerthAllocationRepository.CountOverlapping(tx, berth.ID, startsAt, endsAt, ""); err != nil {
+		c.Log.WithError(err).Error("failed to count overlapping berth allocations")
+		return nil, fiber.ErrInternalServerError
+	} else if count > 0 {
+		return nil, fiber.NewError(fiber.StatusConflict, "the berth is already allocated during this window")
+	}
+
+	allocation := &entity.BerthAllocation{
+		ID:          uuid.NewString(),
+		BerthID:     berth.ID,
+		PortCallID:  portCall.ID,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		Remarks:     request.Remarks,
+		AllocatedBy: userId,
+	}
+
+	if err := c.BerthAllocationRepository.Create(tx, allocation); err != nil {
+		c.Log.WithError(err).Error("failed to create berth allocation")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	allocation.Berth = *berth
+	return converter.BerthAllocationToResponse(allocation), nil
+}
+
+// Release frees the berth of an allocation
+func (c *BerthUseCaseImpl) Release(ctx context.Context, request *model.ReleaseBerthAllocationRequest, userId string) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	allocation := new(entity.BerthAllocation)
+	if err := c.BerthAllocationRepository.FindById(tx, allocation, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find berth allocation")
+		return fiber.NewError(fiber.StatusNotFound, "berth allocation not found")
+	}
+
+	portCall := new(entity.PortCall)
+	if err := c.findPortCall(tx, portCall, allocation.PortCallID, userId); err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "berth allocation not found")
+	}
+
+	if err := c.BerthAllocationRepository.Delete(tx, allocation); err != nil {
+		c.Log.WithError(err).Error("failed to delete berth allocation")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// ListAllocationsByBerth lists the allocations of a berth with their port calls and ships, operator users only see
+// the allocations of their own fleet
+func (c *BerthUseCaseImpl) ListAllocationsByBerth(ctx context.Context, request *model.ListBerthAllocationRequest, userId string) ([]model.BerthAllocationResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	berth := new(entity.Berth)
+	if err := c.findBerth(tx, berth, request.BerthID, userId); err != nil {
+		return nil, err
+	}
+
+	allocations, err := c.BerthAllocationRepository.FindAllByBerthIDInScope(tx, berth.ID, request.From, request.To, userId)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find berth allocations")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.BerthAllocationResponse, 0, len(allocations))
+	for _, allocation := range allocations {
+		responses = append(responses, *converter.BerthAllocationToResponse(&allocation))
+	}
+
+	return responses, nil
+}
+
+// ListAllocationsByPortCall lists the berths allocated to a port call in the order of their windows
+func (c *BerthUseCaseImpl) ListAllocationsByPortCall(ctx context.Context, request *model.ListPortCallBerthAllocationRequest, userId string) ([]model.BerthAllocationResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	portCall := new(entity.PortCall)
+	if err := c.findPortCall(tx, portCall, request.PortCallID, userId); err != nil {
+		return nil, err
+	}
+
+	allocations, err := c.BerthAllocationRepository.FindAllByPortCallID(tx, portCall.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find berth allocations")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.BerthAllocationResponse, len(allocations))
+	for i, allocation := range allocations {
+		responses[i] = *converter.BerthAllocationToResponse(&allocation)
+	}
+
+	return responses, nil
+}
+
+// Suggest lists the active berths of the harbor of a port call that take the ship and are free during the window,
+// the tightest fit comes first so that larger berths stay available for larger ships
+func (c *BerthUseCaseImpl) Suggest(ctx context.Context, request *model.SuggestBerthRequest, userId string) ([]model.BerthResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	portCall := new(entity.PortCall)
+	if err := c.findPortCall(tx, portCall, request.PortCallID, userId); err != nil {
+		return nil, err
+	}
+
+	if err := checkOpen(portCall); err != nil {
+		return nil, err
+	}
+
+	startsAt, endsAt, err := window(portCall, request.StartsAt, request.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	berths, err := c.BerthRepository.FindAllByHarborID(tx, portCall.HarborID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find berths")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	candidates := make([]entity.Berth, 0, len(berths))
+	ids := make([]string, 0, len(berths))
+	for _, berth := range berths {
+		if berth.IsActive && checkFit(&berth, &portCall.Ship) == nil {
+			candidates = append(candidates, berth)
+			ids = append(ids, berth.ID)
+		}
+	}
+
+	busyIDs, err := c.BerthAllocationRepository.FindBusyBerthIDs(tx, ids, startsAt, endsAt)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find allocated berths")
+		return nil, fiber.ErrInternalServerError
+	}
+	busy := make(map[string]bool, len(busyIDs))
+	for _, id := range busyIDs {
+		busy[id] = true
+	}
+
+	free := make([]entity.Berth, 0, len(candidates))
+	for _, berth := range candidates {
+		if !busy[berth.ID] {
+			free = append(free, berth)
+		}
+	}
+
+	sort.SliceStable(free, func(i, j int) bool {
+		if free[i].Length != free[j].Length {
+			return free[i].Length < free[j].Length
+		}
+		return free[i].Depth < free[j].Depth
+	})
+
+	responses := make([]model.BerthResponse, len(free))
+	for i, berth := range free {
+		responses[i] = *converter.BerthToResponse(&berth)
+	}
+
+	return responses, nil
+}
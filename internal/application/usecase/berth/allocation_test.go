@@ -0,0 +1,121 @@
+package berth
+
+import (
+	"errors"
+	"testing"
+
+	"mkp-boarding-test/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		portCall  entity.PortCall
+		startsAt  *int64
+		endsAt    *int64
+		wantStart int64
+		wantEnd   int64
+		message   string
+	}{
+		{
+			name:      "eta to etd of the port call",
+			portCall:  entity.PortCall{ETA: 1000, ETD: ptr[int64](5000)},
+			wantStart: 1000,
+			wantEnd:   5000,
+		},
+		{
+			name:      "actual arrival replaces the eta",
+			portCall:  entity.PortCall{ETA: 1000, ATA: ptr[int64](1500), ETD: ptr[int64](5000)},
+			wantStart: 1500,
+			wantEnd:   5000,
+		},
+		{
+			name:      "requested window replaces the port call times",
+			portCall:  entity.PortCall{ETA: 1000, ATA: ptr[int64](1500), ETD: ptr[int64](5000)},
+			startsAt:  ptr[int64](2000),
+			endsAt:    ptr[int64](3000),
+			wantStart: 2000,
+			wantEnd:   3000,
+		},
+		{
+			name:      "requested end without an etd",
+			portCall:  entity.PortCall{ETA: 1000},
+			endsAt:    ptr[int64](3000),
+			wantStart: 1000,
+			wantEnd:   3000,
+		},
+		{
+			name:     "no end without an etd",
+			portCall: entity.PortCall{ETA: 1000},
+			message:  "ends_at is required when the port call has no etd",
+		},
+		{
+			name:     "empty window",
+			portCall: entity.PortCall{ETA: 1000, ETD: ptr[int64](1000)},
+			message:  "ends_at must be after starts_at",
+		},
+		{
+			name:     "end before the start",
+			portCall: entity.PortCall{ETA: 1000, ETD: ptr[int64](5000)},
+			startsAt: ptr[int64](6000),
+			message:  "ends_at must be after starts_at",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := window(&tt.portCall, tt.startsAt, tt.endsAt)
+			if tt.message != "" {
+				var fiberErr *fiber.Error
+				if !errors.As(err, &fiberErr) || fiberErr.Code != fiber.StatusBadRequest || fiberErr.Message != tt.message {
+					t.Fatalf("expected 400 %q, got %v", tt.message, err)
+				}
+				return
+			}
+			if err != nil || start != tt.wantStart || end != tt.wantEnd {
+				t.Fatalf("window = %d, %d, %v, want %d, %d", start, end, err, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestCheckFit(t *testing.T) {
+	berth := entity.Berth{
+		Code:      "B1",
+		Length:    200,
+		Depth:     12,
+		ShipTypes: []entity.BerthShipType{{ShipType: "tanker"}, {ShipType: "bulk_carrier"}},
+	}
+
+	tests := []struct {
+		name  string
+		berth entity.Berth
+		ship  entity.Ship
+		fits  bool
+	}{
+		{name: "ship within length and depth", berth: berth, ship: entity.Ship{ShipType: "tanker", Length: ptr(150.0), Draft: ptr(10.0)}, fits: true},
+		{name: "ship as long as the berth", berth: berth, ship: entity.Ship{ShipType: "tanker", Length: ptr(200.0)}, fits: true},
+		{name: "ship drawing the depth of the berth", berth: berth, ship: entity.Ship{ShipType: "tanker", Draft: ptr(12.0)}, fits: true},
+		{name: "ship longer than the berth", berth: berth, ship: entity.Ship{ShipType: "tanker", Length: ptr(200.5)}},
+		{name: "ship drawing more than the depth", berth: berth, ship: entity.Ship{ShipType: "tanker", Draft: ptr(12.1)}},
+		{name: "unknown dimensions", berth: berth, ship: entity.Ship{ShipType: "tanker"}, fits: true},
+		{name: "ship type in another case", berth: berth, ship: entity.Ship{ShipType: "Bulk_Carrier"}, fits: true},
+		{name: "ship type the berth does not take", berth: berth, ship: entity.Ship{ShipType: "passenger"}},
+		{name: "berth taking any ship type", berth: entity.Berth{Code: "B2", Length: 200, Depth: 12}, ship: entity.Ship{ShipType: "passenger"}, fits: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkFit(&tt.berth, &tt.ship)
+			if (err == nil) != tt.fits {
+				t.Fatalf("checkFit = %v, want fits %v", err, tt.fits)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package berth
+
+import (
+	"context"
+	"strings"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/domain/repository"
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/internal/model/converter"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type BerthUseCaseImpl struct {
+	DB                        *gorm.DB
+	Log                       *logrus.Logger
+	Validate                  *validator.Validate
+	BerthRepository           repository.BerthRepository
+	BerthAllocationRepository repository.BerthAllocationRepository
+	PortCallRepository        repository.PortCallRepository
+	ShipRepository            repository.ShipRepository
+	HarborRepository          repository.HarborRepository
+}
+
+func NewBerthUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate,
+	berthRepository repository.BerthRepository, berthAllocationRepository repository.BerthAllocationRepository,
+	portCallRepository repository.PortCallRepository, shipRepository repository.ShipRepository,
+	harborRepository repository.HarborRepository) usecase.BerthUseCase {
+	return &BerthUseCaseImpl{
+		DB:                        db,
+		Log:                       log,
+		Validate:                  validate,
+		BerthRepository:           berthRepository,
+		BerthAllocationRepository: berthAllocationRepository,
+		PortCallRepository:        portCallRepository,
+		ShipRepository:            shipRepository,
+		HarborRepository:          harborRepository,
+	}
+}
+
+// findBerth loads a berth with its ship types, a berth is only visible while its harbor is in the scope of the user
+func (c *BerthUseCaseImpl) findBerth(tx *gorm.DB, berth *entity.Berth, id string, userId string) error {
+	if err := c.BerthRepository.FindByIdWithShipTypes(tx, berth, id); err != nil {
+		c.Log.WithError(err).Error("failed to find berth")
+		return fiber.NewError(fiber.StatusNotFound, "berth not found")
+	}
+
+	harbor := new(entity.Harbor)
+	if err := c.HarborRepository.FindByIdInScope(tx, harbor, berth.HarborID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find harbor")
+		return fiber.NewError(fiber.StatusNotFound, "berth not found")
+	}
+	return nil
+}
+
+// findPortCall loads a port call with its ship and harbor, a port call is only visible while both its harbor and its
+// ship are in the scope of the user
+func (c *BerthUseCaseImpl) findPortCall(tx *gorm.DB, portCall *entity.PortCall, id string, userId string) error {
+	if err := c.PortCallRepository.FindById(tx, portCall, id); err != nil {
+		c.Log.WithError(err).Error("failed to find port call")
+		return fiber.NewError(fiber.StatusNotFound, "port call not found")
+	}
+
+	if err := c.HarborRepository.FindByIdInScope(tx, &portCall.Harbor, portCall.HarborID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find harbor")
+		return fiber.NewError(fiber.StatusNotFound, "port call not found")
+	}
+	if err := c.ShipRepository.FindByIdInScope(tx, &portCall.Ship, portCall.ShipID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find ship")
+		return fiber.NewError(fiber.StatusNotFound, "port call not found")
+	}
+	return nil
+}
+
+// checkCode makes sure the code of a berth is not used by another berth of the same harbor
+func (c *BerthUseCaseImpl) checkCode(tx *gorm.DB, harborID string, code string, excludeID string) error {
+	count, err := c.BerthRepository.CountByHarborIDAndCode(tx, harborID, code, excludeID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to count berths by code")
+		return fiber.ErrInternalServerError
+	}
+	if count > 0 {
+		return fiber.NewError(fiber.StatusConflict, "a berth with this code already exists in the harbor")
+	}
+	return nil
+}
+
+// shipTypes trims the ship types a berth takes and drops the ones listed twice regardless of case
+func shipTypes(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[strings.ToLower(value)] {
+			continue
+		}
+		seen[strings.ToLower(value)] = true
+		result = append(result, value)
+	}
+	return result
+}
+
+// Create registers a berth of a harbor granted to the user
+func (c *BerthUseCaseImpl) Create(ctx context.Context, request *model.CreateBerthRequest, userId string) (*model.BerthResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	harbor := new(entity.Harbor)
+	if err := c.HarborRepository.FindByIdInScope(tx, harbor, request.HarborID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find harbor")
+		return nil, fiber.NewError(fiber.StatusNotFound, "harbor not found")
+	}
+
+	if err := c.checkCode(tx, harbor.ID, request.Code, ""); err != nil {
+		return nil, err
+	}
+
+	berth := &entity.Berth{
+		ID:       uuid.NewString(),
+		HarborID: harbor.ID,
+		Code:     request.Code,
+		Name:     request.Name,
+		Length:   request.Length,
+		Depth:    request.Depth,
+		IsActive: true,
+		Notes:    request.Notes,
+	}
+
+	if err := c.BerthRepository.Create(tx, berth); err != nil {
+		c.Log.WithError(err).Error("failed to create berth")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.BerthRepository.ReplaceShipTypes(tx, berth.ID, shipTypes(request.ShipTypes)); err != nil {
+		c.Log.WithError(err).Error("failed to create berth ship types")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.BerthRepository.FindByIdWithShipTypes(tx, berth, berth.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find berth")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.BerthToResponse(berth), nil
+}
+
+// Update changes a berth, the allocations it already has are kept
+func (c *BerthUseCaseImpl) Update(ctx context.Context, request *model.UpdateBerthRequest, userId string) (*model.BerthResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	berth := new(entity.Berth)
+	if err := c.findBerth(tx, berth, request.ID, userId); err != nil {
+		return nil, err
+	}
+
+	if request.Code != nil && *request.Code != "" && *request.Code != berth.Code {
+		if err := c.checkCode(tx, berth.HarborID, *request.Code, berth.ID); err != nil {
+			return nil, err
+		}
+		berth.Code = *request.Code
+	}
+	if request.Name != nil && *request.Name != "" {
+		berth.Name = *request.Name
+	}
+	if request.Length != nil {
+		berth.Length = *request.Length
+	}
+	if request.Depth != nil {
+		berth.Depth = *request.Depth
+	}
+	if request.IsActive != nil {
+		berth.IsActive = *request.IsActive
+	}
+	if request.Notes != nil {
+		berth.Notes = request.Notes
+	}
+
+	if err := c.BerthRepository.Update(tx, berth); err != nil {
+		c.Log.WithError(err).Error("failed to update berth")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if request.ShipTypes != nil {
+		if err := c.BerthRepository.ReplaceShipTypes(tx, berth.ID, shipTypes(*request.ShipTypes)); err != nil {
+			c.Log.WithError(err).Error("failed to replace berth ship types")
+			return nil, fiber.ErrInternalServerError
+		}
+
+		berth = new(entity.Berth)
+		if err := c.BerthRepository.FindByIdWithShipTypes(tx, berth, request.ID); err != nil {
+			c.Log.WithError(err).Error("failed to find berth")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.BerthToResponse(berth), nil
+}
+
+func (c *BerthUseCaseImpl) Get(ctx context.Context, request *model.GetBerthRequest, userId string) (*model.BerthResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	berth := new(entity.Berth)
+	if err := c.findBerth(tx, berth, request.ID, userId); err != nil {
+		return nil, err
+	}
+
+	return converter.BerthToResponse(berth), nil
+}
+
+// Delete removes a berth that was never allocated, allocated berths are deactivated instead to keep their history
+func (c *BerthUseCaseImpl) Delete(ctx context.Context, request *model.DeleteBerthRequest, userId string) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	berth := new(entity.Berth)
+	if err := c.findBerth(tx, berth, request.ID, userId); err != nil {
+		return err
+	}
+
+	if count, err := c.BerthAllocationRepository.CountByBerthID(tx, berth.ID); err != nil {
+		c.Log.WithError(err).Error("failed to count berth allocations")
+		return fiber.ErrInternalServerError
+	} else if count > 0 {
+		return fiber.NewError(fiber.StatusConflict, "allocated berths can not be deleted, deactivate them instead")
+	}
+
+	if err := c.BerthRepository.Delete(tx, berth); err != nil {
+		c.Log.WithError(err).Error("failed to delete berth")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// ListByHarbor lists the berths of a harbor granted to the user by code
+func (c *BerthUseCaseImpl) ListByHarbor(ctx context.Context, request *model.ListHarborBerthRequest, userId string) ([]model.BerthResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	harbor := new(entity.Harbor)
+	if err := c.HarborRepository.FindByIdInScope(tx, harbor, request.HarborID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find harbor")
+		return nil, fiber.NewError(fiber.StatusNotFound, "harbor not found")
+	}
+
+	berths, err := c.BerthRepository.FindAllByHarborID(tx, harbor.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find berths")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.BerthResponse, len(berths))
+	for i, berth := range berths {
+		responses[i] = *converter.BerthToResponse(&berth)
+	}
+
+	return responses, nil
+}
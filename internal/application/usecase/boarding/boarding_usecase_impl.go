@@ -0,0 +1,309 @@
+package boarding
+
+import (
+	"context"
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/domain/repository"
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/internal/model/converter"
+	"mkp-boarding-test/pkg/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// conductPermission is the permission an inspector must hold to be assigned to a boarding
+const conductPermission = "boarding.conduct"
+
+type BoardingUseCaseImpl struct {
+	DB                                *gorm.DB
+	Log                               *logrus.Logger
+	Validate                          *validator.Validate
+	BoardingRepository                repository.BoardingRepository
+	ShipRepository                    repository.ShipRepository
+	HarborRepository                  repository.HarborRepository
+	UserRepository                    repository.UserRepository
+	PermissionRepository              repository.PermissionRepository
+	ChecklistTemplateRepository       repository.ChecklistTemplateRepository
+	BoardingChecklistAnswerRepository repository.BoardingChecklistAnswerRepository
+}
+
+func NewBoardingUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate,
+	boardingRepository repository.BoardingRepository, shipRepository repository.ShipRepository,
+	harborRepository repository.HarborRepository,
+	userRepository repository.UserRepository, permissionRepository repository.PermissionRepository,
+	checklistTemplateRepository repository.ChecklistTemplateRepository,
+	boardingChecklistAnswerRepository repository.BoardingChecklistAnswerRepository) usecase.BoardingUseCase {
+	return &BoardingUseCaseImpl{
+		DB:                                db,
+		Log:                               log,
+		Validate:                          validate,
+		BoardingRepository:                boardingRepository,
+		ShipRepository:                    shipRepository,
+		HarborRepository:                  harborRepository,
+		UserRepository:                    userRepository,
+		PermissionRepository:              permissionRepository,
+		ChecklistTemplateRepository:       checklistTemplateRepository,
+		BoardingChecklistAnswerRepository: boardingChecklistAnswerRepository,
+	}
+}
+
+// findBoarding loads a boarding with its ship and harbor, a boarding is only visible while both its harbor and its
+// ship are in the scope of the user
+func (c *BoardingUseCaseImpl) findBoarding(tx *gorm.DB, boarding *entity.Boarding, id string, userId string) error {
+	if err := c.BoardingRepository.FindById(tx, boarding, id); err != nil {
+		c.Log.WithError(err).Error("failed to find boarding")
+		return fiber.NewError(fiber.StatusNotFound, "boarding not found")
+	}
+
+	if err := c.HarborRepository.FindByIdInScope(tx, &boarding.Harbor, boarding.HarborID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find harbor")
+		return fiber.NewError(fiber.StatusNotFound, "boarding not found")
+	}
+	if err := c.ShipRepository.FindByIdInScope(tx, &boarding.Ship, boarding.ShipID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find ship")
+		return fiber.NewError(fiber.StatusNotFound, "boarding not found")
+	}
+	return nil
+}
+
+// checkInspector makes sure the inspector is an active user allowed to conduct boardings
+func (c *BoardingUseCaseImpl) checkInspector(tx *gorm.DB, inspectorID string) error {
+	inspector := new(entity.User)
+	if err := c.UserRepository.FindById(tx, inspector, inspectorID); err != nil || inspector.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find inspector")
+		return fiber.NewError(fiber.StatusNotFound, "inspector not found")
+	}
+
+	if !inspector.IsActive {
+		return fiber.NewError(fiber.StatusBadRequest, "inspector is not active")
+	}
+
+	permissions, err := c.PermissionRepository.FindAllByUserID(tx, inspector.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find inspector permissions")
+		return fiber.ErrInternalServerError
+	}
+	names := make([]string, len(permissions))
+	for i, permission := range permissions {
+		names[i] = permission.Name
+	}
+	if !service.NewPermissionSet(names).Allows(conductPermission) {
+		return fiber.NewError(fiber.StatusBadRequest, "inspector is not allowed to conduct boardings")
+	}
+	return nil
+}
+
+func (c *BoardingUseCaseImpl) Create(ctx context.Context, request *model.CreateBoardingRequest, userId string) (*model.BoardingResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	ship := new(entity.Ship)
+	if err := c.ShipRepository.FindByIdInScope(tx, ship, request.ShipID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find ship")
+		return nil, fiber.NewError(fiber.StatusNotFound, "ship not found")
+	}
+
+	harbor := new(entity.Harbor)
+	if err := c.HarborRepository.FindByIdInScope(tx, harbor, request.HarborID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find harbor")
+		return nil, fiber.NewError(fiber.StatusNotFound, "harbor not found")
+	}
+
+	inspectorID := userId
+	if request.InspectorID != nil && *request.InspectorID != "" {
+		inspectorID = *request.InspectorID
+	}
+	if err := c.checkInspector(tx, inspectorID); err != nil {
+		return nil, err
+	}
+
+	boarding := &entity.Boarding{
+		ID:          uuid.NewString(),
+		ShipID:      ship.ID,
+		HarborID:    harbor.ID,
+		InspectorID: inspectorID,
+		Status:      entity.BoardingStatusPlanned,
+		PlannedAt:   request.PlannedAt,
+		Remarks:     request.Remarks,
+	}
+
+	if err := c.BoardingRepository.Create(tx, boarding); err != nil {
+		c.Log.WithError(err).Error("failed to create boarding")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	boarding.Ship = *ship
+	boarding.Harbor = *harbor
+	return converter.BoardingToResponse(boarding), nil
+}
+
+func (c *BoardingUseCaseImpl) Update(ctx context.Context, request *model.UpdateBoardingRequest, userId string) (*model.BoardingResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	boarding := new(entity.Boarding)
+	if err := c.findBoarding(tx, boarding, request.ID, userId); err != nil {
+		return nil, err
+	}
+
+	// Once the inspectors are on board the plan is history, only the lifecycle endpoints change the boarding
+	if boarding.Status != entity.BoardingStatusPlanned {
+		return nil, fiber.NewError(fiber.StatusConflict, "only planned boardings can be changed")
+	}
+
+	if request.HarborID != nil && *request.HarborID != "" {
+		harbor := new(entity.Harbor)
+		if err := c.HarborRepository.FindByIdInScope(tx, harbor, *request.HarborID, userId); err != nil {
+			c.Log.WithError(err).Error("failed to find harbor")
+			return nil, fiber.NewError(fiber.StatusNotFound, "harbor not found")
+		}
+		boarding.HarborID = harbor.ID
+		boarding.Harbor = *harbor
+	}
+	if request.InspectorID != nil && *request.InspectorID != "" {
+		if err := c.checkInspector(tx, *request.InspectorID); err != nil {
+			return nil, err
+		}
+		boarding.InspectorID = *request.InspectorID
+	}
+	if request.PlannedAt != nil {
+		boarding.PlannedAt = *request.PlannedAt
+	}
+	if request.Remarks != nil {
+		boarding.Remarks = request.Remarks
+	}
+
+	if err := c.BoardingRepository.Update(tx, boarding); err != nil {
+		c.Log.WithError(err).Error("failed to update boarding")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.BoardingToResponse(boarding), nil
+}
+
+func (c *BoardingUseCaseImpl) Get(ctx context.Context, request *model.GetBoardingRequest, userId string) (*model.BoardingResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	boarding := new(entity.Boarding)
+	if err := c.findBoarding(tx, boarding, request.ID, userId); err != nil {
+		return nil, err
+	}
+
+	return converter.BoardingToResponse(boarding), nil
+}
+
+func (c *BoardingUseCaseImpl) Delete(ctx context.Context, request *model.DeleteBoardingRequest, userId string) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	boarding := new(entity.Boarding)
+	if err := c.findBoarding(tx, boarding, request.ID, userId); err != nil {
+		return err
+	}
+
+	// Boardings that took place are inspection records and are kept, a boarding that went wrong is aborted instead
+	if boarding.Status != entity.BoardingStatusPlanned {
+		return fiber.NewError(fiber.StatusConflict, "only planned boardings can be deleted")
+	}
+
+	if err := c.BoardingRepository.Delete(tx, boarding); err != nil {
+		c.Log.WithError(err).Error("failed to delete boarding")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *BoardingUseCaseImpl) List(ctx context.Context, request *model.ListBoardingRequest, userId string) (*model.WebResponse[[]model.BoardingResponse], error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	// Only boardings in harbors granted to the user's roles, and for operator users only those of their own fleet
+	query := tx.Model(&entity.Boarding{}).
+		Where("boardings.harbor_id IN (?)", c.HarborRepository.SelectIDsInScope(tx, userId)).
+		Where("boardings.ship_id IN (?)", c.ShipRepository.SelectIDsInScope(tx, userId))
+
+	if request.ShipID != nil && *request.ShipID != "" {
+		query = query.Where("ship_id = ?", *request.ShipID)
+	}
+	if request.HarborID != nil && *request.HarborID != "" {
+		query = query.Where("harbor_id = ?", *request.HarborID)
+	}
+	if request.InspectorID != nil && *request.InspectorID != "" {
+		query = query.Where("inspector_id = ?", *request.InspectorID)
+	}
+	if request.Status != nil && *request.Status != "" {
+		query = query.Where("status = ?", *request.Status)
+	}
+
+	// Count total records
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.Log.WithError(err).Error("failed to count boardings")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Apply pagination
+	offset := (request.Page - 1) * request.Size
+	query = query.Order("planned_at DESC").Offset(offset).Limit(request.Size)
+
+	var boardings []entity.Boarding
+	if err := query.Preload("Ship").Preload("Harbor").Find(&boardings).Error; err != nil {
+		c.Log.WithError(err).Error("failed to find boardings")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.BoardingResponse, len(boardings))
+	for i, boarding := range boardings {
+		responses[i] = *converter.BoardingToResponse(&boarding)
+	}
+
+	return &model.WebResponse[[]model.BoardingResponse]{
+		Data: responses,
+		Meta: model.NewPageMetadata(request.Page, request.Size, total),
+	}, nil
+}
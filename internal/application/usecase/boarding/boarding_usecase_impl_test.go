@@ -0,0 +1,345 @@
+package boarding
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/domain/repository"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/test/fakedb"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type fakeBoardingRepository struct {
+	repository.BoardingRepository
+	boardings map[string]*entity.Boarding
+}
+
+func (r *fakeBoardingRepository) Create(_ *gorm.DB, boarding *entity.Boarding) error {
+	stored := *boarding
+	r.boardings[boarding.ID] = &stored
+	return nil
+}
+
+func (r *fakeBoardingRepository) Update(db *gorm.DB, boarding *entity.Boarding) error {
+	return r.Create(db, boarding)
+}
+
+func (r *fakeBoardingRepository) FindById(_ *gorm.DB, boarding *entity.Boarding, id any) error {
+	found, ok := r.boardings[id.(string)]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	*boarding = *found
+	return nil
+}
+
+func (r *fakeBoardingRepository) CountInProgressByShipID(_ *gorm.DB, shipID string, excludeID string) (int64, error) {
+	var total int64
+	for _, boarding := range r.boardings {
+		if boarding.ShipID == shipID && boarding.Status == entity.BoardingStatusInProgress && boarding.ID != excludeID {
+			total++
+		}
+	}
+	return total, nil
+}
+
+// fakeShipRepository holds the ships in the scope of the caller
+type fakeShipRepository struct {
+	repository.ShipRepository
+	inScope map[string]entity.Ship
+}
+
+func (r *fakeShipRepository) FindByIdInScope(_ *gorm.DB, ship *entity.Ship, id string, _ string) error {
+	found, ok := r.inScope[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	*ship = found
+	return nil
+}
+
+// fakeHarborRepository holds the harbors in the scope of the caller
+type fakeHarborRepository struct {
+	repository.HarborRepository
+	inScope map[string]entity.Harbor
+}
+
+func (r *fakeHarborRepository) FindByIdInScope(_ *gorm.DB, harbor *entity.Harbor, id string, _ string) error {
+	found, ok := r.inScope[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	*harbor = found
+	return nil
+}
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	users map[string]entity.User
+}
+
+func (r *fakeUserRepository) FindById(_ *gorm.DB, user *entity.User, id any) error {
+	found, ok := r.users[id.(string)]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	*user = found
+	return nil
+}
+
+type fakePermissionRepository struct {
+	repository.PermissionRepository
+	grants map[string][]string
+}
+
+func (r *fakePermissionRepository) FindAllByUserID(_ *gorm.DB, userID string) ([]entity.Permission, error) {
+	permissions := make([]entity.Permission, len(r.grants[userID]))
+	for i, name := range r.grants[userID] {
+		permissions[i] = entity.Permission{Name: name}
+	}
+	return permissions, nil
+}
+
+// fakeChecklistTemplateRepository has no checklist for any ship type
+type fakeChecklistTemplateRepository struct {
+	repository.ChecklistTemplateRepository
+}
+
+func (fakeChecklistTemplateRepository) FindLatestActiveByShipType(*gorm.DB, *entity.ChecklistTemplate, string) error {
+	return gorm.ErrRecordNotFound
+}
+
+// boardingTest is a boarding use case of a caller who sees one ship and one harbor
+type boardingTest struct {
+	ship        entity.Ship
+	harbor      entity.Harbor
+	boardings   *fakeBoardingRepository
+	users       *fakeUserRepository
+	permissions *fakePermissionRepository
+	useCase     *BoardingUseCaseImpl
+}
+
+func newBoardingTest(t *testing.T) *boardingTest {
+	t.Helper()
+
+	db, _, err := fakedb.Open()
+	if err != nil {
+		t.Fatalf("open fake database: %v", err)
+	}
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	b := &boardingTest{
+		ship:        entity.Ship{ID: uuid.NewString(), ShipType: "tanker"},
+		harbor:      entity.Harbor{ID: uuid.NewString()},
+		boardings:   &fakeBoardingRepository{boardings: make(map[string]*entity.Boarding)},
+		users:       &fakeUserRepository{users: make(map[string]entity.User)},
+		permissions: &fakePermissionRepository{grants: make(map[string][]string)},
+	}
+	b.useCase = &BoardingUseCaseImpl{
+		DB:                          db,
+		Log:                         log,
+		Validate:                    validator.New(),
+		BoardingRepository:          b.boardings,
+		ShipRepository:              &fakeShipRepository{inScope: map[string]entity.Ship{b.ship.ID: b.ship}},
+		HarborRepository:            &fakeHarborRepository{inScope: map[string]entity.Harbor{b.harbor.ID: b.harbor}},
+		UserRepository:              b.users,
+		PermissionRepository:        b.permissions,
+		ChecklistTemplateRepository: fakeChecklistTemplateRepository{},
+	}
+	return b
+}
+
+// addBoarding stores a boarding of the ship in the harbor the caller sees
+func (b *boardingTest) addBoarding(status string) *entity.Boarding {
+	boarding := &entity.Boarding{ID: uuid.NewString(), ShipID: b.ship.ID, HarborID: b.harbor.ID, Status: status}
+	b.boardings.boardings[boarding.ID] = boarding
+	return boarding
+}
+
+func assertError(t *testing.T, err error, status int, message string) {
+	t.Helper()
+
+	fiberErr, ok := err.(*fiber.Error)
+	if !ok || fiberErr.Code != status || (message != "" && fiberErr.Message != message) {
+		t.Fatalf("expected %d %q, got %v", status, message, err)
+	}
+}
+
+func TestGetBoardingInScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		adjust func(b *boardingTest, boarding *entity.Boarding) string
+		found  bool
+	}{
+		{
+			name:   "boarding in the harbor and of the ship of the caller",
+			adjust: func(_ *boardingTest, boarding *entity.Boarding) string { return boarding.ID },
+			found:  true,
+		},
+		{
+			name:   "unknown boarding",
+			adjust: func(*boardingTest, *entity.Boarding) string { return uuid.NewString() },
+		},
+		{
+			name: "boarding in a harbor outside the scope",
+			adjust: func(_ *boardingTest, boarding *entity.Boarding) string {
+				boarding.HarborID = uuid.NewString()
+				return boarding.ID
+			},
+		},
+		{
+			name: "boarding of a ship outside the scope",
+			adjust: func(_ *boardingTest, boarding *entity.Boarding) string {
+				boarding.ShipID = uuid.NewString()
+				return boarding.ID
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBoardingTest(t)
+			id := tt.adjust(b, b.addBoarding(entity.BoardingStatusPlanned))
+
+			res, err := b.useCase.Get(context.Background(), &model.GetBoardingRequest{ID: id}, uuid.NewString())
+			if !tt.found {
+				// Boardings outside the scope are reported like missing ones
+				assertError(t, err, fiber.StatusNotFound, "boarding not found")
+				return
+			}
+			if err != nil {
+				t.Fatalf("get: %v", err)
+			}
+			if res.Ship == nil || res.Harbor == nil {
+				t.Fatalf("expected the ship and harbor of the boarding, got %+v", res)
+			}
+		})
+	}
+}
+
+func TestStartBoarding(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		others  []string
+		message string
+	}{
+		{name: "planned boarding", status: entity.BoardingStatusPlanned},
+		{name: "ship boarded before", status: entity.BoardingStatusPlanned, others: []string{entity.BoardingStatusCompleted, entity.BoardingStatusAborted}},
+		{name: "ship being boarded by another team", status: entity.BoardingStatusPlanned, others: []string{entity.BoardingStatusInProgress}, message: "the ship is already being boarded"},
+		{name: "boarding already started", status: entity.BoardingStatusInProgress, message: "a in_progress boarding can not become in_progress"},
+		{name: "completed boarding", status: entity.BoardingStatusCompleted, message: "a completed boarding can not become in_progress"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBoardingTest(t)
+			boarding := b.addBoarding(tt.status)
+			for _, status := range tt.others {
+				b.addBoarding(status)
+			}
+
+			before := time.Now().UnixMilli()
+			res, err := b.useCase.Start(context.Background(), &model.StartBoardingRequest{ID: boarding.ID}, uuid.NewString())
+			if tt.message != "" {
+				assertError(t, err, fiber.StatusConflict, tt.message)
+				if b.boardings.boardings[boarding.ID].Status != tt.status {
+					t.Fatalf("a refused start changed the boarding")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("start: %v", err)
+			}
+			if res.Status != entity.BoardingStatusInProgress || res.StartedAt == nil || *res.StartedAt < before {
+				t.Fatalf("expected a started boarding, got %+v", res)
+			}
+		})
+	}
+}
+
+func TestCreateBoardingChecksInspector(t *testing.T) {
+	tests := []struct {
+		name      string
+		inspector *entity.User
+		grants    []string
+		status    int
+		message   string
+	}{
+		{name: "active inspector with the permission", inspector: &entity.User{IsActive: true}, grants: []string{"boarding.conduct"}},
+		{name: "permission through a resource wildcard", inspector: &entity.User{IsActive: true}, grants: []string{"boarding.*"}},
+		{name: "permission through the full wildcard", inspector: &entity.User{IsActive: true}, grants: []string{"*.*"}},
+		{name: "unknown inspector", status: fiber.StatusNotFound, message: "inspector not found"},
+		{name: "deleted inspector", inspector: &entity.User{IsActive: true, DeletedAt: new(int64)}, grants: []string{"boarding.conduct"}, status: fiber.StatusNotFound, message: "inspector not found"},
+		{name: "inactive inspector", inspector: &entity.User{}, grants: []string{"boarding.conduct"}, status: fiber.StatusBadRequest, message: "inspector is not active"},
+		{name: "inspector without the permission", inspector: &entity.User{IsActive: true}, grants: []string{"boarding.index", "ship.*"}, status: fiber.StatusBadRequest, message: "inspector is not allowed to conduct boardings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBoardingTest(t)
+			inspectorID := uuid.NewString()
+			if tt.inspector != nil {
+				tt.inspector.ID = inspectorID
+				b.users.users[inspectorID] = *tt.inspector
+			}
+			b.permissions.grants[inspectorID] = tt.grants
+
+			res, err := b.useCase.Create(context.Background(), &model.CreateBoardingRequest{
+				ShipID:      b.ship.ID,
+				HarborID:    b.harbor.ID,
+				InspectorID: &inspectorID,
+				PlannedAt:   time.Now().UnixMilli(),
+			}, uuid.NewString())
+			if tt.status != 0 {
+				assertError(t, err, tt.status, tt.message)
+				if len(b.boardings.boardings) != 0 {
+					t.Fatalf("a refused inspector was assigned a boarding")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			if res.InspectorID != inspectorID || res.Status != entity.BoardingStatusPlanned {
+				t.Fatalf("expected a planned boarding of the inspector, got %+v", res)
+			}
+		})
+	}
+}
+
+func TestCreateBoardingDefaultsToTheCaller(t *testing.T) {
+	b := newBoardingTest(t)
+	callerID := uuid.NewString()
+	b.users.users[callerID] = entity.User{ID: callerID, IsActive: true}
+	b.permissions.grants[callerID] = []string{"boarding.conduct"}
+
+	res, err := b.useCase.Create(context.Background(), &model.CreateBoardingRequest{
+		ShipID:    b.ship.ID,
+		HarborID:  b.harbor.ID,
+		PlannedAt: time.Now().UnixMilli(),
+	}, callerID)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if res.InspectorID != callerID {
+		t.Fatalf("expected the caller to inspect, got %s", res.InspectorID)
+	}
+
+	_, err = b.useCase.Create(context.Background(), &model.CreateBoardingRequest{
+		ShipID:    uuid.NewString(),
+		HarborID:  b.harbor.ID,
+		PlannedAt: time.Now().UnixMilli(),
+	}, callerID)
+	assertError(t, err, fiber.StatusNotFound, "ship not found")
+}
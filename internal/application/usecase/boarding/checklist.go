@@ -0,0 +1,218 @@
+package boarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/internal/model/converter"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Answer types of checklist items
+const (
+	answerYesNo   = "yes_no"
+	answerYesNoNA = "yes_no_na"
+	answerNumeric = "numeric"
+	answerPhoto   = "photo"
+)
+
+// GetChecklist returns the checklist version of the boarding with the answers given so far, boardings of ship types
+// without a checklist template have no template
+func (c *BoardingUseCaseImpl) GetChecklist(ctx context.Context, request *model.GetBoardingChecklistRequest, userId string) (*model.BoardingChecklistResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	boarding := new(entity.Boarding)
+	if err := c.findBoarding(tx, boarding, request.ID, userId); err != nil {
+		return nil, err
+	}
+
+	if boarding.ChecklistTemplateID == nil {
+		return &model.BoardingChecklistResponse{
+			BoardingID: boarding.ID,
+			Status:     boarding.Status,
+			Answers:    []model.ChecklistAnswerResponse{},
+		}, nil
+	}
+
+	template := new(entity.ChecklistTemplate)
+	if err := c.ChecklistTemplateRepository.FindByIdWithItems(tx, template, *boarding.ChecklistTemplateID); err != nil {
+		c.Log.WithError(err).Error("failed to find checklist template")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return c.checklistResponse(tx, boarding, template)
+}
+
+// AnswerChecklist records answers to items of the checklist version of a boarding in progress, an item answered
+// again is overwritten. Once the boarding is completed or aborted its answers are frozen.
+func (c *BoardingUseCaseImpl) AnswerChecklist(ctx context.Context, request *model.AnswerBoardingChecklistRequest, userId string) (*model.BoardingChecklistResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	boarding := new(entity.Boarding)
+	if err := c.findBoarding(tx, boarding, request.ID, userId); err != nil {
+		return nil, err
+	}
+
+	if boarding.Status != entity.BoardingStatusInProgress {
+		return nil, fiber.NewError(fiber.StatusConflict, "the checklist can only be answered while the boarding is in progress")
+	}
+	if boarding.ChecklistTemplateID == nil {
+		return nil, fiber.NewError(fiber.StatusConflict, "the boarding has no checklist")
+	}
+
+	template := new(entity.ChecklistTemplate)
+	if err := c.ChecklistTemplateRepository.FindByIdWithItems(tx, template, *boarding.ChecklistTemplateID); err != nil {
+		c.Log.WithError(err).Error("failed to find checklist template")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	items := make(map[string]entity.ChecklistItem)
+	for _, section := range template.Sections {
+		for _, item := range section.Items {
+			items[item.ID] = item
+		}
+	}
+
+	for _, answerRequest := range request.Answers {
+		item, ok := items[answerRequest.ItemID]
+		if !ok {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("item %s is not part of the checklist of this boarding", answerRequest.ItemID))
+		}
+
+		value, err := checkAnswer(&item, &answerRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		answer := new(entity.BoardingChecklistAnswer)
+		err = c.BoardingChecklistAnswerRepository.FindByBoardingIDAndItemID(tx, answer, boarding.ID, item.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.WithError(err).Error("failed to find checklist answer")
+			return nil, fiber.ErrInternalServerError
+		}
+		exists := err == nil
+
+		answer.Value = value
+		answer.PhotoURL = answerRequest.PhotoURL
+		answer.Remarks = answerRequest.Remarks
+		answer.AnsweredBy = userId
+
+		if exists {
+			err = c.BoardingChecklistAnswerRepository.Update(tx, answer)
+		} else {
+			answer.ID = uuid.NewString()
+			answer.BoardingID = boarding.ID
+			answer.ItemID = item.ID
+			err = c.BoardingChecklistAnswerRepository.Create(tx, answer)
+		}
+		if err != nil {
+			c.Log.WithError(err).Error("failed to save checklist answer")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	response, err := c.checklistResponse(tx, boarding, template)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return response, nil
+}
+
+// checkAnswer validates an answer against the answer type of its item and returns the normalized value
+func checkAnswer(item *entity.ChecklistItem, request *model.ChecklistAnswerRequest) (*string, error) {
+	var value string
+	if request.Value != nil {
+		value = strings.ToLower(strings.TrimSpace(*request.Value))
+	}
+
+	switch item.AnswerType {
+	case answerYesNo:
+		if value != "yes" && value != "no" {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("the answer to item %s must be yes or no", item.ID))
+		}
+	case answerYesNoNA:
+		if value != "yes" && value != "no" && value != "na" {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("the answer to item %s must be yes, no or na", item.ID))
+		}
+	case answerNumeric:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("the answer to item %s must be a number", item.ID))
+		}
+	case answerPhoto:
+		if request.PhotoURL == nil || *request.PhotoURL == "" {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("the answer to item %s needs a photo_url", item.ID))
+		}
+		// The value of a photo item is an optional caption and kept as written
+		if request.Value == nil || *request.Value == "" {
+			return nil, nil
+		}
+		return request.Value, nil
+	}
+
+	return &value, nil
+}
+
+// checklistResponse combines the checklist version of a boarding with its answers
+func (c *BoardingUseCaseImpl) checklistResponse(tx *gorm.DB, boarding *entity.Boarding, template *entity.ChecklistTemplate) (*model.BoardingChecklistResponse, error) {
+	answers, err := c.BoardingChecklistAnswerRepository.FindAllByBoardingID(tx, boarding.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find checklist answers")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.ChecklistAnswerResponse, len(answers))
+	for i, answer := range answers {
+		responses[i] = *converter.ChecklistAnswerToResponse(&answer)
+	}
+
+	return &model.BoardingChecklistResponse{
+		BoardingID:         boarding.ID,
+		Status:             boarding.Status,
+		Template:           converter.ChecklistTemplateToResponse(template),
+		Answers:            responses,
+		RequiredUnanswered: requiredUnanswered(template, answers),
+	}, nil
+}
+
+// requiredUnanswered counts the required items of the template without an answer
+func requiredUnanswered(template *entity.ChecklistTemplate, answers []entity.BoardingChecklistAnswer) int {
+	answered := make(map[string]bool, len(answers))
+	for _, answer := range answers {
+		answered[answer.ItemID] = true
+	}
+
+	total := 0
+	for _, section := range template.Sections {
+		for _, item := range section.Items {
+			if item.IsRequired && !answered[item.ID] {
+				total++
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,129 @@
+package boarding
+
+import (
+	"errors"
+	"testing"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+func text(value string) *string {
+	return &value
+}
+
+func TestCheckAnswer(t *testing.T) {
+	tests := []struct {
+		name       string
+		answerType string
+		value      *string
+		photoURL   *string
+		want       *string
+		invalid    bool
+	}{
+		{name: "yes", answerType: answerYesNo, value: text("yes"), want: text("yes")},
+		{name: "yes in another case with spaces", answerType: answerYesNo, value: text("  YES "), want: text("yes")},
+		{name: "na is not a yes/no answer", answerType: answerYesNo, value: text("na"), invalid: true},
+		{name: "missing yes/no answer", answerType: answerYesNo, invalid: true},
+		{name: "na", answerType: answerYesNoNA, value: text("NA"), want: text("na")},
+		{name: "maybe is not a yes/no/na answer", answerType: answerYesNoNA, value: text("maybe"), invalid: true},
+		{name: "number", answerType: answerNumeric, value: text(" 12.5 "), want: text("12.5")},
+		{name: "negative number", answerType: answerNumeric, value: text("-3"), want: text("-3")},
+		{name: "text is not a number", answerType: answerNumeric, value: text("twelve"), invalid: true},
+		{name: "missing number", answerType: answerNumeric, invalid: true},
+		{name: "photo with a caption kept as written", answerType: answerPhoto, value: text("Rusted Hatch"), photoURL: text("https://cdn.example.com/a.jpg"), want: text("Rusted Hatch")},
+		{name: "photo without a caption", answerType: answerPhoto, photoURL: text("https://cdn.example.com/a.jpg")},
+		{name: "photo without a photo_url", answerType: answerPhoto, value: text("caption"), invalid: true},
+		{name: "photo with an empty photo_url", answerType: answerPhoto, photoURL: text(""), invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &entity.ChecklistItem{ID: "item", AnswerType: tt.answerType}
+			got, err := checkAnswer(item, &model.ChecklistAnswerRequest{ItemID: item.ID, Value: tt.value, PhotoURL: tt.photoURL})
+			if tt.invalid {
+				var fiberErr *fiber.Error
+				if !errors.As(err, &fiberErr) || fiberErr.Code != fiber.StatusBadRequest {
+					t.Fatalf("expected a 400 error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("checkAnswer: %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Fatalf("checkAnswer = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecklistAnswerRequestPhotoURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		photoURL *string
+		valid    bool
+	}{
+		{name: "https link", photoURL: text("https://cdn.example.com/boardings/a.jpg"), valid: true},
+		{name: "http link", photoURL: text("http://cdn.example.com/a.jpg"), valid: true},
+		{name: "no photo", valid: true},
+		{name: "empty photo", photoURL: text("")},
+		{name: "not a url", photoURL: text("not a url")},
+		{name: "relative path", photoURL: text("/uploads/a.jpg")},
+		{name: "javascript link", photoURL: text("javascript:alert(1)")},
+		{name: "file link", photoURL: text("file:///etc/passwd")},
+		{name: "longer than the column", photoURL: text("https://cdn.example.com/" + string(make([]byte, 2048)))},
+	}
+
+	validate := validator.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &model.AnswerBoardingChecklistRequest{
+				ID: "8d3f9a52-5a0e-4c4b-9f43-1f6f0d7c2b11",
+				Answers: []model.ChecklistAnswerRequest{
+					{ItemID: "1b2c3d4e-5f60-4718-8a9b-0c1d2e3f4a5b", PhotoURL: tt.photoURL},
+				},
+			}
+			if err := validate.Struct(request); (err == nil) != tt.valid {
+				t.Fatalf("validate = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestRequiredUnanswered(t *testing.T) {
+	template := &entity.ChecklistTemplate{
+		Sections: []entity.ChecklistSection{
+			{Items: []entity.ChecklistItem{{ID: "hull", IsRequired: true}, {ID: "paint"}}},
+			{Items: []entity.ChecklistItem{{ID: "lifeboats", IsRequired: true}, {ID: "extinguishers", IsRequired: true}}},
+			{},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		answered []string
+		want     int
+	}{
+		{name: "nothing answered", want: 3},
+		{name: "optional items do not count", answered: []string{"paint"}, want: 3},
+		{name: "required items across sections", answered: []string{"hull", "lifeboats"}, want: 1},
+		{name: "every required item", answered: []string{"hull", "lifeboats", "extinguishers"}, want: 0},
+		{name: "answers of other items", answered: []string{"hull", "lifeboats", "extinguishers", "other"}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answers := make([]entity.BoardingChecklistAnswer, len(tt.answered))
+			for i, itemID := range tt.answered {
+				answers[i] = entity.BoardingChecklistAnswer{ItemID: itemID}
+			}
+			if got := requiredUnanswered(template, answers); got != tt.want {
+				t.Fatalf("requiredUnanswered = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
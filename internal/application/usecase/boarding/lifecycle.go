@@ -0,0 +1,135 @@
+package boarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/internal/model/converter"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Start records that the inspectors went on board, optionally with the position of the ship
+func (c *BoardingUseCaseImpl) Start(ctx context.Context, request *model.StartBoardingRequest, userId string) (*model.BoardingResponse, error) {
+	return c.transition(ctx, request, request.ID, userId, entity.BoardingStatusInProgress, func(tx *gorm.DB, boarding *entity.Boarding, now int64) error {
+		// A ship is boarded by one team at a time
+		if count, err := c.BoardingRepository.CountInProgressByShipID(tx, boarding.ShipID, boarding.ID); err != nil {
+			c.Log.WithError(err).Error("failed to count boardings in progress")
+			return fiber.ErrInternalServerError
+		} else if count > 0 {
+			return fiber.NewError(fiber.StatusConflict, "the ship is already being boarded")
+		}
+
+		// The latest active checklist version for the ship type is chosen now and kept for the whole boarding
+		template := new(entity.ChecklistTemplate)
+		if err := c.ChecklistTemplateRepository.FindLatestActiveByShipType(tx, template, boarding.Ship.ShipType); err == nil {
+			boarding.ChecklistTemplateID = &template.ID
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.WithError(err).Error("failed to find checklist template")
+			return fiber.ErrInternalServerError
+		}
+
+		boarding.StartedAt = &now
+		setPosition(boarding, request.Latitude, request.Longitude)
+		return nil
+	})
+}
+
+// Complete closes a boarding in progress with its outcome, every required item of its checklist must be answered
+func (c *BoardingUseCaseImpl) Complete(ctx context.Context, request *model.CompleteBoardingRequest, userId string) (*model.BoardingResponse, error) {
+	return c.transition(ctx, request, request.ID, userId, entity.BoardingStatusCompleted, func(tx *gorm.DB, boarding *entity.Boarding, now int64) error {
+		if boarding.ChecklistTemplateID != nil {
+			if err := c.checkChecklistComplete(tx, boarding); err != nil {
+				return err
+			}
+		}
+
+		boarding.CompletedAt = &now
+		boarding.Outcome = &request.Outcome
+		if request.Remarks != nil {
+			boarding.Remarks = request.Remarks
+		}
+		setPosition(boarding, request.Latitude, request.Longitude)
+		return nil
+	})
+}
+
+// Abort ends a planned boarding or a boarding in progress without an outcome, the remarks explain why
+func (c *BoardingUseCaseImpl) Abort(ctx context.Context, request *model.AbortBoardingRequest, userId string) (*model.BoardingResponse, error) {
+	return c.transition(ctx, request, request.ID, userId, entity.BoardingStatusAborted, func(tx *gorm.DB, boarding *entity.Boarding, now int64) error {
+		boarding.AbortedAt = &now
+		boarding.Remarks = &request.Remarks
+		return nil
+	})
+}
+
+// transition moves a boarding in the scope of the user to the status, apply records what the step changes
+func (c *BoardingUseCaseImpl) transition(ctx context.Context, request any, id string, userId string, status string,
+	apply func(tx *gorm.DB, boarding *entity.Boarding, now int64) error) (*model.BoardingResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	boarding := new(entity.Boarding)
+	if err := c.findBoarding(tx, boarding, id, userId); err != nil {
+		return nil, err
+	}
+
+	if err := entity.BoardingLifecycle.Check(boarding.Status, status); err != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, err.Error())
+	}
+
+	if err := apply(tx, boarding, time.Now().UnixMilli()); err != nil {
+		return nil, err
+	}
+	boarding.Status = status
+
+	if err := c.BoardingRepository.Update(tx, boarding); err != nil {
+		c.Log.WithError(err).Error("failed to update boarding")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.BoardingToResponse(boarding), nil
+}
+
+// setPosition records where the ship was boarded, a position is only taken when both coordinates are given
+func setPosition(boarding *entity.Boarding, latitude *float64, longitude *float64) {
+	if latitude != nil && longitude != nil {
+		boarding.Latitude = latitude
+		boarding.Longitude = longitude
+	}
+}
+
+// checkChecklistComplete makes sure every required item of the checklist of the boarding has an answer
+func (c *BoardingUseCaseImpl) checkChecklistComplete(tx *gorm.DB, boarding *entity.Boarding) error {
+	template := new(entity.ChecklistTemplate)
+	if err := c.ChecklistTemplateRepository.FindByIdWithItems(tx, template, *boarding.ChecklistTemplateID); err != nil {
+		c.Log.WithError(err).Error("failed to find checklist template")
+		return fiber.ErrInternalServerError
+	}
+
+	answers, err := c.BoardingChecklistAnswerRepository.FindAllByBoardingID(tx, boarding.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find checklist answers")
+		return fiber.ErrInternalServerError
+	}
+
+	if unanswered := requiredUnanswered(template, answers); unanswered > 0 {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("%d required checklist items are not answered", unanswered))
+	}
+	return nil
+}
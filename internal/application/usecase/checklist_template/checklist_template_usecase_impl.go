@@ -0,0 +1,251 @@
+package checklist_template
+
+import (
+	"context"
+	"strings"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/domain/repository"
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/internal/model/converter"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ChecklistTemplateUseCaseImpl struct {
+	DB                          *gorm.DB
+	Log                         *logrus.Logger
+	Validate                    *validator.Validate
+	ChecklistTemplateRepository repository.ChecklistTemplateRepository
+	BoardingRepository          repository.BoardingRepository
+}
+
+func NewChecklistTemplateUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate,
+	checklistTemplateRepository repository.ChecklistTemplateRepository, boardingRepository repository.BoardingRepository) usecase.ChecklistTemplateUseCase {
+	return &ChecklistTemplateUseCaseImpl{
+		DB:                          db,
+		Log:                         log,
+		Validate:                    validate,
+		ChecklistTemplateRepository: checklistTemplateRepository,
+		BoardingRepository:          boardingRepository,
+	}
+}
+
+// Create publishes the next version of the checklist for the ship type, earlier versions stay as they are so the
+// boardings that used them keep their questions
+func (c *ChecklistTemplateUseCaseImpl) Create(ctx context.Context, request *model.CreateChecklistTemplateRequest) (*model.ChecklistTemplateResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	shipType := strings.TrimSpace(request.ShipType)
+	if shipType == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "ship type is required")
+	}
+
+	version, err := c.ChecklistTemplateRepository.FindMaxVersionByShipType(tx, shipType)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find latest checklist template version")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	template := &entity.ChecklistTemplate{
+		ID:          uuid.NewString(),
+		ShipType:    shipType,
+		Version:     version + 1,
+		Name:        request.Name,
+		Description: request.Description,
+		IsActive:    true,
+		Sections:    make([]entity.ChecklistSection, len(request.Sections)),
+	}
+
+	for i, sectionRequest := range request.Sections {
+		section := entity.ChecklistSection{
+			ID:         uuid.NewString(),
+			TemplateID: template.ID,
+			Title:      sectionRequest.Title,
+			Position:   i + 1,
+			Items:      make([]entity.ChecklistItem, len(sectionRequest.Items)),
+		}
+
+		for j, itemRequest := range sectionRequest.Items {
+			isRequired := true
+			if itemRequest.IsRequired != nil {
+				isRequired = *itemRequest.IsRequired
+			}
+
+			section.Items[j] = entity.ChecklistItem{
+				ID:         uuid.NewString(),
+				SectionID:  section.ID,
+				Prompt:     itemRequest.Prompt,
+				Guidance:   itemRequest.Guidance,
+				AnswerType: itemRequest.AnswerType,
+				IsRequired: isRequired,
+				Position:   j + 1,
+			}
+		}
+
+		template.Sections[i] = section
+	}
+
+	// Sections and items are created together with the template
+	if err := c.ChecklistTemplateRepository.Create(tx, template); err != nil {
+		c.Log.WithError(err).Error("failed to create checklist template")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ChecklistTemplateToResponse(template), nil
+}
+
+func (c *ChecklistTemplateUseCaseImpl) Update(ctx context.Context, request *model.UpdateChecklistTemplateRequest) (*model.ChecklistTemplateResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	template := new(entity.ChecklistTemplate)
+	if err := c.ChecklistTemplateRepository.FindById(tx, template, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find checklist template")
+		return nil, fiber.NewError(fiber.StatusNotFound, "checklist template not found")
+	}
+
+	if request.Name != nil && *request.Name != "" {
+		template.Name = *request.Name
+	}
+	if request.Description != nil {
+		template.Description = request.Description
+	}
+	// An inactive version is no longer chosen for new boardings, boardings that already use it keep it
+	if request.IsActive != nil {
+		template.IsActive = *request.IsActive
+	}
+
+	if err := c.ChecklistTemplateRepository.Update(tx, template); err != nil {
+		c.Log.WithError(err).Error("failed to update checklist template")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ChecklistTemplateToResponse(template), nil
+}
+
+func (c *ChecklistTemplateUseCaseImpl) Get(ctx context.Context, request *model.GetChecklistTemplateRequest) (*model.ChecklistTemplateResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	template := new(entity.ChecklistTemplate)
+	if err := c.ChecklistTemplateRepository.FindByIdWithItems(tx, template, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find checklist template")
+		return nil, fiber.NewError(fiber.StatusNotFound, "checklist template not found")
+	}
+
+	return converter.ChecklistTemplateToResponse(template), nil
+}
+
+func (c *ChecklistTemplateUseCaseImpl) Delete(ctx context.Context, request *model.DeleteChecklistTemplateRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	template := new(entity.ChecklistTemplate)
+	if err := c.ChecklistTemplateRepository.FindById(tx, template, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find checklist template")
+		return fiber.NewError(fiber.StatusNotFound, "checklist template not found")
+	}
+
+	// The answers of a boarding point at the items of its version, a version in use can only be deactivated
+	total, err := c.BoardingRepository.CountByChecklistTemplateID(tx, template.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to count boardings of checklist template")
+		return fiber.ErrInternalServerError
+	}
+	if total > 0 {
+		return fiber.NewError(fiber.StatusConflict, "checklist template is used by boardings, deactivate it instead")
+	}
+
+	if err := c.ChecklistTemplateRepository.Delete(tx, template); err != nil {
+		c.Log.WithError(err).Error("failed to delete checklist template")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *ChecklistTemplateUseCaseImpl) List(ctx context.Context, request *model.ListChecklistTemplateRequest) (*model.WebResponse[[]model.ChecklistTemplateResponse], error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	query := tx.Model(&entity.ChecklistTemplate{})
+
+	if request.ShipType != nil && *request.ShipType != "" {
+		query = query.Where("LOWER(ship_type) = LOWER(?)", strings.TrimSpace(*request.ShipType))
+	}
+	if request.IsActive != nil {
+		query = query.Where("is_active = ?", *request.IsActive)
+	}
+
+	// Count total records
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.Log.WithError(err).Error("failed to count checklist templates")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Apply pagination
+	offset := (request.Page - 1) * request.Size
+	query = query.Order("ship_type ASC").Order("version DESC").Offset(offset).Limit(request.Size)
+
+	var templates []entity.ChecklistTemplate
+	if err := query.Find(&templates).Error; err != nil {
+		c.Log.WithError(err).Error("failed to find checklist templates")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.ChecklistTemplateResponse, len(templates))
+	for i, template := range templates {
+		responses[i] = *converter.ChecklistTemplateToResponse(&template)
+	}
+
+	return &model.WebResponse[[]model.ChecklistTemplateResponse]{
+		Data: responses,
+		Meta: model.NewPageMetadata(request.Page, request.Size, total),
+	}, nil
+}
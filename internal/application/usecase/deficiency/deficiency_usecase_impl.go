@@ -0,0 +1,285 @@
+package deficiency
+
+import (
+	"context"
+	"time"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/domain/repository"
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/internal/model/converter"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type DeficiencyUseCaseImpl struct {
+	DB                   *gorm.DB
+	Log                  *logrus.Logger
+	Validate             *validator.Validate
+	DeficiencyRepository repository.DeficiencyRepository
+	BoardingRepository   repository.BoardingRepository
+	ShipRepository       repository.ShipRepository
+	HarborRepository     repository.HarborRepository
+}
+
+func NewDeficiencyUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate,
+	deficiencyRepository repository.DeficiencyRepository, boardingRepository repository.BoardingRepository,
+	shipRepository repository.ShipRepository, harborRepository repository.HarborRepository) usecase.DeficiencyUseCase {
+	return &DeficiencyUseCaseImpl{
+		DB:                   db,
+		Log:                  log,
+		Validate:             validate,
+		DeficiencyRepository: deficiencyRepository,
+		BoardingRepository:   boardingRepository,
+		ShipRepository:       shipRepository,
+		HarborRepository:     harborRepository,
+	}
+}
+
+// findBoarding loads a boarding whose harbor and ship are both in the scope of the user
+func (c *DeficiencyUseCaseImpl) findBoarding(tx *gorm.DB, boarding *entity.Boarding, id string, userId string) error {
+	if err := c.BoardingRepository.FindById(tx, boarding, id); err != nil {
+		c.Log.WithError(err).Error("failed to find boarding")
+		return fiber.NewError(fiber.StatusNotFound, "boarding not found")
+	}
+
+	harbor := new(entity.Harbor)
+	if err := c.HarborRepository.FindByIdInScope(tx, harbor, boarding.HarborID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find harbor")
+		return fiber.NewError(fiber.StatusNotFound, "boarding not found")
+	}
+	if err := c.ShipRepository.FindByIdInScope(tx, new(entity.Ship), boarding.ShipID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find ship")
+		return fiber.NewError(fiber.StatusNotFound, "boarding not found")
+	}
+	return nil
+}
+
+// findDeficiency loads a deficiency of a ship in the tenant scope of the user, this is how operators follow up on the
+// deficiencies of their fleet
+func (c *DeficiencyUseCaseImpl) findDeficiency(tx *gorm.DB, deficiency *entity.Deficiency, id string, userId string) error {
+	if err := c.DeficiencyRepository.FindById(tx, deficiency, id); err != nil {
+		c.Log.WithError(err).Error("failed to find deficiency")
+		return fiber.NewError(fiber.StatusNotFound, "deficiency not found")
+	}
+
+	if err := c.ShipRepository.FindByIdInScope(tx, new(entity.Ship), deficiency.ShipID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find ship")
+		return fiber.NewError(fiber.StatusNotFound, "deficiency not found")
+	}
+	return nil
+}
+
+// findInspectedDeficiency loads a deficiency whose boarding is in the scope of the user, inspectors only change
+// deficiencies found in the harbors granted to them
+func (c *DeficiencyUseCaseImpl) findInspectedDeficiency(tx *gorm.DB, deficiency *entity.Deficiency, id string, userId string) error {
+	if err := c.DeficiencyRepository.FindById(tx, deficiency, id); err != nil {
+		c.Log.WithError(err).Error("failed to find deficiency")
+		return fiber.NewError(fiber.StatusNotFound, "deficiency not found")
+	}
+
+	if err := c.findBoarding(tx, new(entity.Boarding), deficiency.BoardingID, userId); err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "deficiency not found")
+	}
+	return nil
+}
+
+// Create records a deficiency found during a boarding, findings are written while the boarding is in progress or
+// once it is completed
+func (c *DeficiencyUseCaseImpl) Create(ctx context.Context, request *model.CreateDeficiencyRequest, userId string) (*model.DeficiencyResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	boarding := new(entity.Boarding)
+	if err := c.findBoarding(tx, boarding, request.BoardingID, userId); err != nil {
+		return nil, err
+	}
+
+	if boarding.Status != entity.BoardingStatusInProgress && boarding.Status != entity.BoardingStatusCompleted {
+		return nil, fiber.NewError(fiber.StatusConflict, "deficiencies can only be recorded on boardings in progress or completed")
+	}
+
+	deficiency := &entity.Deficiency{
+		ID:           uuid.NewString(),
+		BoardingID:   boarding.ID,
+		ShipID:       boarding.ShipID,
+		Code:         request.Code,
+		Severity:     request.Severity,
+		Description:  request.Description,
+		Deadline:     request.Deadline,
+		IsDetainable: request.IsDetainable,
+		Status:       entity.DeficiencyStatusOpen,
+		CreatedBy:    userId,
+	}
+
+	if err := c.DeficiencyRepository.Create(tx, deficiency); err != nil {
+		c.Log.WithError(err).Error("failed to create deficiency")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.DeficiencyToResponse(deficiency), nil
+}
+
+// Update corrects a deficiency that is not rectified yet, a new deadline decides again whether it is reported overdue
+func (c *DeficiencyUseCaseImpl) Update(ctx context.Context, request *model.UpdateDeficiencyRequest, userId string) (*model.DeficiencyResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	deficiency := new(entity.Deficiency)
+	if err := c.findInspectedDeficiency(tx, deficiency, request.ID, userId); err != nil {
+		return nil, err
+	}
+
+	if deficiency.Status != entity.DeficiencyStatusOpen {
+		return nil, fiber.NewError(fiber.StatusConflict, "only open or overdue deficiencies can be changed")
+	}
+
+	if request.Code != nil && *request.Code != "" {
+		deficiency.Code = *request.Code
+	}
+	if request.Severity != nil && *request.Severity != "" {
+		deficiency.Severity = *request.Severity
+	}
+	if request.Description != nil && *request.Description != "" {
+		deficiency.Description = *request.Description
+	}
+	if request.IsDetainable != nil {
+		deficiency.IsDetainable = *request.IsDetainable
+	}
+	if request.Deadline != nil {
+		deficiency.Deadline = request.Deadline
+	}
+
+	if err := c.DeficiencyRepository.Update(tx, deficiency); err != nil {
+		c.Log.WithError(err).Error("failed to update deficiency")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.DeficiencyToResponse(deficiency), nil
+}
+
+func (c *DeficiencyUseCaseImpl) Get(ctx context.Context, request *model.GetDeficiencyRequest, userId string) (*model.DeficiencyResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	deficiency := new(entity.Deficiency)
+	if err := c.findDeficiency(tx, deficiency, request.ID, userId); err != nil {
+		return nil, err
+	}
+
+	return converter.DeficiencyToResponse(deficiency), nil
+}
+
+func (c *DeficiencyUseCaseImpl) ListByBoarding(ctx context.Context, request *model.ListBoardingDeficiencyRequest, userId string) ([]model.DeficiencyResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	boarding := new(entity.Boarding)
+	if err := c.findBoarding(tx, boarding, request.BoardingID, userId); err != nil {
+		return nil, err
+	}
+
+	deficiencies, err := c.DeficiencyRepository.FindAllByBoardingID(tx, boarding.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find deficiencies")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.DeficiencyResponse, len(deficiencies))
+	for i, deficiency := range deficiencies {
+		responses[i] = *converter.DeficiencyToResponse(&deficiency)
+	}
+
+	return responses, nil
+}
+
+// ListByShip lists the deficiencies of a ship, by default the ones that are not verified yet with the nearest
+// deadline first
+func (c *DeficiencyUseCaseImpl) ListByShip(ctx context.Context, request *model.ListShipDeficiencyRequest, userId string) (*model.WebResponse[[]model.DeficiencyResponse], error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	ship := new(entity.Ship)
+	if err := c.ShipRepository.FindByIdInScope(tx, ship, request.ShipID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find ship")
+		return nil, fiber.NewError(fiber.StatusNotFound, "ship not found")
+	}
+
+	query := tx.Model(&entity.Deficiency{}).Where("ship_id = ?", ship.ID)
+
+	// Overdue is derived from the deadline, open only matches the deficiencies that are still within it
+	now := time.Now().UnixMilli()
+	if request.Status != nil && *request.Status == entity.DeficiencyStatusOverdue {
+		query = query.Where("status = ? AND deadline IS NOT NULL AND deadline < ?", entity.DeficiencyStatusOpen, now)
+	} else if request.Status != nil && *request.Status == entity.DeficiencyStatusOpen {
+		query = query.Where("status = ? AND (deadline IS NULL OR deadline >= ?)", entity.DeficiencyStatusOpen, now)
+	} else if request.Status != nil && *request.Status != "" {
+		query = query.Where("status = ?", *request.Status)
+	} else {
+		query = query.Where("status != ?", entity.DeficiencyStatusVerified)
+	}
+
+	// Count total records
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.Log.WithError(err).Error("failed to count deficiencies")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Apply pagination
+	offset := (request.Page - 1) * request.Size
+	query = query.Order("deadline ASC NULLS LAST").Order("created_at ASC").Offset(offset).Limit(request.Size)
+
+	var deficiencies []entity.Deficiency
+	if err := query.Find(&deficiencies).Error; err != nil {
+		c.Log.WithError(err).Error("failed to find deficiencies")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.DeficiencyResponse, len(deficiencies))
+	for i, deficiency := range deficiencies {
+		responses[i] = *converter.DeficiencyToResponse(&deficiency)
+	}
+
+	return &model.WebResponse[[]model.DeficiencyResponse]{
+		Data: responses,
+		Meta: model.NewPageMetadata(request.Page, request.Size, total),
+	}, nil
+}
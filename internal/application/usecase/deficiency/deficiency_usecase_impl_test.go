@@ -0,0 +1,334 @@
+package deficiency
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/domain/repository"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/test/fakedb"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type fakeDeficiencyRepository struct {
+	repository.DeficiencyRepository
+	deficiencies map[string]*entity.Deficiency
+}
+
+func (r *fakeDeficiencyRepository) FindById(_ *gorm.DB, deficiency *entity.Deficiency, id any) error {
+	found, ok := r.deficiencies[id.(string)]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	*deficiency = *found
+	return nil
+}
+
+func (r *fakeDeficiencyRepository) Update(_ *gorm.DB, deficiency *entity.Deficiency) error {
+	stored := *deficiency
+	r.deficiencies[deficiency.ID] = &stored
+	return nil
+}
+
+type fakeBoardingRepository struct {
+	repository.BoardingRepository
+	boardings map[string]entity.Boarding
+}
+
+func (r *fakeBoardingRepository) FindById(_ *gorm.DB, boarding *entity.Boarding, id any) error {
+	found, ok := r.boardings[id.(string)]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	*boarding = found
+	return nil
+}
+
+// fakeShipRepository holds the ships in the scope of the caller
+type fakeShipRepository struct {
+	repository.ShipRepository
+	inScope map[string]bool
+}
+
+func (r *fakeShipRepository) FindByIdInScope(_ *gorm.DB, ship *entity.Ship, id string, _ string) error {
+	if !r.inScope[id] {
+		return gorm.ErrRecordNotFound
+	}
+	ship.ID = id
+	return nil
+}
+
+// fakeHarborRepository holds the harbors in the scope of the caller
+type fakeHarborRepository struct {
+	repository.HarborRepository
+	inScope map[string]bool
+}
+
+func (r *fakeHarborRepository) FindByIdInScope(_ *gorm.DB, harbor *entity.Harbor, id string, _ string) error {
+	if !r.inScope[id] {
+		return gorm.ErrRecordNotFound
+	}
+	harbor.ID = id
+	return nil
+}
+
+// deficiencyTest is a deficiency use case of a caller who sees one ship and one harbor
+type deficiencyTest struct {
+	shipID       string
+	harborID     string
+	deficiencies *fakeDeficiencyRepository
+	boardings    *fakeBoardingRepository
+	recorder     *fakedb.Recorder
+	useCase      *DeficiencyUseCaseImpl
+}
+
+func newDeficiencyTest(t *testing.T) *deficiencyTest {
+	t.Helper()
+
+	db, recorder, err := fakedb.Open()
+	if err != nil {
+		t.Fatalf("open fake database: %v", err)
+	}
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	d := &deficiencyTest{
+		shipID:       uuid.NewString(),
+		harborID:     uuid.NewString(),
+		deficiencies: &fakeDeficiencyRepository{deficiencies: make(map[string]*entity.Deficiency)},
+		boardings:    &fakeBoardingRepository{boardings: make(map[string]entity.Boarding)},
+		recorder:     recorder,
+	}
+	d.useCase = &DeficiencyUseCaseImpl{
+		DB:                   db,
+		Log:                  log,
+		Validate:             validator.New(),
+		DeficiencyRepository: d.deficiencies,
+		BoardingRepository:   d.boardings,
+		ShipRepository:       &fakeShipRepository{inScope: map[string]bool{d.shipID: true}},
+		HarborRepository:     &fakeHarborRepository{inScope: map[string]bool{d.harborID: true}},
+	}
+	return d
+}
+
+// addDeficiency stores a deficiency found during a boarding of the ship in the harbor
+func (d *deficiencyTest) addDeficiency(shipID string, harborID string, status string, deadline *int64) *entity.Deficiency {
+	boarding := entity.Boarding{ID: uuid.NewString(), ShipID: shipID, HarborID: harborID, Status: entity.BoardingStatusCompleted}
+	d.boardings.boardings[boarding.ID] = boarding
+
+	deficiency := &entity.Deficiency{ID: uuid.NewString(), BoardingID: boarding.ID, ShipID: shipID, Status: status, Deadline: deadline}
+	d.deficiencies.deficiencies[deficiency.ID] = deficiency
+	return deficiency
+}
+
+func assertError(t *testing.T, err error, status int, message string) {
+	t.Helper()
+
+	fiberErr, ok := err.(*fiber.Error)
+	if !ok || fiberErr.Code != status || (message != "" && fiberErr.Message != message) {
+		t.Fatalf("expected %d %q, got %v", status, message, err)
+	}
+}
+
+func millis(offset time.Duration) *int64 {
+	value := time.Now().Add(offset).UnixMilli()
+	return &value
+}
+
+// TestGetDeficiencyInScope checks operators follow up on the deficiencies of their fleet wherever they were found
+func TestGetDeficiencyInScope(t *testing.T) {
+	tests := []struct {
+		name       string
+		ownShip    bool
+		ownHarbor  bool
+		deadline   *int64
+		wantStatus string
+	}{
+		{name: "ship in scope", ownShip: true, ownHarbor: true, wantStatus: entity.DeficiencyStatusOpen},
+		{name: "ship in scope found in another harbor", ownShip: true, wantStatus: entity.DeficiencyStatusOpen},
+		{name: "open past the deadline reads as overdue", ownShip: true, deadline: millis(-time.Hour), wantStatus: entity.DeficiencyStatusOverdue},
+		{name: "ship outside the scope", ownHarbor: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDeficiencyTest(t)
+			shipID, harborID := uuid.NewString(), uuid.NewString()
+			if tt.ownShip {
+				shipID = d.shipID
+			}
+			if tt.ownHarbor {
+				harborID = d.harborID
+			}
+			deficiency := d.addDeficiency(shipID, harborID, entity.DeficiencyStatusOpen, tt.deadline)
+
+			res, err := d.useCase.Get(context.Background(), &model.GetDeficiencyRequest{ID: deficiency.ID}, uuid.NewString())
+			if tt.wantStatus == "" {
+				assertError(t, err, fiber.StatusNotFound, "deficiency not found")
+				return
+			}
+			if err != nil {
+				t.Fatalf("get: %v", err)
+			}
+			if res.Status != tt.wantStatus {
+				t.Fatalf("expected status %s, got %s", tt.wantStatus, res.Status)
+			}
+		})
+	}
+
+	d := newDeficiencyTest(t)
+	_, err := d.useCase.Get(context.Background(), &model.GetDeficiencyRequest{ID: uuid.NewString()}, uuid.NewString())
+	assertError(t, err, fiber.StatusNotFound, "deficiency not found")
+}
+
+// TestVerifyDeficiencyInScope checks inspectors only verify the deficiencies of boardings in their scope
+func TestVerifyDeficiencyInScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		ownShip   bool
+		ownHarbor bool
+		status    string
+		error     int
+		message   string
+	}{
+		{name: "rectified deficiency in scope", ownShip: true, ownHarbor: true, status: entity.DeficiencyStatusRectified},
+		{name: "boarding in a harbor outside the scope", ownShip: true, status: entity.DeficiencyStatusRectified, error: fiber.StatusNotFound, message: "deficiency not found"},
+		{name: "boarding of a ship outside the scope", ownHarbor: true, status: entity.DeficiencyStatusRectified, error: fiber.StatusNotFound, message: "deficiency not found"},
+		{name: "open deficiency", ownShip: true, ownHarbor: true, status: entity.DeficiencyStatusOpen, error: fiber.StatusConflict, message: "a open deficiency can not become verified"},
+		{name: "verified deficiency", ownShip: true, ownHarbor: true, status: entity.DeficiencyStatusVerified, error: fiber.StatusConflict, message: "a verified deficiency can not become verified"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDeficiencyTest(t)
+			shipID, harborID := uuid.NewString(), uuid.NewString()
+			if tt.ownShip {
+				shipID = d.shipID
+			}
+			if tt.ownHarbor {
+				harborID = d.harborID
+			}
+			deficiency := d.addDeficiency(shipID, harborID, tt.status, nil)
+
+			userID := uuid.NewString()
+			res, err := d.useCase.Verify(context.Background(), &model.VerifyDeficiencyRequest{ID: deficiency.ID}, userID)
+			if tt.error != 0 {
+				assertError(t, err, tt.error, tt.message)
+				if d.deficiencies.deficiencies[deficiency.ID].Status != tt.status {
+					t.Fatalf("a refused verification changed the deficiency")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+			stored := d.deficiencies.deficiencies[deficiency.ID]
+			if res.Status != entity.DeficiencyStatusVerified || stored.VerifiedBy == nil || *stored.VerifiedBy != userID {
+				t.Fatalf("expected a deficiency verified by %s, got %+v", userID, stored)
+			}
+		})
+	}
+}
+
+func TestRectifyOverdueDeficiency(t *testing.T) {
+	d := newDeficiencyTest(t)
+	deficiency := d.addDeficiency(d.shipID, uuid.NewString(), entity.DeficiencyStatusOpen, millis(-time.Hour))
+
+	res, err := d.useCase.Rectify(context.Background(), &model.RectifyDeficiencyRequest{ID: deficiency.ID, Notes: "replaced"}, uuid.NewString())
+	if err != nil {
+		t.Fatalf("rectify: %v", err)
+	}
+	if res.Status != entity.DeficiencyStatusRectified {
+		t.Fatalf("expected a rectified deficiency, got %s", res.Status)
+	}
+}
+
+// TestListByShipStatusFilter checks the status filter against the stored status and the deadline, overdue is never
+// stored
+func TestListByShipStatusFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		status *string
+		where  string
+		vars   []interface{}
+		now    bool
+	}{
+		{
+			name:   "overdue",
+			status: ptr(entity.DeficiencyStatusOverdue),
+			where:  `(status = $2 AND deadline IS NOT NULL AND deadline < $3)`,
+			vars:   []interface{}{entity.DeficiencyStatusOpen},
+			now:    true,
+		},
+		{
+			name:   "open",
+			status: ptr(entity.DeficiencyStatusOpen),
+			where:  `(status = $2 AND (deadline IS NULL OR deadline >= $3))`,
+			vars:   []interface{}{entity.DeficiencyStatusOpen},
+			now:    true,
+		},
+		{
+			name:   "rectified",
+			status: ptr(entity.DeficiencyStatusRectified),
+			where:  `status = $2`,
+			vars:   []interface{}{entity.DeficiencyStatusRectified},
+		},
+		{
+			name:  "not verified yet",
+			where: `status != $2`,
+			vars:  []interface{}{entity.DeficiencyStatusVerified},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDeficiencyTest(t)
+
+			before := time.Now().UnixMilli()
+			_, err := d.useCase.ListByShip(context.Background(), &model.ListShipDeficiencyRequest{ShipID: d.shipID, Page: 1, Size: 10, Status: tt.status}, uuid.NewString())
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			after := time.Now().UnixMilli()
+
+			statements := d.recorder.Statements()
+			if len(statements) != 2 {
+				t.Fatalf("expected a count and a select, got %v", statements)
+			}
+			for _, statement := range statements {
+				if !strings.Contains(statement.SQL, `ship_id = $1 AND `+tt.where) {
+					t.Fatalf("expected %q in %s", tt.where, statement.SQL)
+				}
+				if statement.Vars[0] != d.shipID || statement.Vars[1] != tt.vars[0] {
+					t.Fatalf("expected the ship and %v, got %v", tt.vars, statement.Vars)
+				}
+				if tt.now {
+					now, ok := statement.Vars[2].(int64)
+					if !ok || now < before || now > after {
+						t.Fatalf("expected the current time in milliseconds, got %v", statement.Vars[2])
+					}
+				}
+			}
+		})
+	}
+
+	d := newDeficiencyTest(t)
+	_, err := d.useCase.ListByShip(context.Background(), &model.ListShipDeficiencyRequest{ShipID: uuid.NewString(), Page: 1, Size: 10}, uuid.NewString())
+	assertError(t, err, fiber.StatusNotFound, "ship not found")
+	if len(d.recorder.Statements()) != 0 {
+		t.Fatalf("a ship outside the scope was queried")
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
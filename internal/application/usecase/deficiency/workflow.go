@@ -0,0 +1,77 @@
+package deficiency
+
+import (
+	"context"
+	"time"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/internal/model/converter"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Rectify records the corrective action reported for the ship, it waits for an inspector to verify it
+func (c *DeficiencyUseCaseImpl) Rectify(ctx context.Context, request *model.RectifyDeficiencyRequest, userId string) (*model.DeficiencyResponse, error) {
+	return c.transition(ctx, request, request.ID, userId, entity.DeficiencyStatusRectified, c.findDeficiency, func(deficiency *entity.Deficiency, now int64) {
+		deficiency.RectifiedAt = &now
+		deficiency.RectifiedBy = &userId
+		deficiency.RectificationNotes = &request.Notes
+	})
+}
+
+// Verify confirms the corrective action of a rectified deficiency, which closes it
+func (c *DeficiencyUseCaseImpl) Verify(ctx context.Context, request *model.VerifyDeficiencyRequest, userId string) (*model.DeficiencyResponse, error) {
+	return c.transition(ctx, request, request.ID, userId, entity.DeficiencyStatusVerified, c.findInspectedDeficiency, func(deficiency *entity.Deficiency, now int64) {
+		deficiency.VerifiedAt = &now
+		deficiency.VerifiedBy = &userId
+		deficiency.VerificationNotes = request.Notes
+	})
+}
+
+// Reopen rejects the corrective action of a rectified deficiency, the notes explain why. The last reported action
+// stays on record until the ship rectifies again.
+func (c *DeficiencyUseCaseImpl) Reopen(ctx context.Context, request *model.ReopenDeficiencyRequest, userId string) (*model.DeficiencyResponse, error) {
+	return c.transition(ctx, request, request.ID, userId, entity.DeficiencyStatusOpen, c.findInspectedDeficiency, func(deficiency *entity.Deficiency, now int64) {
+		deficiency.VerificationNotes = &request.Notes
+	})
+}
+
+// transition moves a deficiency found with find to the status, apply records what the step changes
+func (c *DeficiencyUseCaseImpl) transition(ctx context.Context, request any, id string, userId string, status string,
+	find func(tx *gorm.DB, deficiency *entity.Deficiency, id string, userId string) error,
+	apply func(deficiency *entity.Deficiency, now int64)) (*model.DeficiencyResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	deficiency := new(entity.Deficiency)
+	if err := find(tx, deficiency, id, userId); err != nil {
+		return nil, err
+	}
+
+	if err := entity.DeficiencyLifecycle.Check(deficiency.Status, status); err != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, err.Error())
+	}
+
+	now := time.Now().UnixMilli()
+	apply(deficiency, now)
+	deficiency.Status = status
+
+	if err := c.DeficiencyRepository.Update(tx, deficiency); err != nil {
+		c.Log.WithError(err).Error("failed to update deficiency")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.DeficiencyToResponse(deficiency), nil
+}
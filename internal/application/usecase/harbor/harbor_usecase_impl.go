@@ -16,22 +16,34 @@ import (
 )
 
 type HarborUseCaseImpl struct {
-	DB               *gorm.DB
-	Log              *logrus.Logger
-	Validate         *validator.Validate
-	HarborRepository repository.HarborRepository
+	DB                   *gorm.DB
+	Log                  *logrus.Logger
+	Validate             *validator.Validate
+	HarborRepository     repository.HarborRepository
+	RoleHarborRepository repository.RoleHarborRepository
+	UserRoleRepository   repository.UserRoleRepository
+	BoardingRepository   repository.BoardingRepository
+	PortCallRepository   repository.PortCallRepository
+	BerthRepository      repository.BerthRepository
 }
 
-func NewHarborUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, harborRepository repository.HarborRepository) usecase.HarborUseCase {
+func NewHarborUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, harborRepository repository.HarborRepository,
+	roleHarborRepository repository.RoleHarborRepository, userRoleRepository repository.UserRoleRepository, boardingRepository repository.BoardingRepository,
+	portCallRepository repository.PortCallRepository, berthRepository repository.BerthRepository) usecase.HarborUseCase {
 	return &HarborUseCaseImpl{
-		DB:               db,
-		Log:              log,
-		Validate:         validate,
-		HarborRepository: harborRepository,
+		DB:                   db,
+		Log:                  log,
+		Validate:             validate,
+		HarborRepository:     harborRepository,
+		RoleHarborRepository: roleHarborRepository,
+		UserRoleRepository:   userRoleRepository,
+		BoardingRepository:   boardingRepository,
+		PortCallRepository:   portCallRepository,
+		BerthRepository:      berthRepository,
 	}
 }
 
-func (c *HarborUseCaseImpl) Create(ctx context.Context, request *model.CreateHarborRequest) (*model.HarborResponse, error) {
+func (c *HarborUseCaseImpl) Create(ctx context.Context, request *model.CreateHarborRequest, userId string) (*model.HarborResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
@@ -141,6 +153,30 @@ func (c *HarborUseCaseImpl) Create(ctx context.Context, request *model.CreateHar
 		return nil, fiber.ErrInternalServerError
 	}
 
+	// Grant the new harbor to the creator's roles, otherwise nobody could see it. A service account grants it to the
+	// roles of the user that created the account, without any role the harbor would be invisible and is not created
+	userRoles, err := c.UserRoleRepository.FindAllInScope(tx, userId)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find user roles")
+		return nil, fiber.ErrInternalServerError
+	}
+	if len(userRoles) == 0 {
+		c.Log.Warnf("Harbor %s can not be created by %s which has no role to grant it to", harbor.HarborCode, userId)
+		return nil, fiber.NewError(fiber.StatusForbidden, "no role to grant the harbor to, it would not be visible")
+	}
+
+	for _, userRole := range userRoles {
+		roleHarbor := &entity.RoleHarbor{
+			RoleID:   userRole.RoleID,
+			HarborID: harbor.ID,
+		}
+
+		if err := c.RoleHarborRepository.Create(tx, roleHarbor); err != nil {
+			c.Log.WithError(err).Error("failed to create role harbor")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.WithError(err).Error("failed to commit transaction")
 		return nil, fiber.ErrInternalServerError
@@ -149,7 +185,7 @@ func (c *HarborUseCaseImpl) Create(ctx context.Context, request *model.CreateHar
 	return converter.HarborToResponse(harbor), nil
 }
 
-func (c *HarborUseCaseImpl) Update(ctx context.Context, request *model.UpdateHarborRequest) (*model.HarborResponse, error) {
+func (c *HarborUseCaseImpl) Update(ctx context.Context, request *model.UpdateHarborRequest, userId string) (*model.HarborResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
@@ -159,7 +195,8 @@ func (c *HarborUseCaseImpl) Update(ctx context.Context, request *model.UpdateHar
 	}
 
 	harbor := &entity.Harbor{}
-	if err := c.HarborRepository.FindById(tx, harbor, request.ID); err != nil {
+	// Harbors outside the user's scope are reported as missing so their existence is not revealed
+	if err := c.HarborRepository.FindByIdInScope(tx, harbor, request.ID, userId); err != nil {
 		c.Log.WithError(err).Error("failed to find harbor")
 		return nil, fiber.ErrNotFound
 	}
@@ -277,7 +314,7 @@ func (c *HarborUseCaseImpl) Update(ctx context.Context, request *model.UpdateHar
 	return converter.HarborToResponse(harbor), nil
 }
 
-func (c *HarborUseCaseImpl) Get(ctx context.Context, request *model.GetHarborRequest) (*model.HarborResponse, error) {
+func (c *HarborUseCaseImpl) Get(ctx context.Context, request *model.GetHarborRequest, userId string) (*model.HarborResponse, error) {
 	tx := c.DB.WithContext(ctx)
 
 	if err := c.Validate.Struct(request); err != nil {
@@ -286,7 +323,8 @@ func (c *HarborUseCaseImpl) Get(ctx context.Context, request *model.GetHarborReq
 	}
 
 	harbor := &entity.Harbor{}
-	if err := c.HarborRepository.FindById(tx, harbor, request.ID); err != nil {
+	// Harbors outside the user's scope are reported as missing so their existence is not revealed
+	if err := c.HarborRepository.FindByIdInScope(tx, harbor, request.ID, userId); err != nil {
 		c.Log.WithError(err).Error("failed to find harbor")
 		return nil, fiber.ErrNotFound
 	}
@@ -294,7 +332,7 @@ func (c *HarborUseCaseImpl) Get(ctx context.Context, request *model.GetHarborReq
 	return converter.HarborToResponse(harbor), nil
 }
 
-func (c *HarborUseCaseImpl) Delete(ctx context.Context, request *model.DeleteHarborRequest) error {
+func (c *HarborUseCaseImpl) Delete(ctx context.Context, request *model.DeleteHarborRequest, userId string) error {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
@@ -304,11 +342,32 @@ func (c *HarborUseCaseImpl) Delete(ctx context.Context, request *model.DeleteHar
 	}
 
 	harbor := &entity.Harbor{}
-	if err := c.HarborRepository.FindById(tx, harbor, request.ID); err != nil {
+	// Harbors outside the user's scope are reported as missing so their existence is not revealed
+	if err := c.HarborRepository.FindByIdInScope(tx, harbor, request.ID, userId); err != nil {
 		c.Log.WithError(err).Error("failed to find harbor")
 		return fiber.ErrNotFound
 	}
 
+	// Boardings and port calls at the harbor are kept as its history, a harbor that has any can only be deactivated
+	if count, err := c.BoardingRepository.CountByHarborID(tx, harbor.ID); err != nil {
+		c.Log.WithError(err).Error("failed to count boardings of harbor")
+		return fiber.ErrInternalServerError
+	} else if count > 0 {
+		return fiber.NewError(fiber.StatusConflict, "harbor has boardings, deactivate it instead")
+	}
+	if count, err := c.PortCallRepository.CountByHarborID(tx, harbor.ID); err != nil {
+		c.Log.WithError(err).Error("failed to count port calls of harbor")
+		return fiber.ErrInternalServerError
+	} else if count > 0 {
+		return fiber.NewError(fiber.StatusConflict, "harbor has port calls, deactivate it instead")
+	}
+	if count, err := c.BerthRepository.CountByHarborID(tx, harbor.ID); err != nil {
+		c.Log.WithError(err).Error("failed to count berths of harbor")
+		return fiber.ErrInternalServerError
+	} else if count > 0 {
+		return fiber.NewError(fiber.StatusConflict, "harbor has berths, delete them first")
+	}
+
 	if err := c.HarborRepository.Delete(tx, harbor); err != nil {
 		c.Log.WithError(err).Error("failed to delete harbor")
 		return fiber.ErrInternalServerError
@@ -330,15 +389,9 @@ func (c *HarborUseCaseImpl) List(ctx context.Context, request *model.ListHarborR
 		return nil, fiber.ErrBadRequest
 	}
 
-	var userRole entity.UserRole
-	err := tx.Model(&entity.UserRole{}).Where("user_id = ?", userId).First(&userRole).Error
-
-	if err != nil {
-		c.Log.WithError(err).Error("failed to find user role")
-		return nil, fiber.ErrInternalServerError
-	}
-
-	query := tx.Model(&entity.Harbor{}).Where("deleted_at IS NULL")
+	// Only harbors granted through any of the user's roles, applied before counting so the total matches the pages
+	query := tx.Model(&entity.Harbor{}).Where("deleted_at IS NULL").
+		Where("harbors.id IN (?)", c.HarborRepository.SelectIDsInScope(tx, userId))
 
 	if request.IsActive != nil {
 		query = query.Where("is_active = ?", *request.IsActive)
@@ -363,8 +416,6 @@ func (c *HarborUseCaseImpl) List(ctx context.Context, request *model.ListHarborR
 		return nil, fiber.ErrInternalServerError
 	}
 
-	query = query.Joins("INNER JOIN role_harbors AS rh ON rh.harbor_id = harbors.id").Where("rh.role_id = ?", userRole.RoleID)
-
 	// Apply pagination
 	offset := (request.Page - 1) * request.Size
 	query = query.Offset(offset).Limit(request.Size)
@@ -380,30 +431,8 @@ func (c *HarborUseCaseImpl) List(ctx context.Context, request *model.ListHarborR
 		responses[i] = *converter.HarborToResponse(&harbor)
 	}
 
-	lastPage := (total + int64(request.Size) - 1) / int64(request.Size)
-	if lastPage == 0 {
-		lastPage = 1
-	}
-
-	from := (request.Page-1)*request.Size + 1
-	to := request.Page * request.Size
-	if int64(to) > total {
-		to = int(total)
-	}
-	if total == 0 {
-		from = 0
-		to = 0
-	}
-
 	return &model.WebResponse[[]model.HarborResponse]{
 		Data: responses,
-		Meta: &model.PageMetadata{
-			CurrentPage: request.Page,
-			PerPage:     request.Size,
-			Total:       total,
-			LastPage:    lastPage,
-			From:        from,
-			To:          to,
-		},
+		Meta: model.NewPageMetadata(request.Page, request.Size, total),
 	}, nil
 }
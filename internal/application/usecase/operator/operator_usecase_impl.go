@@ -271,30 +271,8 @@ func (c *OperatorUseCaseImpl) List(ctx context.Context, request *model.ListOpera
 		responses[i] = *converter.OperatorToResponse(&operator)
 	}
 
-	lastPage := (total + int64(request.Size) - 1) / int64(request.Size)
-	if lastPage == 0 {
-		lastPage = 1
-	}
-	
-	from := (request.Page-1)*request.Size + 1
-	to := request.Page * request.Size
-	if int64(to) > total {
-		to = int(total)
-	}
-	if total == 0 {
-		from = 0
-		to = 0
-	}
-	
 	return &model.WebResponse[[]model.OperatorResponse]{
 		Data: responses,
-		Meta: &model.PageMetadata{
-			CurrentPage: request.Page,
-			PerPage:     request.Size,
-			Total:       total,
-			LastPage:    lastPage,
-			From:        from,
-			To:          to,
-		},
+		Meta: model.NewPageMetadata(request.Page, request.Size, total),
 	}, nil
 }
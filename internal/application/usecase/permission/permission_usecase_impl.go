@@ -254,30 +254,30 @@ func (c *PermissionUseCaseImpl) List(ctx context.Context, request *model.ListPer
 		responses[i] = *converter.PermissionToResponse(&permission)
 	}
 
-	lastPage := (total + int64(request.Size) - 1) / int64(request.Size)
-	if lastPage == 0 {
-		lastPage = 1
-	}
-	
-	from := (request.Page-1)*request.Size + 1
-	to := request.Page * request.Size
-	if int64(to) > total {
-		to = int(total)
-	}
-	if total == 0 {
-		from = 0
-		to = 0
-	}
-	
 	return &model.WebResponse[[]model.PermissionResponse]{
 		Data: responses,
-		Meta: &model.PageMetadata{
-			CurrentPage: request.Page,
-			PerPage:     request.Size,
-			Total:       total,
-			LastPage:    lastPage,
-			From:        from,
-			To:          to,
-		},
+		Meta: model.NewPageMetadata(request.Page, request.Size, total),
 	}, nil
 }
+
+func (c *PermissionUseCaseImpl) FindNamesByUserID(ctx context.Context, userID string) ([]string, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if userID == "" {
+		c.Log.Error("userID is required")
+		return nil, fiber.ErrBadRequest
+	}
+
+	permissions, err := c.PermissionRepository.FindAllByUserID(tx, userID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find permissions by user id")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	names := make([]string, len(permissions))
+	for i, permission := range permissions {
+		names[i] = permission.Name
+	}
+
+	return names, nil
+}
@@ -0,0 +1,101 @@
+package port_call
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/internal/model/converter"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Arrive records the actual time of arrival, a ship is in port at one harbor at a time
+func (c *PortCallUseCaseImpl) Arrive(ctx context.Context, request *model.ArrivePortCallRequest, userId string) (*model.PortCallResponse, error) {
+	return c.transition(ctx, request, request.ID, userId, func(tx *gorm.DB, portCall *entity.PortCall, now int64) (string, error) {
+		if count, err := c.PortCallRepository.CountInPortByShipID(tx, portCall.ShipID, portCall.ID); err != nil {
+			c.Log.WithError(err).Error("failed to count port calls in port")
+			return "", fiber.ErrInternalServerError
+		} else if count > 0 {
+			return "", fiber.NewError(fiber.StatusConflict, "the ship is still in port at another harbor")
+		}
+
+		ata := now
+		if request.ATA != nil {
+			ata = *request.ATA
+		}
+		portCall.ATA = &ata
+		return entity.PortCallStatusInPort, nil
+	})
+}
+
+// Close ends a port call, a ship in port departs at the actual time of departure and an expected call is cancelled
+func (c *PortCallUseCaseImpl) Close(ctx context.Context, request *model.ClosePortCallRequest, userId string) (*model.PortCallResponse, error) {
+	return c.transition(ctx, request, request.ID, userId, func(tx *gorm.DB, portCall *entity.PortCall, now int64) (string, error) {
+		if request.Remarks != nil {
+			portCall.Remarks = request.Remarks
+		}
+
+		if portCall.Status == entity.PortCallStatusExpected {
+			return entity.PortCallStatusCancelled, nil
+		}
+
+		atd := now
+		if request.ATD != nil {
+			atd = *request.ATD
+		}
+		if portCall.ATA != nil && atd < *portCall.ATA {
+			return "", fiber.NewError(fiber.StatusBadRequest, "atd can not be before ata")
+		}
+		portCall.ATD = &atd
+		return entity.PortCallStatusDeparted, nil
+	})
+}
+
+// transition moves a port call in the scope of the user to the status apply returns, apply records what the step
+// changes
+func (c *PortCallUseCaseImpl) transition(ctx context.Context, request any, id string, userId string,
+	apply func(tx *gorm.DB, portCall *entity.PortCall, now int64) (string, error)) (*model.PortCallResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	portCall := new(entity.PortCall)
+	if err := c.findPortCall(tx, portCall, id, userId); err != nil {
+		return nil, err
+	}
+
+	// Closed calls are final, the status check runs before apply looks at the call
+	if entity.PortCallLifecycle.IsFinal(portCall.Status) {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("the port call is already %s", portCall.Status))
+	}
+
+	status, err := apply(tx, portCall, time.Now().UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := entity.PortCallLifecycle.Check(portCall.Status, status); err != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, err.Error())
+	}
+	portCall.Status = status
+
+	if err := c.PortCallRepository.Update(tx, portCall); err != nil {
+		c.Log.WithError(err).Error("failed to update port call")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.PortCallToResponse(portCall), nil
+}
@@ -0,0 +1,257 @@
+package port_call
+
+import (
+	"context"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/domain/repository"
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/internal/model/converter"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PortCallUseCaseImpl struct {
+	DB                 *gorm.DB
+	Log                *logrus.Logger
+	Validate           *validator.Validate
+	PortCallRepository repository.PortCallRepository
+	ShipRepository     repository.ShipRepository
+	HarborRepository   repository.HarborRepository
+}
+
+func NewPortCallUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate,
+	portCallRepository repository.PortCallRepository, shipRepository repository.ShipRepository,
+	harborRepository repository.HarborRepository) usecase.PortCallUseCase {
+	return &PortCallUseCaseImpl{
+		DB:                 db,
+		Log:                log,
+		Validate:           validate,
+		PortCallRepository: portCallRepository,
+		ShipRepository:     shipRepository,
+		HarborRepository:   harborRepository,
+	}
+}
+
+// findPortCall loads a port call with its ship and harbor, a port call is only visible while both its harbor and its
+// ship are in the scope of the user
+func (c *PortCallUseCaseImpl) findPortCall(tx *gorm.DB, portCall *entity.PortCall, id string, userId string) error {
+	if err := c.PortCallRepository.FindById(tx, portCall, id); err != nil {
+		c.Log.WithError(err).Error("failed to find port call")
+		return fiber.NewError(fiber.StatusNotFound, "port call not found")
+	}
+
+	if err := c.HarborRepository.FindByIdInScope(tx, &portCall.Harbor, portCall.HarborID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find harbor")
+		return fiber.NewError(fiber.StatusNotFound, "port call not found")
+	}
+	if err := c.ShipRepository.FindByIdInScope(tx, &portCall.Ship, portCall.ShipID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find ship")
+		return fiber.NewError(fiber.StatusNotFound, "port call not found")
+	}
+	return nil
+}
+
+// checkSchedule makes sure the ship is not expected to leave before it arrives
+func checkSchedule(portCall *entity.PortCall) error {
+	if portCall.ETD != nil && *portCall.ETD < portCall.ETA {
+		return fiber.NewError(fiber.StatusBadRequest, "etd can not be before eta")
+	}
+	return nil
+}
+
+// Create declares the expected call of a ship in the scope of the user at a harbor granted to the user
+func (c *PortCallUseCaseImpl) Create(ctx context.Context, request *model.CreatePortCallRequest, userId string) (*model.PortCallResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	ship := new(entity.Ship)
+	if err := c.ShipRepository.FindByIdInScope(tx, ship, request.ShipID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find ship")
+		return nil, fiber.NewError(fiber.StatusNotFound, "ship not found")
+	}
+
+	harbor := new(entity.Harbor)
+	if err := c.HarborRepository.FindByIdInScope(tx, harbor, request.HarborID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find harbor")
+		return nil, fiber.NewError(fiber.StatusNotFound, "harbor not found")
+	}
+
+	portCall := &entity.PortCall{
+		ID:           uuid.NewString(),
+		ShipID:       ship.ID,
+		HarborID:     harbor.ID,
+		Status:       entity.PortCallStatusExpected,
+		ETA:          request.ETA,
+		ETD:          request.ETD,
+		PreviousPort: request.PreviousPort,
+		NextPort:     request.NextPort,
+		Purpose:      request.Purpose,
+		Remarks:      request.Remarks,
+		DeclaredBy:   userId,
+	}
+
+	if err := checkSchedule(portCall); err != nil {
+		return nil, err
+	}
+
+	if err := c.PortCallRepository.Create(tx, portCall); err != nil {
+		c.Log.WithError(err).Error("failed to create port call")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	portCall.Ship = *ship
+	portCall.Harbor = *harbor
+	return converter.PortCallToResponse(portCall), nil
+}
+
+// Update changes the schedule, ports, purpose or remarks of a call that is not closed yet
+func (c *PortCallUseCaseImpl) Update(ctx context.Context, request *model.UpdatePortCallRequest, userId string) (*model.PortCallResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	portCall := new(entity.PortCall)
+	if err := c.findPortCall(tx, portCall, request.ID, userId); err != nil {
+		return nil, err
+	}
+
+	if portCall.Status != entity.PortCallStatusExpected && portCall.Status != entity.PortCallStatusInPort {
+		return nil, fiber.NewError(fiber.StatusConflict, "closed port calls can not be changed")
+	}
+
+	if request.ETA != nil {
+		portCall.ETA = *request.ETA
+	}
+	if request.ETD != nil {
+		portCall.ETD = request.ETD
+	}
+	if request.PreviousPort != nil {
+		portCall.PreviousPort = request.PreviousPort
+	}
+	if request.NextPort != nil {
+		portCall.NextPort = request.NextPort
+	}
+	if request.Purpose != nil && *request.Purpose != "" {
+		portCall.Purpose = *request.Purpose
+	}
+	if request.Remarks != nil {
+		portCall.Remarks = request.Remarks
+	}
+
+	if err := checkSchedule(portCall); err != nil {
+		return nil, err
+	}
+
+	if err := c.PortCallRepository.Update(tx, portCall); err != nil {
+		c.Log.WithError(err).Error("failed to update port call")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.PortCallToResponse(portCall), nil
+}
+
+func (c *PortCallUseCaseImpl) Get(ctx context.Context, request *model.GetPortCallRequest, userId string) (*model.PortCallResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	portCall := new(entity.PortCall)
+	if err := c.findPortCall(tx, portCall, request.ID, userId); err != nil {
+		return nil, err
+	}
+
+	return converter.PortCallToResponse(portCall), nil
+}
+
+// ListByHarbor lists the port calls of a harbor granted to the user with their ships, operator users only see the
+// calls of their own fleet. In port ships come latest arrival first, expected ships earliest arrival first and
+// departed ships latest departure first.
+func (c *PortCallUseCaseImpl) ListByHarbor(ctx context.Context, request *model.ListHarborPortCallRequest, userId string) (*model.WebResponse[[]model.PortCallResponse], error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	harbor := new(entity.Harbor)
+	if err := c.HarborRepository.FindByIdInScope(tx, harbor, request.HarborID, userId); err != nil {
+		c.Log.WithError(err).Error("failed to find harbor")
+		return nil, fiber.NewError(fiber.StatusNotFound, "harbor not found")
+	}
+
+	query := tx.Model(&entity.PortCall{}).Where("harbor_id = ? AND ship_id IN (?)", harbor.ID, c.ShipRepository.SelectIDsInScope(tx, userId))
+
+	if request.ShipID != nil && *request.ShipID != "" {
+		query = query.Where("ship_id = ?", *request.ShipID)
+	}
+
+	order := "eta DESC"
+	if request.Status != nil && *request.Status != "" {
+		query = query.Where("status = ?", *request.Status)
+
+		switch *request.Status {
+		case entity.PortCallStatusInPort:
+			order = "ata DESC"
+		case entity.PortCallStatusExpected:
+			order = "eta ASC"
+		case entity.PortCallStatusDeparted:
+			order = "atd DESC"
+		}
+	}
+
+	// Count total records
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.Log.WithError(err).Error("failed to count port calls")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Apply pagination
+	offset := (request.Page - 1) * request.Size
+	query = query.Order(order).Offset(offset).Limit(request.Size)
+
+	var portCalls []entity.PortCall
+	if err := query.Preload("Ship").Find(&portCalls).Error; err != nil {
+		c.Log.WithError(err).Error("failed to find port calls")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.PortCallResponse, len(portCalls))
+	for i, portCall := range portCalls {
+		responses[i] = *converter.PortCallToResponse(&portCall)
+	}
+
+	return &model.WebResponse[[]model.PortCallResponse]{
+		Data: responses,
+		Meta: model.NewPageMetadata(request.Page, request.Size, total),
+	}, nil
+}
@@ -0,0 +1,258 @@
+package port_call
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/domain/repository"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/test/fakedb"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type fakePortCallRepository struct {
+	repository.PortCallRepository
+	portCalls map[string]*entity.PortCall
+}
+
+func (r *fakePortCallRepository) Create(_ *gorm.DB, portCall *entity.PortCall) error {
+	stored := *portCall
+	r.portCalls[portCall.ID] = &stored
+	return nil
+}
+
+func (r *fakePortCallRepository) Update(db *gorm.DB, portCall *entity.PortCall) error {
+	return r.Create(db, portCall)
+}
+
+func (r *fakePortCallRepository) FindById(_ *gorm.DB, portCall *entity.PortCall, id any) error {
+	found, ok := r.portCalls[id.(string)]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	*portCall = *found
+	return nil
+}
+
+func (r *fakePortCallRepository) CountInPortByShipID(_ *gorm.DB, shipID string, excludeID string) (int64, error) {
+	var total int64
+	for _, portCall := range r.portCalls {
+		if portCall.ShipID == shipID && portCall.Status == entity.PortCallStatusInPort && portCall.ID != excludeID {
+			total++
+		}
+	}
+	return total, nil
+}
+
+// fakeShipRepository holds the ships in the scope of the caller
+type fakeShipRepository struct {
+	repository.ShipRepository
+	inScope map[string]bool
+}
+
+func (r *fakeShipRepository) FindByIdInScope(_ *gorm.DB, ship *entity.Ship, id string, _ string) error {
+	if !r.inScope[id] {
+		return gorm.ErrRecordNotFound
+	}
+	ship.ID = id
+	return nil
+}
+
+// fakeHarborRepository holds the harbors in the scope of the caller
+type fakeHarborRepository struct {
+	repository.HarborRepository
+	inScope map[string]bool
+}
+
+func (r *fakeHarborRepository) FindByIdInScope(_ *gorm.DB, harbor *entity.Harbor, id string, _ string) error {
+	if !r.inScope[id] {
+		return gorm.ErrRecordNotFound
+	}
+	harbor.ID = id
+	return nil
+}
+
+// portCallTest is a port call use case of a caller who sees one ship and two harbors
+type portCallTest struct {
+	shipID    string
+	harborIDs [2]string
+	portCalls *fakePortCallRepository
+	useCase   *PortCallUseCaseImpl
+}
+
+func newPortCallTest(t *testing.T) *portCallTest {
+	t.Helper()
+
+	db, _, err := fakedb.Open()
+	if err != nil {
+		t.Fatalf("open fake database: %v", err)
+	}
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	p := &portCallTest{
+		shipID:    uuid.NewString(),
+		harborIDs: [2]string{uuid.NewString(), uuid.NewString()},
+		portCalls: &fakePortCallRepository{portCalls: make(map[string]*entity.PortCall)},
+	}
+	p.useCase = &PortCallUseCaseImpl{
+		DB:                 db,
+		Log:                log,
+		Validate:           validator.New(),
+		PortCallRepository: p.portCalls,
+		ShipRepository:     &fakeShipRepository{inScope: map[string]bool{p.shipID: true}},
+		HarborRepository:   &fakeHarborRepository{inScope: map[string]bool{p.harborIDs[0]: true, p.harborIDs[1]: true}},
+	}
+	return p
+}
+
+// addPortCall stores a call of the ship at one of the harbors
+func (p *portCallTest) addPortCall(harbor int, status string, ata *int64) *entity.PortCall {
+	portCall := &entity.PortCall{
+		ID:       uuid.NewString(),
+		ShipID:   p.shipID,
+		HarborID: p.harborIDs[harbor],
+		Status:   status,
+		ETA:      time.Now().UnixMilli(),
+		ATA:      ata,
+	}
+	p.portCalls.portCalls[portCall.ID] = portCall
+	return portCall
+}
+
+func assertError(t *testing.T, err error, status int, message string) {
+	t.Helper()
+
+	fiberErr, ok := err.(*fiber.Error)
+	if !ok || fiberErr.Code != status || (message != "" && fiberErr.Message != message) {
+		t.Fatalf("expected %d %q, got %v", status, message, err)
+	}
+}
+
+func millis(value int64) *int64 {
+	return &value
+}
+
+// TestArrive checks a ship is in port at one harbor at a time
+func TestArrive(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		others  []string
+		ata     *int64
+		error   int
+		message string
+	}{
+		{name: "expected call", status: entity.PortCallStatusExpected},
+		{name: "expected call with the actual time of arrival", status: entity.PortCallStatusExpected, ata: millis(1_700_000_000_000)},
+		{name: "ship that left another harbor", status: entity.PortCallStatusExpected, others: []string{entity.PortCallStatusDeparted, entity.PortCallStatusCancelled}},
+		{name: "ship also expected at another harbor", status: entity.PortCallStatusExpected, others: []string{entity.PortCallStatusExpected}},
+		{name: "ship still in port at another harbor", status: entity.PortCallStatusExpected, others: []string{entity.PortCallStatusInPort}, error: fiber.StatusConflict, message: "the ship is still in port at another harbor"},
+		{name: "ship already in port", status: entity.PortCallStatusInPort, error: fiber.StatusConflict, message: "a in_port port call can not become in_port"},
+		{name: "departed call", status: entity.PortCallStatusDeparted, error: fiber.StatusConflict, message: "the port call is already departed"},
+		{name: "cancelled call", status: entity.PortCallStatusCancelled, error: fiber.StatusConflict, message: "the port call is already cancelled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPortCallTest(t)
+			portCall := p.addPortCall(0, tt.status, nil)
+			for _, status := range tt.others {
+				p.addPortCall(1, status, nil)
+			}
+
+			before := time.Now().UnixMilli()
+			res, err := p.useCase.Arrive(context.Background(), &model.ArrivePortCallRequest{ID: portCall.ID, ATA: tt.ata}, uuid.NewString())
+			if tt.error != 0 {
+				assertError(t, err, tt.error, tt.message)
+				if p.portCalls.portCalls[portCall.ID].Status != tt.status {
+					t.Fatalf("a refused arrival changed the port call")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("arrive: %v", err)
+			}
+			if res.Status != entity.PortCallStatusInPort || res.ATA == nil {
+				t.Fatalf("expected a call in port, got %+v", res)
+			}
+			if tt.ata != nil && *res.ATA != *tt.ata || tt.ata == nil && *res.ATA < before {
+				t.Fatalf("expected the ata %v, got %d", tt.ata, *res.ATA)
+			}
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		ata        *int64
+		atd        *int64
+		wantStatus string
+		message    string
+	}{
+		{name: "expected call is cancelled", status: entity.PortCallStatusExpected, wantStatus: entity.PortCallStatusCancelled},
+		{name: "ship in port departs", status: entity.PortCallStatusInPort, ata: millis(1_000), atd: millis(2_000), wantStatus: entity.PortCallStatusDeparted},
+		{name: "departure before the arrival", status: entity.PortCallStatusInPort, ata: millis(2_000), atd: millis(1_000), message: "atd can not be before ata"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPortCallTest(t)
+			portCall := p.addPortCall(0, tt.status, tt.ata)
+
+			res, err := p.useCase.Close(context.Background(), &model.ClosePortCallRequest{ID: portCall.ID, ATD: tt.atd}, uuid.NewString())
+			if tt.message != "" {
+				assertError(t, err, fiber.StatusBadRequest, tt.message)
+				return
+			}
+			if err != nil {
+				t.Fatalf("close: %v", err)
+			}
+			if res.Status != tt.wantStatus {
+				t.Fatalf("expected status %s, got %s", tt.wantStatus, res.Status)
+			}
+			if tt.wantStatus == entity.PortCallStatusDeparted && (res.ATD == nil || *res.ATD != *tt.atd) {
+				t.Fatalf("expected the atd %d, got %v", *tt.atd, res.ATD)
+			}
+		})
+	}
+}
+
+func TestGetPortCallInScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		adjust func(portCall *entity.PortCall)
+		found  bool
+	}{
+		{name: "call of the caller's ship at a harbor of the caller", adjust: func(*entity.PortCall) {}, found: true},
+		{name: "call at a harbor outside the scope", adjust: func(portCall *entity.PortCall) { portCall.HarborID = uuid.NewString() }},
+		{name: "call of a ship outside the scope", adjust: func(portCall *entity.PortCall) { portCall.ShipID = uuid.NewString() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPortCallTest(t)
+			portCall := p.addPortCall(0, entity.PortCallStatusExpected, nil)
+			tt.adjust(portCall)
+
+			_, err := p.useCase.Get(context.Background(), &model.GetPortCallRequest{ID: portCall.ID}, uuid.NewString())
+			if !tt.found {
+				assertError(t, err, fiber.StatusNotFound, "port call not found")
+				return
+			}
+			if err != nil {
+				t.Fatalf("get: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/pkg/service"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// GetEffectivePermissions resolves what holders of the role may do, through the same inheritance and wildcard rules the
+// permission middleware applies
+func (c *RoleUseCaseImpl) GetEffectivePermissions(ctx context.Context, request *model.GetRoleEffectivePermissionsRequest) (*model.RoleEffectivePermissionsResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	role := new(entity.Role)
+	if err := c.RoleRepository.FindById(tx, role, request.ID); err != nil || role.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find role")
+		return nil, fiber.ErrNotFound
+	}
+
+	ancestors, err := c.ancestors(tx, role)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find role ancestors")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Inheritance stops at an inactive role, like it does when permissions are resolved
+	inheritedRoles := make([]string, 0, len(ancestors))
+	if role.IsActive {
+		for _, ancestor := range ancestors {
+			if !ancestor.IsActive {
+				break
+			}
+			inheritedRoles = append(inheritedRoles, ancestor.Name)
+		}
+	}
+
+	granted, err := c.PermissionRepository.FindAllByRoleID(tx, role.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find role permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	catalog, err := c.PermissionRepository.FindAllActive(tx)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	grants := make([]string, len(granted))
+	for i, permission := range granted {
+		grants[i] = permission.Name
+	}
+	names := make([]string, len(catalog))
+	for i, permission := range catalog {
+		names[i] = permission.Name
+	}
+
+	permissions := service.NewPermissionSet(grants).Expand(names)
+	sort.Strings(grants)
+	sort.Strings(permissions)
+
+	return &model.RoleEffectivePermissionsResponse{
+		RoleID:         role.ID,
+		InheritedRoles: inheritedRoles,
+		Grants:         grants,
+		Permissions:    permissions,
+	}, nil
+}
+
+// checkParent makes sure the parent role exists and is not the role itself or one of its descendants
+func (c *RoleUseCaseImpl) checkParent(tx *gorm.DB, roleID string, parentID string) error {
+	parent := new(entity.Role)
+	if err := c.RoleRepository.FindById(tx, parent, parentID); err != nil || parent.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find parent role")
+		return fiber.NewError(fiber.StatusNotFound, "parent role not found")
+	}
+
+	ancestors, err := c.ancestors(tx, parent)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find role ancestors")
+		return fiber.ErrInternalServerError
+	}
+
+	if parent.ID == roleID {
+		return fiber.NewError(fiber.StatusBadRequest, "role can not inherit from itself")
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.ID == roleID {
+			c.Log.Warnf("Role %s can not inherit from its descendant %s", roleID, parent.ID)
+			return fiber.NewError(fiber.StatusBadRequest, "role hierarchy must not contain cycles")
+		}
+	}
+
+	return nil
+}
+
+// ancestors returns the parent chain of the role, nearest parent first
+func (c *RoleUseCaseImpl) ancestors(tx *gorm.DB, role *entity.Role) ([]entity.Role, error) {
+	var ancestors []entity.Role
+	visited := map[string]bool{role.ID: true}
+
+	for parentID := role.ParentID; parentID != nil; {
+		// A cycle can only come from data written around the API, the walk ends where it closes
+		if visited[*parentID] {
+			break
+		}
+		visited[*parentID] = true
+
+		parent := entity.Role{}
+		if err := c.RoleRepository.FindById(tx, &parent, *parentID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				break
+			}
+			return nil, err
+		}
+		if parent.DeletedAt != nil {
+			break
+		}
+
+		ancestors = append(ancestors, parent)
+		parentID = parent.ParentID
+	}
+
+	return ancestors, nil
+}
@@ -2,6 +2,7 @@ package role
 
 import (
 	"context"
+	"fmt"
 	"mkp-boarding-test/internal/domain/entity"
 	"mkp-boarding-test/internal/domain/repository"
 	"mkp-boarding-test/internal/domain/usecase"
@@ -16,21 +17,29 @@ import (
 )
 
 type RoleUseCaseImpl struct {
-	DB                   *gorm.DB
-	Log                  *logrus.Logger
-	Validate             *validator.Validate
-	RoleRepository       repository.RoleRepository
-	PermissionRepository repository.PermissionRepository
+	DB                       *gorm.DB
+	Log                      *logrus.Logger
+	Validate                 *validator.Validate
+	RoleRepository           repository.RoleRepository
+	PermissionRepository     repository.PermissionRepository
+	HarborRepository         repository.HarborRepository
+	RoleHarborRepository     repository.RoleHarborRepository
+	RolePermissionRepository repository.RolePermissionRepository
 }
 
 func NewRoleUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
-	roleRepository repository.RoleRepository, permissionRepository repository.PermissionRepository) usecase.RoleUseCase {
+	roleRepository repository.RoleRepository, permissionRepository repository.PermissionRepository,
+	harborRepository repository.HarborRepository, roleHarborRepository repository.RoleHarborRepository,
+	rolePermissionRepository repository.RolePermissionRepository) usecase.RoleUseCase {
 	return &RoleUseCaseImpl{
-		DB:                   db,
-		Log:                  logger,
-		Validate:             validate,
-		RoleRepository:       roleRepository,
-		PermissionRepository: permissionRepository,
+		DB:                       db,
+		Log:                      logger,
+		Validate:                 validate,
+		RoleRepository:           roleRepository,
+		PermissionRepository:     permissionRepository,
+		HarborRepository:         harborRepository,
+		RoleHarborRepository:     roleHarborRepository,
+		RolePermissionRepository: rolePermissionRepository,
 	}
 }
 
@@ -66,6 +75,13 @@ func (c *RoleUseCaseImpl) Create(ctx context.Context, request *model.CreateRoleR
 		IsSystem:    false,
 	}
 
+	if request.ParentID != nil && *request.ParentID != "" {
+		if err := c.checkParent(tx, role.ID, *request.ParentID); err != nil {
+			return nil, err
+		}
+		role.ParentID = request.ParentID
+	}
+
 	if err := c.RoleRepository.Create(tx, role); err != nil {
 		c.Log.WithError(err).Error("failed to create role")
 		return nil, fiber.ErrInternalServerError
@@ -122,11 +138,27 @@ func (c *RoleUseCaseImpl) Update(ctx context.Context, request *model.UpdateRoleR
 		role.IsActive = *request.IsActive
 	}
 
+	// An empty parent id detaches the role from its parent
+	if request.ParentID != nil {
+		if *request.ParentID == "" {
+			role.ParentID = nil
+		} else {
+			if err := c.checkParent(tx, role.ID, *request.ParentID); err != nil {
+				return nil, err
+			}
+			role.ParentID = request.ParentID
+		}
+	}
+
 	if err := c.RoleRepository.Update(tx, role); err != nil {
 		c.Log.WithError(err).Error("failed to update role")
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if err := c.loadPermissions(tx, role); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.WithError(err).Error("failed to commit transaction")
 		return nil, fiber.ErrInternalServerError
@@ -150,6 +182,10 @@ func (c *RoleUseCaseImpl) Get(ctx context.Context, request *model.GetRoleRequest
 		return nil, fiber.ErrNotFound
 	}
 
+	if err := c.loadPermissions(tx, role); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.WithError(err).Error("failed to commit transaction")
 		return nil, fiber.ErrInternalServerError
@@ -180,11 +216,10 @@ func (c *RoleUseCaseImpl) Delete(ctx context.Context, request *model.DeleteRoleR
 	}
 
 	// Delete all role permissions first
-	// TODO: Implement role permission deletion when RolePermissionRepository is available
-	// if err := c.RolePermissionRepository.DeleteByRoleID(tx, role.ID); err != nil {
-	//	c.Log.WithError(err).Error("failed to delete role permissions")
-	//	return fiber.ErrInternalServerError
-	// }
+	if err := c.RolePermissionRepository.DeleteAllByRoleID(tx, role.ID); err != nil {
+		c.Log.WithError(err).Error("failed to delete role permissions")
+		return fiber.ErrInternalServerError
+	}
 
 	if err := c.RoleRepository.Delete(tx, role); err != nil {
 		c.Log.WithError(err).Error("failed to delete role")
@@ -228,7 +263,7 @@ func (c *RoleUseCaseImpl) List(ctx context.Context, request *model.ListRoleReque
 	query = query.Offset(offset).Limit(request.Size)
 
 	var roles []entity.Role
-	if err := query.Find(&roles).Error; err != nil {
+	if err := query.Preload("RolePermissions.Permission").Find(&roles).Error; err != nil {
 		c.Log.WithError(err).Error("failed to find roles")
 		return nil, fiber.ErrInternalServerError
 	}
@@ -238,35 +273,45 @@ func (c *RoleUseCaseImpl) List(ctx context.Context, request *model.ListRoleReque
 		responses[i] = *converter.RoleToResponse(&role)
 	}
 
-	lastPage := (total + int64(request.Size) - 1) / int64(request.Size)
-	if lastPage == 0 {
-		lastPage = 1
+	return &model.WebResponse[[]model.RoleResponse]{
+		Data: responses,
+		Meta: model.NewPageMetadata(request.Page, request.Size, total),
+	}, nil
+}
+
+func (c *RoleUseCaseImpl) AssignPermissions(ctx context.Context, request *model.AssignPermissionsRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	role, err := c.findModifiableRole(tx, request.RoleID)
+	if err != nil {
+		return err
 	}
 
-	from := (request.Page-1)*request.Size + 1
-	to := request.Page * request.Size
-	if int64(to) > total {
-		to = int(total)
+	permissionIDs, err := c.findPermissionIDs(tx, request.PermissionIDs)
+	if err != nil {
+		return err
 	}
-	if total == 0 {
-		from = 0
-		to = 0
+
+	if err := c.RolePermissionRepository.CreateAllByRoleID(tx, role.ID, permissionIDs); err != nil {
+		c.Log.WithError(err).Error("failed to create role permissions")
+		return fiber.ErrInternalServerError
 	}
 
-	return &model.WebResponse[[]model.RoleResponse]{
-		Data: responses,
-		Meta: &model.PageMetadata{
-			CurrentPage: request.Page,
-			PerPage:     request.Size,
-			Total:       total,
-			LastPage:    lastPage,
-			From:        from,
-			To:          to,
-		},
-	}, nil
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
 }
 
-func (c *RoleUseCaseImpl) AssignPermissions(ctx context.Context, request *model.AssignPermissionsRequest) error {
+func (c *RoleUseCaseImpl) RemovePermissions(ctx context.Context, request *model.RemovePermissionsRequest) error {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
@@ -275,32 +320,151 @@ func (c *RoleUseCaseImpl) AssignPermissions(ctx context.Context, request *model.
 		return fiber.ErrBadRequest
 	}
 
-	// Check if role exists
+	role, err := c.findModifiableRole(tx, request.RoleID)
+	if err != nil {
+		return err
+	}
+
+	if err := c.RolePermissionRepository.DeleteAllByRoleIDAndPermissionIDs(tx, role.ID, request.PermissionIDs); err != nil {
+		c.Log.WithError(err).Error("failed to delete role permissions")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *RoleUseCaseImpl) SetPermissions(ctx context.Context, request *model.SetPermissionsRequest) (*model.RoleResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	role, err := c.findModifiableRole(tx, request.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissionIDs, err := c.findPermissionIDs(tx, request.PermissionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// The set is replaced in place, permissions the role keeps are not touched
+	if err := c.RolePermissionRepository.DeleteAllByRoleIDExcept(tx, role.ID, permissionIDs); err != nil {
+		c.Log.WithError(err).Error("failed to delete role permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.RolePermissionRepository.CreateAllByRoleID(tx, role.ID, permissionIDs); err != nil {
+		c.Log.WithError(err).Error("failed to create role permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.loadPermissions(tx, role); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.RoleToResponse(role), nil
+}
+
+// findModifiableRole loads a role whose permissions may be changed, the permissions of system roles are fixed
+func (c *RoleUseCaseImpl) findModifiableRole(tx *gorm.DB, roleID string) (*entity.Role, error) {
 	role := new(entity.Role)
-	if err := c.RoleRepository.FindById(tx, role, request.RoleID); err != nil {
+	if err := c.RoleRepository.FindById(tx, role, roleID); err != nil || role.DeletedAt != nil {
 		c.Log.WithError(err).Error("failed to find role")
-		return fiber.ErrNotFound
+		return nil, fiber.ErrNotFound
 	}
 
-	// Check if role is system role
 	if role.IsSystem {
 		c.Log.Error("cannot modify permissions for system role")
-		return fiber.ErrForbidden
+		return nil, fiber.ErrForbidden
 	}
 
-	// Validate all permissions exist
-	for _, permissionID := range request.PermissionIDs {
+	return role, nil
+}
+
+// findPermissionIDs checks that every permission exists and returns the ids without duplicates
+func (c *RoleUseCaseImpl) findPermissionIDs(tx *gorm.DB, permissionIDs []string) ([]string, error) {
+	unique := make([]string, 0, len(permissionIDs))
+	seen := make(map[string]bool, len(permissionIDs))
+	for _, permissionID := range permissionIDs {
+		if seen[permissionID] {
+			continue
+		}
+		seen[permissionID] = true
+
 		permission := new(entity.Permission)
-		if err := c.PermissionRepository.FindById(tx, permission, permissionID); err != nil {
-			c.Log.WithError(err).Error("failed to find permission")
-			return fiber.ErrNotFound
+		if err := c.PermissionRepository.FindById(tx, permission, permissionID); err != nil || permission.DeletedAt != nil {
+			c.Log.WithError(err).Errorf("failed to find permission %s", permissionID)
+			return nil, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("permission %s not found", permissionID))
 		}
+		unique = append(unique, permission.ID)
+	}
+	return unique, nil
+}
+
+// loadPermissions fills the permissions of the role for the response
+func (c *RoleUseCaseImpl) loadPermissions(tx *gorm.DB, role *entity.Role) error {
+	rolePermissions, err := c.RolePermissionRepository.FindAllByRoleID(tx, role.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find role permissions")
+		return fiber.ErrInternalServerError
+	}
+	role.RolePermissions = rolePermissions
+	return nil
+}
+
+func (c *RoleUseCaseImpl) AssignHarbors(ctx context.Context, request *model.AssignHarborsRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
 	}
 
-	// TODO: Create role permissions when RolePermissionRepository is available
-	// for _, permissionID := range request.PermissionIDs {
-	//	// Check if already exists and create role permission
-	// }
+	role := new(entity.Role)
+	if err := c.RoleRepository.FindById(tx, role, request.RoleID); err != nil || role.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find role")
+		return fiber.ErrNotFound
+	}
+
+	for _, harborID := range request.HarborIDs {
+		// Only harbors in the caller's own scope can be handed on
+		harbor := new(entity.Harbor)
+		if err := c.HarborRepository.FindByIdInScope(tx, harbor, harborID, request.UserID); err != nil {
+			c.Log.WithError(err).Errorf("failed to find harbor %s", harborID)
+			return fiber.ErrNotFound
+		}
+
+		// Assigning a harbor the role already has is a no-op
+		if err := c.RoleHarborRepository.FindByRoleIDAndHarborID(tx, new(entity.RoleHarbor), role.ID, harbor.ID); err == nil {
+			continue
+		}
+
+		roleHarbor := &entity.RoleHarbor{
+			RoleID:   role.ID,
+			HarborID: harbor.ID,
+		}
+
+		if err := c.RoleHarborRepository.Create(tx, roleHarbor); err != nil {
+			c.Log.WithError(err).Error("failed to create role harbor")
+			return fiber.ErrInternalServerError
+		}
+	}
 
 	if err := tx.Commit().Error; err != nil {
 		c.Log.WithError(err).Error("failed to commit transaction")
@@ -310,7 +474,7 @@ func (c *RoleUseCaseImpl) AssignPermissions(ctx context.Context, request *model.
 	return nil
 }
 
-func (c *RoleUseCaseImpl) RemovePermissions(ctx context.Context, request *model.RemovePermissionsRequest) error {
+func (c *RoleUseCaseImpl) RemoveHarbors(ctx context.Context, request *model.RemoveHarborsRequest) error {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
@@ -319,23 +483,24 @@ func (c *RoleUseCaseImpl) RemovePermissions(ctx context.Context, request *model.
 		return fiber.ErrBadRequest
 	}
 
-	// Check if role exists
 	role := new(entity.Role)
-	if err := c.RoleRepository.FindById(tx, role, request.RoleID); err != nil {
+	if err := c.RoleRepository.FindById(tx, role, request.RoleID); err != nil || role.DeletedAt != nil {
 		c.Log.WithError(err).Error("failed to find role")
 		return fiber.ErrNotFound
 	}
 
-	// Check if role is system role
-	if role.IsSystem {
-		c.Log.Error("cannot modify permissions for system role")
-		return fiber.ErrForbidden
-	}
+	for _, harborID := range request.HarborIDs {
+		harbor := new(entity.Harbor)
+		if err := c.HarborRepository.FindByIdInScope(tx, harbor, harborID, request.UserID); err != nil {
+			c.Log.WithError(err).Errorf("failed to find harbor %s", harborID)
+			return fiber.ErrNotFound
+		}
 
-	// TODO: Remove role permissions when RolePermissionRepository is available
-	// for _, permissionID := range request.PermissionIDs {
-	//	// Delete role permission by role ID and permission ID
-	// }
+		if err := c.RoleHarborRepository.DeleteByRoleIDAndHarborID(tx, role.ID, harbor.ID); err != nil {
+			c.Log.WithError(err).Error("failed to delete role harbor")
+			return fiber.ErrInternalServerError
+		}
+	}
 
 	if err := tx.Commit().Error; err != nil {
 		c.Log.WithError(err).Error("failed to commit transaction")
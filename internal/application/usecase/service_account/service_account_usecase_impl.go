@@ -0,0 +1,442 @@
+package service_account
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/domain/repository"
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/internal/model/converter"
+	"mkp-boarding-test/pkg/service"
+	"mkp-boarding-test/pkg/utils"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// apiKeyScheme starts every API key so leaked keys are easy to recognise, the full format is mkp_<prefix>_<secret>
+const apiKeyScheme = "mkp"
+
+// apiKeyLastUsedInterval limits how often last_used_at is written for a busy key
+const apiKeyLastUsedInterval = time.Minute
+
+type ServiceAccountUseCaseImpl struct {
+	DB                       *gorm.DB
+	Log                      *logrus.Logger
+	Validate                 *validator.Validate
+	ServiceAccountRepository repository.ServiceAccountRepository
+	APIKeyRepository         repository.APIKeyRepository
+	PermissionRepository     repository.PermissionRepository
+	OperatorRepository       repository.OperatorRepository
+}
+
+func NewServiceAccountUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
+	serviceAccountRepository repository.ServiceAccountRepository, apiKeyRepository repository.APIKeyRepository,
+	permissionRepository repository.PermissionRepository, operatorRepository repository.OperatorRepository) usecase.ServiceAccountUseCase {
+	return &ServiceAccountUseCaseImpl{
+		DB:                       db,
+		Log:                      logger,
+		Validate:                 validate,
+		ServiceAccountRepository: serviceAccountRepository,
+		APIKeyRepository:         apiKeyRepository,
+		PermissionRepository:     permissionRepository,
+		OperatorRepository:       operatorRepository,
+	}
+}
+
+func (c *ServiceAccountUseCaseImpl) Create(ctx context.Context, request *model.CreateServiceAccountRequest) (*model.ServiceAccountResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if count, err := c.ServiceAccountRepository.CountByName(tx, request.Name, ""); err != nil {
+		c.Log.WithError(err).Error("failed to count service account by name")
+		return nil, fiber.ErrInternalServerError
+	} else if count > 0 {
+		c.Log.Error("service account name already exists")
+		return nil, fiber.ErrConflict
+	}
+
+	serviceAccount := &entity.ServiceAccount{
+		ID:          uuid.NewString(),
+		Name:        request.Name,
+		Description: request.Description,
+		IsActive:    true,
+		CreatedBy:   &request.CreatedBy,
+	}
+
+	// The account is scoped to the operator of its creator, an account created by staff is not linked to an operator
+	// and sees no ships instead of inheriting the staff scope
+	operator := new(entity.Operator)
+	if err := c.OperatorRepository.FindByUserID(tx, operator, request.CreatedBy); err == nil {
+		serviceAccount.OperatorID = &operator.ID
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.Log.WithError(err).Error("failed to find operator of user")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.ServiceAccountRepository.Create(tx, serviceAccount); err != nil {
+		c.Log.WithError(err).Error("failed to create service account")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ServiceAccountToResponse(serviceAccount), nil
+}
+
+func (c *ServiceAccountUseCaseImpl) Get(ctx context.Context, request *model.GetServiceAccountRequest) (*model.ServiceAccountResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	serviceAccount := new(entity.ServiceAccount)
+	if err := c.ServiceAccountRepository.FindActiveByID(tx, serviceAccount, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find service account")
+		return nil, fiber.ErrNotFound
+	}
+
+	return converter.ServiceAccountToResponse(serviceAccount), nil
+}
+
+func (c *ServiceAccountUseCaseImpl) Delete(ctx context.Context, request *model.DeleteServiceAccountRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	serviceAccount := new(entity.ServiceAccount)
+	if err := c.ServiceAccountRepository.FindActiveByID(tx, serviceAccount, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find service account")
+		return fiber.ErrNotFound
+	}
+
+	// Service accounts are soft deleted so the keys they used stay traceable, every key stops working immediately
+	now := time.Now().UnixMilli()
+	serviceAccount.IsActive = false
+	serviceAccount.DeletedAt = &now
+
+	if err := c.ServiceAccountRepository.Update(tx, serviceAccount); err != nil {
+		c.Log.WithError(err).Error("failed to delete service account")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.APIKeyRepository.RevokeByServiceAccountID(tx, serviceAccount.ID, now); err != nil {
+		c.Log.WithError(err).Error("failed to revoke service account api keys")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *ServiceAccountUseCaseImpl) List(ctx context.Context, request *model.ListServiceAccountRequest) (*model.WebResponse[[]model.ServiceAccountResponse], error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	query := tx.Model(&entity.ServiceAccount{}).Where("deleted_at IS NULL")
+
+	if request.IsActive != nil {
+		query = query.Where("is_active = ?", *request.IsActive)
+	}
+	if request.Name != nil && *request.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+*request.Name+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.Log.WithError(err).Error("failed to count service accounts")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	offset := (request.Page - 1) * request.Size
+	var serviceAccounts []entity.ServiceAccount
+	if err := query.Order("created_at DESC").Offset(offset).Limit(request.Size).Find(&serviceAccounts).Error; err != nil {
+		c.Log.WithError(err).Error("failed to find service accounts")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.ServiceAccountResponse, len(serviceAccounts))
+	for i, serviceAccount := range serviceAccounts {
+		responses[i] = *converter.ServiceAccountToResponse(&serviceAccount)
+	}
+
+	return &model.WebResponse[[]model.ServiceAccountResponse]{
+		Data: responses,
+		Meta: utils.CreatePaginationMeta(request.Page, request.Size, total),
+	}, nil
+}
+
+func (c *ServiceAccountUseCaseImpl) CreateAPIKey(ctx context.Context, request *model.CreateAPIKeyRequest) (*model.APIKeyResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if request.ExpiresAt != nil && *request.ExpiresAt <= time.Now().Unix() {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "expires_at must be in the future")
+	}
+
+	serviceAccount := new(entity.ServiceAccount)
+	if err := c.ServiceAccountRepository.FindActiveByID(tx, serviceAccount, request.ServiceAccountID); err != nil {
+		c.Log.WithError(err).Error("failed to find service account")
+		return nil, fiber.ErrNotFound
+	}
+
+	permissions, err := c.findScopePermissions(tx, request.Permissions, request.GrantorPermissions)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := utils.GenerateRandomToken(6)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate api key prefix")
+		return nil, fiber.ErrInternalServerError
+	}
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate api key secret")
+		return nil, fiber.ErrInternalServerError
+	}
+	key := fmt.Sprintf("%s_%s_%s", apiKeyScheme, prefix, secret)
+
+	apiKey := &entity.APIKey{
+		ID:               uuid.NewString(),
+		ServiceAccountID: serviceAccount.ID,
+		Name:             request.Name,
+		Prefix:           prefix,
+		KeyHash:          utils.HashToken(key),
+		ExpiresAt:        request.ExpiresAt,
+		CreatedBy:        &request.CreatedBy,
+	}
+
+	if err := c.APIKeyRepository.Create(tx, apiKey); err != nil {
+		c.Log.WithError(err).Error("failed to create api key")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	permissionIDs := make([]string, len(permissions))
+	for i, permission := range permissions {
+		permissionIDs[i] = permission.ID
+		apiKey.APIKeyPermissions = append(apiKey.APIKeyPermissions, entity.APIKeyPermission{
+			APIKeyID:     apiKey.ID,
+			PermissionID: permission.ID,
+			Permission:   permission,
+		})
+	}
+
+	if err := c.APIKeyRepository.AddPermissions(tx, apiKey.ID, permissionIDs); err != nil {
+		c.Log.WithError(err).Error("failed to add api key permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.APIKeyToResponse(apiKey)
+	response.Key = key
+	return response, nil
+}
+
+func (c *ServiceAccountUseCaseImpl) ListAPIKeys(ctx context.Context, request *model.ListAPIKeyRequest) ([]model.APIKeyResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	serviceAccount := new(entity.ServiceAccount)
+	if err := c.ServiceAccountRepository.FindActiveByID(tx, serviceAccount, request.ServiceAccountID); err != nil {
+		c.Log.WithError(err).Error("failed to find service account")
+		return nil, fiber.ErrNotFound
+	}
+
+	apiKeys, err := c.APIKeyRepository.FindAllByServiceAccountID(tx, serviceAccount.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find api keys")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.APIKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		responses[i] = *converter.APIKeyToResponse(&apiKey)
+	}
+
+	return responses, nil
+}
+
+func (c *ServiceAccountUseCaseImpl) RevokeAPIKey(ctx context.Context, request *model.RevokeAPIKeyRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	apiKey := new(entity.APIKey)
+	if err := c.APIKeyRepository.FindByServiceAccountIDAndID(tx, apiKey, request.ServiceAccountID, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find api key")
+		return fiber.ErrNotFound
+	}
+
+	// Revoking twice is a no-op, the original revocation time is kept
+	if apiKey.RevokedAt != nil {
+		return nil
+	}
+
+	now := time.Now().UnixMilli()
+	apiKey.RevokedAt = &now
+
+	if err := c.APIKeyRepository.Update(tx, apiKey); err != nil {
+		c.Log.WithError(err).Error("failed to revoke api key")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *ServiceAccountUseCaseImpl) AuthenticateAPIKey(ctx context.Context, request *model.AuthenticateAPIKeyRequest) (*model.APIKeyPrincipal, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Warn("invalid api key")
+		return nil, fiber.ErrUnauthorized
+	}
+
+	parts := strings.Split(request.Key, "_")
+	if len(parts) != 3 || parts[0] != apiKeyScheme || parts[1] == "" || parts[2] == "" {
+		c.Log.Warn("malformed api key")
+		return nil, fiber.ErrUnauthorized
+	}
+
+	apiKey := new(entity.APIKey)
+	if err := c.APIKeyRepository.FindByPrefix(tx, apiKey, parts[1]); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.Warnf("Unknown api key prefix %s", parts[1])
+			return nil, fiber.ErrUnauthorized
+		}
+		c.Log.WithError(err).Error("failed to find api key")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if subtle.ConstantTimeCompare([]byte(utils.HashToken(request.Key)), []byte(apiKey.KeyHash)) != 1 {
+		c.Log.Warnf("Invalid secret for api key %s", apiKey.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	now := time.Now()
+	if apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && *apiKey.ExpiresAt <= now.Unix()) {
+		c.Log.Warnf("Api key %s is revoked or expired", apiKey.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	serviceAccount := apiKey.ServiceAccount
+	if !serviceAccount.IsActive || serviceAccount.DeletedAt != nil {
+		c.Log.Warnf("Service account %s of api key %s is inactive", serviceAccount.ID, apiKey.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(time.UnixMilli(*apiKey.LastUsedAt)) >= apiKeyLastUsedInterval {
+		if err := c.APIKeyRepository.TouchLastUsed(tx, apiKey.ID, now.UnixMilli(), request.IPAddress); err != nil {
+			c.Log.WithError(err).Error("failed to update api key last used")
+			return nil, fiber.ErrInternalServerError
+		}
+		if err := tx.Commit().Error; err != nil {
+			c.Log.WithError(err).Error("failed to commit transaction")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	// Permissions that were deactivated after the key was issued no longer apply
+	permissions := make([]string, 0, len(apiKey.APIKeyPermissions))
+	for _, apiKeyPermission := range apiKey.APIKeyPermissions {
+		permission := apiKeyPermission.Permission
+		if permission.ID != "" && permission.IsActive && permission.DeletedAt == nil {
+			permissions = append(permissions, permission.Name)
+		}
+	}
+
+	return &model.APIKeyPrincipal{
+		ServiceAccountID:   serviceAccount.ID,
+		ServiceAccountName: serviceAccount.Name,
+		APIKeyID:           apiKey.ID,
+		Permissions:        permissions,
+	}, nil
+}
+
+// findScopePermissions resolves the requested scope, every permission must exist and be held by the grantor
+func (c *ServiceAccountUseCaseImpl) findScopePermissions(tx *gorm.DB, names []string, grantorPermissions service.PermissionSet) ([]entity.Permission, error) {
+	unique := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+
+	permissions, err := c.PermissionRepository.FindAllActiveByNames(tx, unique)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	found := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		found[permission.Name] = true
+	}
+
+	for _, name := range unique {
+		if !found[name] {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("unknown permission %s", name))
+		}
+		if !grantorPermissions.Allows(name) {
+			return nil, fiber.NewError(fiber.StatusForbidden, fmt.Sprintf("cannot grant permission %s that you do not hold", name))
+		}
+	}
+
+	return permissions, nil
+}
@@ -16,22 +16,55 @@ import (
 )
 
 type ShipUseCaseImpl struct {
-	DB             *gorm.DB
-	Log            *logrus.Logger
-	Validate       *validator.Validate
-	ShipRepository repository.ShipRepository
+	DB                   *gorm.DB
+	Log                  *logrus.Logger
+	Validate             *validator.Validate
+	ShipRepository       repository.ShipRepository
+	OperatorRepository   repository.OperatorRepository
+	DeficiencyRepository repository.DeficiencyRepository
+	BoardingRepository   repository.BoardingRepository
+	PortCallRepository   repository.PortCallRepository
 }
 
-func NewShipUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, shipRepository repository.ShipRepository) usecase.ShipUseCase {
+func NewShipUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, shipRepository repository.ShipRepository, operatorRepository repository.OperatorRepository, deficiencyRepository repository.DeficiencyRepository,
+	boardingRepository repository.BoardingRepository, portCallRepository repository.PortCallRepository) usecase.ShipUseCase {
 	return &ShipUseCaseImpl{
-		DB:             db,
-		Log:            log,
-		Validate:       validate,
-		ShipRepository: shipRepository,
+		DB:                   db,
+		Log:                  log,
+		Validate:             validate,
+		ShipRepository:       shipRepository,
+		OperatorRepository:   operatorRepository,
+		DeficiencyRepository: deficiencyRepository,
+		BoardingRepository:   boardingRepository,
+		PortCallRepository:   portCallRepository,
 	}
 }
 
-func (c *ShipUseCaseImpl) Create(ctx context.Context, request *model.CreateShipRequest) (*model.ShipResponse, error) {
+// flagDeficiencies marks the ships with a detainable deficiency that is not verified yet
+func (c *ShipUseCaseImpl) flagDeficiencies(tx *gorm.DB, responses ...*model.ShipResponse) error {
+	shipIDs := make([]string, len(responses))
+	for i, response := range responses {
+		shipIDs[i] = response.ID
+	}
+
+	flagged, err := c.DeficiencyRepository.FindShipIDsWithOpenDetainable(tx, shipIDs)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find ships with open detainable deficiencies")
+		return fiber.ErrInternalServerError
+	}
+
+	detainable := make(map[string]bool, len(flagged))
+	for _, id := range flagged {
+		detainable[id] = true
+	}
+	for _, response := range responses {
+		flag := detainable[response.ID]
+		response.HasOpenDetainableDeficiencies = &flag
+	}
+	return nil
+}
+
+func (c *ShipUseCaseImpl) Create(ctx context.Context, request *model.CreateShipRequest, userId string) (*model.ShipResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
@@ -40,6 +73,12 @@ func (c *ShipUseCaseImpl) Create(ctx context.Context, request *model.CreateShipR
 		return nil, fiber.ErrBadRequest
 	}
 
+	// Operator users can only register ships of their own fleet, other operators are reported as missing
+	if err := c.OperatorRepository.FindByIdInScope(tx, new(entity.Operator), request.OperatorID, userId); err != nil {
+		c.Log.WithError(err).Warnf("User %s can not create ships for operator %s", userId, request.OperatorID)
+		return nil, fiber.NewError(fiber.StatusNotFound, "operator not found")
+	}
+
 	// Check if ship name already exists for the operator
 	if count, err := c.ShipRepository.CountByShipNameAndOperatorID(tx, request.ShipName, request.OperatorID, ""); err != nil {
 		c.Log.WithError(err).Error("failed to count ship by name and operator")
@@ -111,15 +150,20 @@ func (c *ShipUseCaseImpl) Create(ctx context.Context, request *model.CreateShipR
 		return nil, fiber.ErrInternalServerError
 	}
 
+	response := converter.ShipToResponse(ship)
+	if err := c.flagDeficiencies(tx, response); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.WithError(err).Error("failed to commit transaction")
 		return nil, fiber.ErrInternalServerError
 	}
 
-	return converter.ShipToResponse(ship), nil
+	return response, nil
 }
 
-func (c *ShipUseCaseImpl) Update(ctx context.Context, request *model.UpdateShipRequest) (*model.ShipResponse, error) {
+func (c *ShipUseCaseImpl) Update(ctx context.Context, request *model.UpdateShipRequest, userId string) (*model.ShipResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
@@ -129,7 +173,7 @@ func (c *ShipUseCaseImpl) Update(ctx context.Context, request *model.UpdateShipR
 	}
 
 	ship := &entity.Ship{}
-	if err := c.ShipRepository.FindById(tx, ship, request.ID); err != nil {
+	if err := c.ShipRepository.FindByIdInScope(tx, ship, request.ID, userId); err != nil {
 		c.Log.WithError(err).Error("failed to find ship")
 		return nil, fiber.ErrNotFound
 	}
@@ -242,15 +286,20 @@ func (c *ShipUseCaseImpl) Update(ctx context.Context, request *model.UpdateShipR
 		return nil, fiber.ErrInternalServerError
 	}
 
+	response := converter.ShipToResponse(ship)
+	if err := c.flagDeficiencies(tx, response); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.WithError(err).Error("failed to commit transaction")
 		return nil, fiber.ErrInternalServerError
 	}
 
-	return converter.ShipToResponse(ship), nil
+	return response, nil
 }
 
-func (c *ShipUseCaseImpl) Get(ctx context.Context, request *model.GetShipRequest) (*model.ShipResponse, error) {
+func (c *ShipUseCaseImpl) Get(ctx context.Context, request *model.GetShipRequest, userId string) (*model.ShipResponse, error) {
 	tx := c.DB.WithContext(ctx)
 
 	if err := c.Validate.Struct(request); err != nil {
@@ -259,15 +308,20 @@ func (c *ShipUseCaseImpl) Get(ctx context.Context, request *model.GetShipRequest
 	}
 
 	ship := &entity.Ship{}
-	if err := c.ShipRepository.FindById(tx, ship, request.ID); err != nil {
+	if err := c.ShipRepository.FindByIdInScope(tx, ship, request.ID, userId); err != nil {
 		c.Log.WithError(err).Error("failed to find ship")
 		return nil, fiber.ErrNotFound
 	}
 
-	return converter.ShipToResponse(ship), nil
+	response := converter.ShipToResponse(ship)
+	if err := c.flagDeficiencies(tx, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
 }
 
-func (c *ShipUseCaseImpl) Delete(ctx context.Context, request *model.DeleteShipRequest) error {
+func (c *ShipUseCaseImpl) Delete(ctx context.Context, request *model.DeleteShipRequest, userId string) error {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
@@ -277,11 +331,25 @@ func (c *ShipUseCaseImpl) Delete(ctx context.Context, request *model.DeleteShipR
 	}
 
 	ship := &entity.Ship{}
-	if err := c.ShipRepository.FindById(tx, ship, request.ID); err != nil {
+	if err := c.ShipRepository.FindByIdInScope(tx, ship, request.ID, userId); err != nil {
 		c.Log.WithError(err).Error("failed to find ship")
 		return fiber.ErrNotFound
 	}
 
+	// Boardings, their deficiencies and port calls are the inspection history of the ship, a ship that has any is kept
+	if count, err := c.BoardingRepository.CountByShipID(tx, ship.ID); err != nil {
+		c.Log.WithError(err).Error("failed to count boardings of ship")
+		return fiber.ErrInternalServerError
+	} else if count > 0 {
+		return fiber.NewError(fiber.StatusConflict, "ship has boardings, deactivate it instead")
+	}
+	if count, err := c.PortCallRepository.CountByShipID(tx, ship.ID); err != nil {
+		c.Log.WithError(err).Error("failed to count port calls of ship")
+		return fiber.ErrInternalServerError
+	} else if count > 0 {
+		return fiber.NewError(fiber.StatusConflict, "ship has port calls, deactivate it instead")
+	}
+
 	if err := c.ShipRepository.Delete(tx, ship); err != nil {
 		c.Log.WithError(err).Error("failed to delete ship")
 		return fiber.ErrInternalServerError
@@ -295,7 +363,7 @@ func (c *ShipUseCaseImpl) Delete(ctx context.Context, request *model.DeleteShipR
 	return nil
 }
 
-func (c *ShipUseCaseImpl) List(ctx context.Context, request *model.ListShipRequest) (*model.WebResponse[[]model.ShipResponse], error) {
+func (c *ShipUseCaseImpl) List(ctx context.Context, request *model.ListShipRequest, userId string) (*model.WebResponse[[]model.ShipResponse], error) {
 	tx := c.DB.WithContext(ctx)
 
 	if err := c.Validate.Struct(request); err != nil {
@@ -303,8 +371,8 @@ func (c *ShipUseCaseImpl) List(ctx context.Context, request *model.ListShipReque
 		return nil, fiber.ErrBadRequest
 	}
 
-	query := tx.Model(&entity.Ship{}).Where("deleted_at IS NULL")
-
+	// Operator users only see their own fleet, the operator filter can narrow that scope but never widen it
+	query := tx.Model(&entity.Ship{}).Where("deleted_at IS NULL AND id IN (?)", c.ShipRepository.SelectIDsInScope(tx, userId))
 	if request.OperatorID != nil && *request.OperatorID != "" {
 		query = query.Where("operator_id = ?", *request.OperatorID)
 	}
@@ -342,34 +410,17 @@ func (c *ShipUseCaseImpl) List(ctx context.Context, request *model.ListShipReque
 	}
 
 	responses := make([]model.ShipResponse, len(ships))
+	flagged := make([]*model.ShipResponse, len(ships))
 	for i, ship := range ships {
 		responses[i] = *converter.ShipToResponse(&ship)
+		flagged[i] = &responses[i]
 	}
-
-	lastPage := (total + int64(request.Size) - 1) / int64(request.Size)
-	if lastPage == 0 {
-		lastPage = 1
-	}
-
-	from := (request.Page-1)*request.Size + 1
-	to := request.Page * request.Size
-	if int64(to) > total {
-		to = int(total)
-	}
-	if total == 0 {
-		from = 0
-		to = 0
+	if err := c.flagDeficiencies(tx, flagged...); err != nil {
+		return nil, err
 	}
 
 	return &model.WebResponse[[]model.ShipResponse]{
 		Data: responses,
-		Meta: &model.PageMetadata{
-			CurrentPage: request.Page,
-			PerPage:     request.Size,
-			Total:       total,
-			LastPage:    lastPage,
-			From:        from,
-			To:          to,
-		},
+		Meta: model.NewPageMetadata(request.Page, request.Size, total),
 	}, nil
 }
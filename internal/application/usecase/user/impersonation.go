@@ -0,0 +1,167 @@
+package user
+
+import (
+	"context"
+
+	"mkp-boarding-test/internal/delivery/http/dto/request"
+	"mkp-boarding-test/internal/delivery/http/dto/response"
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model/converter"
+	"mkp-boarding-test/pkg/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Impersonate issues a short-lived token that lets the actor act as the user. The token carries the actor in the
+// act claim, belongs to no session and can not be refreshed, starting the impersonation is written to the audit log.
+func (c *UserUseCaseImpl) Impersonate(ctx context.Context, request *request.ImpersonateUserRequest) (*response.ImpersonationResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	if request.UserID == request.ActorID {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "you can not impersonate yourself")
+	}
+
+	actor := new(entity.User)
+	if err := c.UserRepository.FindById(tx, actor, request.ActorID); err != nil || actor.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find actor by id")
+		return nil, fiber.ErrUnauthorized
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserID); err != nil || user.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return nil, fiber.NewError(fiber.StatusNotFound, "user not found")
+	}
+
+	if !user.IsActive {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "user is not active")
+	}
+
+	// Admins can not act as each other, otherwise impersonation would hand out the permissions of another admin
+	permissions, err := c.PermissionRepository.FindAllByUserID(tx, user.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find user permissions")
+		return nil, fiber.ErrInternalServerError
+	}
+	names := make([]string, len(permissions))
+	for i, permission := range permissions {
+		names[i] = permission.Name
+	}
+	if service.NewPermissionSet(names).Allows(service.ImpersonatePermission) {
+		return nil, fiber.NewError(fiber.StatusForbidden, "users who may impersonate can not be impersonated")
+	}
+
+	token, err := c.JWTService.GenerateImpersonationToken(user, actor, c.ImpersonationPolicy.TokenTTL)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate impersonation token")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	claims, err := c.JWTService.ExtractClaimsFromToken(token)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to read impersonation token")
+		return nil, fiber.ErrInternalServerError
+	}
+	tokenID, _ := claims["token_id"].(string)
+
+	// Issuing the token is recorded as a completed request, the response can only fail from here on
+	statusCode := fiber.StatusOK
+	auditLog := &entity.ImpersonationAuditLog{
+		ID:         uuid.NewString(),
+		ActorID:    actor.ID,
+		UserID:     user.ID,
+		TokenID:    tokenID,
+		Method:     request.Method,
+		Path:       request.Path,
+		Reason:     &request.Reason,
+		IPAddress:  nullableString(request.IPAddress),
+		StatusCode: &statusCode,
+	}
+
+	if err := c.ImpersonationAuditLogRepository.Create(tx, auditLog); err != nil {
+		c.Log.WithError(err).Error("failed to create impersonation audit log")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.loadRoles(tx, user); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	c.Log.Infof("User %s impersonates user %s: %s", actor.ID, user.ID, request.Reason)
+
+	var expiresAt int64
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		expiresAt = exp.Unix()
+	}
+
+	return &response.ImpersonationResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		ActorID:   actor.ID,
+		User:      converter.UserToResponse(user),
+	}, nil
+}
+
+// StartImpersonatedWrite records a write request made with an impersonation token before it runs and returns the id
+// of the audit log, the request must not run when it could not be recorded
+func (c *UserUseCaseImpl) StartImpersonatedWrite(ctx context.Context, request *request.StartImpersonatedWriteRequest) (string, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return "", fiber.ErrBadRequest
+	}
+
+	auditLog := &entity.ImpersonationAuditLog{
+		ID:        uuid.NewString(),
+		ActorID:   request.ActorID,
+		UserID:    request.UserID,
+		TokenID:   request.TokenID,
+		Method:    request.Method,
+		Path:      request.Path,
+		IPAddress: nullableString(request.IPAddress),
+	}
+
+	if err := c.ImpersonationAuditLogRepository.Create(tx, auditLog); err != nil {
+		c.Log.WithError(err).Error("failed to create impersonation audit log")
+		return "", fiber.ErrInternalServerError
+	}
+
+	return auditLog.ID, nil
+}
+
+// FinishImpersonatedWrite completes the audit log of a write request with the response status
+func (c *UserUseCaseImpl) FinishImpersonatedWrite(ctx context.Context, request *request.FinishImpersonatedWriteRequest) error {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	auditLog := new(entity.ImpersonationAuditLog)
+	if err := c.ImpersonationAuditLogRepository.FindById(tx, auditLog, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find impersonation audit log")
+		return fiber.ErrNotFound
+	}
+
+	auditLog.StatusCode = &request.StatusCode
+	if err := c.ImpersonationAuditLogRepository.Update(tx, auditLog); err != nil {
+		c.Log.WithError(err).Error("failed to update impersonation audit log")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
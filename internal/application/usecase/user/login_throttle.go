@@ -0,0 +1,101 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"mkp-boarding-test/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// loginKey is a failed login counter together with the number of failures that locks it
+type loginKey struct {
+	key         string
+	maxAttempts int
+}
+
+// loginKeys returns the counters a login attempt is tracked under, the account is identified by the submitted
+// username so unknown usernames are throttled exactly like existing ones
+func (c *UserUseCaseImpl) loginKeys(username, ipAddress string) []loginKey {
+	keys := []loginKey{{key: accountLoginKey(username), maxAttempts: c.LockoutPolicy.MaxAttempts}}
+	if ipAddress != "" {
+		keys = append(keys, loginKey{key: "ip:" + ipAddress, maxAttempts: c.LockoutPolicy.IPMaxAttempts})
+	}
+	return keys
+}
+
+func accountLoginKey(username string) string {
+	return "username:" + username
+}
+
+// checkLoginThrottle rejects the attempt while any of the keys is still backing off or locked
+func (c *UserUseCaseImpl) checkLoginThrottle(ctx context.Context, keys []loginKey, now time.Time) error {
+	db := c.DB.WithContext(ctx)
+
+	for _, key := range keys {
+		attempt := new(entity.LoginAttempt)
+		if err := c.LoginAttemptRepository.FindByKey(db, attempt, key.key); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			c.Log.WithError(err).Error("failed to find login attempt")
+			return fiber.ErrInternalServerError
+		}
+
+		if wait := time.UnixMilli(attempt.LockedUntil).Sub(now); wait > 0 {
+			c.Log.Warnf("Login attempt for %s throttled for %s", key.key, wait)
+			seconds := int(math.Ceil(wait.Seconds()))
+			return fiber.NewError(fiber.StatusTooManyRequests, fmt.Sprintf("too many failed login attempts, try again in %d seconds", seconds))
+		}
+	}
+
+	return nil
+}
+
+// recordLoginFailure bumps the counters of the keys, it runs outside the login transaction so it survives the rollback
+func (c *UserUseCaseImpl) recordLoginFailure(ctx context.Context, keys []loginKey, now time.Time) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	for _, key := range keys {
+		attempt := new(entity.LoginAttempt)
+		err := c.LoginAttemptRepository.FindByKey(tx, attempt, key.key)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Log.WithError(err).Error("failed to find login attempt")
+			return
+		}
+
+		exists := err == nil
+		if !exists {
+			attempt.Key = key.key
+		}
+
+		// Counters start over once a key has been quiet for a full lockout period
+		if now.Sub(time.UnixMilli(attempt.LastFailedAt)) > c.LockoutPolicy.LockoutDuration {
+			attempt.FailedCount = 0
+		}
+
+		attempt.FailedCount++
+		attempt.LastFailedAt = now.UnixMilli()
+		attempt.LockedUntil = now.Add(c.LockoutPolicy.Delay(attempt.FailedCount, key.maxAttempts)).UnixMilli()
+
+		if exists {
+			err = c.LoginAttemptRepository.Update(tx, attempt)
+		} else {
+			err = c.LoginAttemptRepository.Create(tx, attempt)
+		}
+		if err != nil {
+			c.Log.WithError(err).Error("failed to save login attempt")
+			return
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+	}
+}
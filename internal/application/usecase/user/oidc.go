@@ -0,0 +1,324 @@
+package user
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"mkp-boarding-test/internal/delivery/http/dto/request"
+	"mkp-boarding-test/internal/delivery/http/dto/response"
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/pkg/service"
+	"mkp-boarding-test/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// provisionedUsernameAttempts is how many random suffixes are tried when the username of a new identity is taken
+const provisionedUsernameAttempts = 5
+
+var usernameDisallowedChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func (c *UserUseCaseImpl) OIDCAuthorize(ctx context.Context) (*response.OIDCAuthorizationResponse, error) {
+	if c.OIDCService == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "OIDC login is not enabled")
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	state, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate oidc state")
+		return nil, fiber.ErrInternalServerError
+	}
+	nonce, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate oidc nonce")
+		return nil, fiber.ErrInternalServerError
+	}
+	codeVerifier, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate pkce code verifier")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	now := time.Now()
+	if err := c.OIDCLoginStateRepository.DeleteExpired(tx, now.Unix()); err != nil {
+		c.Log.WithError(err).Error("failed to delete expired oidc login states")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	loginState := &entity.OIDCLoginState{
+		ID:           uuid.NewString(),
+		StateHash:    utils.HashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    now.Add(c.OIDCPolicy.StateTTL).Unix(),
+	}
+
+	if err := c.OIDCLoginStateRepository.Create(tx, loginState); err != nil {
+		c.Log.WithError(err).Error("failed to create oidc login state")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	authorizationURL, err := c.OIDCService.AuthCodeURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to build oidc authorization url")
+		return nil, fiber.NewError(fiber.StatusBadGateway, "identity provider is not available")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &response.OIDCAuthorizationResponse{AuthorizationURL: authorizationURL}, nil
+}
+
+func (c *UserUseCaseImpl) OIDCCallback(ctx context.Context, request *request.OIDCCallbackRequest) (*response.UserResponse, error) {
+	if c.OIDCService == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "OIDC login is not enabled")
+	}
+
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	// A state is good for a single callback, it is consumed outside the transaction so a callback that fails later
+	// does not roll the state back for another attempt
+	now := time.Now()
+	loginState := new(entity.OIDCLoginState)
+	if err := c.OIDCLoginStateRepository.ConsumeByStateHash(c.DB.WithContext(ctx), loginState, utils.HashToken(request.State)); err != nil {
+		c.Log.WithError(err).Warn("failed to find oidc login state")
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "invalid or expired login state")
+	}
+
+	if loginState.ExpiresAt < now.Unix() {
+		c.Log.Warnf("OIDC login state %s expired", loginState.ID)
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "invalid or expired login state")
+	}
+
+	identity, err := c.OIDCService.Exchange(ctx, request.Code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		c.Log.WithError(err).Warn("failed to exchange oidc authorization code")
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "identity provider login failed")
+	}
+
+	user, userIdentity, err := c.resolveOIDCUser(tx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DeletedAt != nil || !user.IsActive {
+		c.Log.Warnf("User %s is deleted or not active", user.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	lastLoginAt := now.UnixMilli()
+	userIdentity.Email = nullableString(identity.Email)
+	userIdentity.LastLoginAt = &lastLoginAt
+	if userIdentity.ID == "" {
+		userIdentity.ID = uuid.NewString()
+		err = c.UserIdentityRepository.Create(tx, userIdentity)
+	} else {
+		err = c.UserIdentityRepository.Update(tx, userIdentity)
+	}
+	if err != nil {
+		c.Log.WithError(err).Error("failed to save user identity")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := c.syncOIDCRoles(tx, user, identity.Groups); err != nil {
+		return nil, err
+	}
+
+	// The identity provider replaces the password, a second factor enabled here is still asked for
+	var response *response.UserResponse
+	if user.TwoFactorEnabled {
+		response, err = c.startTwoFactorLogin(tx, user, now)
+	} else {
+		response, err = c.completeLogin(tx, user, request.UserAgent, request.IPAddress, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return response, nil
+}
+
+// resolveOIDCUser finds the user of an external identity. Unknown identities are linked to the user with the same
+// verified email or, when auto provisioning is enabled, to a new user. The returned identity has no ID until it is saved.
+func (c *UserUseCaseImpl) resolveOIDCUser(tx *gorm.DB, identity *service.OIDCIdentity) (*entity.User, *entity.UserIdentity, error) {
+	userIdentity := new(entity.UserIdentity)
+	user := new(entity.User)
+
+	if err := c.UserIdentityRepository.FindByIssuerAndSubject(tx, userIdentity, identity.Issuer, identity.Subject); err == nil {
+		if err := c.UserRepository.FindById(tx, user, userIdentity.UserID); err != nil {
+			c.Log.WithError(err).Error("failed to find user of identity")
+			return nil, nil, fiber.ErrUnauthorized
+		}
+		return user, userIdentity, nil
+	}
+
+	// An unverified address could belong to anyone, so it is never used to pick or create an account
+	if identity.Email == "" || !identity.EmailVerified {
+		c.Log.Warnf("OIDC subject %s has no verified email", identity.Subject)
+		return nil, nil, fiber.NewError(fiber.StatusForbidden, "identity provider account has no verified email")
+	}
+
+	userIdentity = &entity.UserIdentity{
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+	}
+
+	if err := c.UserRepository.FindByEmail(tx, user, identity.Email); err == nil {
+		c.Log.Infof("Linking OIDC subject %s to user %s", identity.Subject, user.ID)
+		userIdentity.UserID = user.ID
+		return user, userIdentity, nil
+	}
+
+	if !c.OIDCPolicy.AutoProvision {
+		c.Log.Warnf("No user for OIDC subject %s and auto provisioning is disabled", identity.Subject)
+		return nil, nil, fiber.NewError(fiber.StatusForbidden, "no account is linked to this identity")
+	}
+
+	user, err := c.provisionOIDCUser(tx, identity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userIdentity.UserID = user.ID
+	return user, userIdentity, nil
+}
+
+// provisionOIDCUser creates a verified user for an identity, it gets a random password and signs in through the provider
+func (c *UserUseCaseImpl) provisionOIDCUser(tx *gorm.DB, identity *service.OIDCIdentity) (*entity.User, error) {
+	username, err := c.availableUsername(tx, provisionedUsername(identity))
+	if err != nil {
+		return nil, err
+	}
+
+	randomPassword, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate random password")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	password, err := bcrypt.GenerateFromPassword([]byte(randomPassword), bcrypt.DefaultCost)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate bcrypt hash")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	verifiedAt := time.Now().UnixMilli()
+	user := &entity.User{
+		ID:              uuid.NewString(),
+		Username:        username,
+		Email:           identity.Email,
+		Password:        string(password),
+		FirstName:       identity.GivenName,
+		LastName:        identity.FamilyName,
+		IsActive:        true,
+		IsVerified:      true,
+		EmailVerifiedAt: &verifiedAt,
+	}
+
+	if err := c.UserRepository.Create(tx, user); err != nil {
+		c.Log.WithError(err).Error("failed to create user")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	c.Log.Infof("Provisioned user %s for OIDC subject %s", user.ID, identity.Subject)
+	return user, nil
+}
+
+// availableUsername appends a random suffix to a username that is already taken
+func (c *UserUseCaseImpl) availableUsername(tx *gorm.DB, username string) (string, error) {
+	candidate := username
+	for attempt := 0; attempt < provisionedUsernameAttempts; attempt++ {
+		count, err := c.UserRepository.CountByUsername(tx, candidate, "")
+		if err != nil {
+			c.Log.WithError(err).Error("failed to count user by username")
+			return "", fiber.ErrInternalServerError
+		}
+		if count == 0 {
+			return candidate, nil
+		}
+
+		suffix, err := utils.GenerateRandomToken(3)
+		if err != nil {
+			c.Log.WithError(err).Error("failed to generate username suffix")
+			return "", fiber.ErrInternalServerError
+		}
+		candidate = username + "-" + suffix
+	}
+
+	c.Log.Errorf("No free username found for %s", username)
+	return "", fiber.NewError(fiber.StatusConflict, "could not choose a username for this identity")
+}
+
+// syncOIDCRoles grants the mapped roles of the user's groups and takes back mapped roles of groups the user left,
+// roles that are not part of the mapping are left alone
+func (c *UserUseCaseImpl) syncOIDCRoles(tx *gorm.DB, user *entity.User, groups []string) error {
+	granted := c.OIDCPolicy.RolesForGroups(groups)
+
+	for _, name := range c.OIDCPolicy.ManagedRoles() {
+		role := new(entity.Role)
+		if err := c.RoleRepository.FindByName(tx, role, name); err != nil || role.DeletedAt != nil {
+			c.Log.WithError(err).Warnf("role %s of the OIDC group mapping does not exist", name)
+			continue
+		}
+
+		assigned := c.UserRoleRepository.FindByUserIDAndRoleID(tx, new(entity.UserRole), user.ID, role.ID) == nil
+
+		if granted[name] && !assigned {
+			userRole := &entity.UserRole{
+				UserID: user.ID,
+				RoleID: role.ID,
+			}
+			if err := c.UserRoleRepository.Create(tx, userRole); err != nil {
+				c.Log.WithError(err).Error("failed to create user role")
+				return fiber.ErrInternalServerError
+			}
+		}
+
+		if !granted[name] && assigned {
+			if err := c.UserRoleRepository.DeleteByUserIDAndRoleID(tx, user.ID, role.ID); err != nil {
+				c.Log.WithError(err).Error("failed to delete user role")
+				return fiber.ErrInternalServerError
+			}
+		}
+	}
+
+	return nil
+}
+
+func provisionedUsername(identity *service.OIDCIdentity) string {
+	username := identity.PreferredUsername
+	if username == "" {
+		username, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	username = strings.Trim(usernameDisallowedChars.ReplaceAllString(username, "-"), "-")
+	if len(username) > 90 {
+		username = username[:90]
+	}
+	if username == "" {
+		username = "user"
+	}
+	return username
+}
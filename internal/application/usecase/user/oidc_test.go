@@ -0,0 +1,645 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"mkp-boarding-test/internal/delivery/http/dto/request"
+	"mkp-boarding-test/internal/delivery/http/dto/response"
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/domain/repository"
+	"mkp-boarding-test/pkg/service"
+	"mkp-boarding-test/pkg/utils"
+	"mkp-boarding-test/test/fakedb"
+	"mkp-boarding-test/test/mockidp"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	oidcClientID     = "mkp-boarding"
+	oidcClientSecret = "secret"
+	oidcRedirectURL  = "http://localhost:3000/api/users/_oidc/callback"
+)
+
+// memory holds the rows of the fake repositories
+type memory struct {
+	mu         sync.Mutex
+	users      map[string]entity.User
+	roles      map[string]entity.Role
+	userRoles  map[string]map[string]bool
+	identities map[string]entity.UserIdentity
+	states     map[string]entity.OIDCLoginState
+}
+
+func newMemory(roleNames ...string) *memory {
+	m := &memory{
+		users:      make(map[string]entity.User),
+		roles:      make(map[string]entity.Role),
+		userRoles:  make(map[string]map[string]bool),
+		identities: make(map[string]entity.UserIdentity),
+		states:     make(map[string]entity.OIDCLoginState),
+	}
+	for _, name := range roleNames {
+		m.roles[name] = entity.Role{ID: uuid.NewString(), Name: name, IsActive: true}
+	}
+	return m
+}
+
+func (m *memory) addUser(user entity.User, roleNames ...string) entity.User {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.users[user.ID] = user
+	m.userRoles[user.ID] = make(map[string]bool)
+	for _, name := range roleNames {
+		m.userRoles[user.ID][m.roles[name].ID] = true
+	}
+	return user
+}
+
+// roleNames returns the sorted names of the roles assigned to the user
+func (m *memory) roleNames(userID string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := []string{}
+	for _, role := range m.roles {
+		if m.userRoles[userID][role.ID] {
+			names = append(names, role.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	*memory
+}
+
+func (r fakeUserRepository) Create(_ *gorm.DB, user *entity.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users[user.ID] = *user
+	r.userRoles[user.ID] = make(map[string]bool)
+	return nil
+}
+
+func (r fakeUserRepository) Update(_ *gorm.DB, user *entity.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users[user.ID] = *user
+	return nil
+}
+
+func (r fakeUserRepository) FindById(_ *gorm.DB, user *entity.User, id any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found, ok := r.users[id.(string)]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	*user = found
+	return nil
+}
+
+func (r fakeUserRepository) FindByEmail(_ *gorm.DB, user *entity.User, email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, found := range r.users {
+		if found.Email == email {
+			*user = found
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (r fakeUserRepository) CountByUsername(_ *gorm.DB, username, _ string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	for _, found := range r.users {
+		if found.Username == username {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type fakeRoleRepository struct {
+	repository.RoleRepository
+	*memory
+}
+
+func (r fakeRoleRepository) FindByName(_ *gorm.DB, role *entity.Role, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found, ok := r.roles[name]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	*role = found
+	return nil
+}
+
+type fakeUserRoleRepository struct {
+	repository.UserRoleRepository
+	*memory
+}
+
+func (r fakeUserRoleRepository) Create(_ *gorm.DB, userRole *entity.UserRole) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.userRoles[userRole.UserID][userRole.RoleID] = true
+	return nil
+}
+
+func (r fakeUserRoleRepository) FindByUserIDAndRoleID(_ *gorm.DB, userRole *entity.UserRole, userID string, roleID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.userRoles[userID][roleID] {
+		return gorm.ErrRecordNotFound
+	}
+	*userRole = entity.UserRole{UserID: userID, RoleID: roleID}
+	return nil
+}
+
+func (r fakeUserRoleRepository) FindAllByUserID(_ *gorm.DB, userID string) ([]entity.UserRole, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var userRoles []entity.UserRole
+	for _, role := range r.roles {
+		if r.userRoles[userID][role.ID] {
+			userRoles = append(userRoles, entity.UserRole{UserID: userID, RoleID: role.ID, Role: role})
+		}
+	}
+	return userRoles, nil
+}
+
+func (r fakeUserRoleRepository) DeleteByUserIDAndRoleID(_ *gorm.DB, userID string, roleID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.userRoles[userID], roleID)
+	return nil
+}
+
+type fakeUserIdentityRepository struct {
+	repository.UserIdentityRepository
+	*memory
+}
+
+func (r fakeUserIdentityRepository) Create(_ *gorm.DB, identity *entity.UserIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.identities[identity.Issuer+" "+identity.Subject] = *identity
+	return nil
+}
+
+func (r fakeUserIdentityRepository) Update(db *gorm.DB, identity *entity.UserIdentity) error {
+	return r.Create(db, identity)
+}
+
+func (r fakeUserIdentityRepository) FindByIssuerAndSubject(_ *gorm.DB, identity *entity.UserIdentity, issuer, subject string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found, ok := r.identities[issuer+" "+subject]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	*identity = found
+	return nil
+}
+
+type fakeOIDCLoginStateRepository struct {
+	repository.OIDCLoginStateRepository
+	*memory
+}
+
+func (r fakeOIDCLoginStateRepository) Create(_ *gorm.DB, state *entity.OIDCLoginState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.states[state.StateHash] = *state
+	return nil
+}
+
+func (r fakeOIDCLoginStateRepository) DeleteExpired(*gorm.DB, int64) error {
+	return nil
+}
+
+func (r fakeOIDCLoginStateRepository) ConsumeByStateHash(_ *gorm.DB, state *entity.OIDCLoginState, stateHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found, ok := r.states[stateHash]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(r.states, stateHash)
+	*state = found
+	return nil
+}
+
+type fakeLoginAttemptRepository struct {
+	repository.LoginAttemptRepository
+}
+
+func (fakeLoginAttemptRepository) DeleteByKey(*gorm.DB, string) error {
+	return nil
+}
+
+type fakeUserSessionRepository struct {
+	repository.UserSessionRepository
+}
+
+func (fakeUserSessionRepository) Create(*gorm.DB, *entity.UserSession) error {
+	return nil
+}
+
+type fakeRefreshTokenRepository struct {
+	repository.RefreshTokenRepository
+}
+
+func (fakeRefreshTokenRepository) Create(*gorm.DB, *entity.RefreshToken) error {
+	return nil
+}
+
+type fakePermissionRepository struct {
+	repository.PermissionRepository
+}
+
+func (fakePermissionRepository) FindAllByUserID(*gorm.DB, string) ([]entity.Permission, error) {
+	return nil, nil
+}
+
+// oidcTest is a user use case signing in through a mock identity provider
+type oidcTest struct {
+	t       *testing.T
+	idp     *mockidp.Server
+	memory  *memory
+	useCase *UserUseCaseImpl
+}
+
+func newOIDCTest(t *testing.T, policy *service.OIDCPolicy) *oidcTest {
+	t.Helper()
+
+	idp := mockidp.NewServer(oidcClientID, oidcClientSecret)
+	t.Cleanup(idp.Close)
+
+	db, _, err := fakedb.Open()
+	if err != nil {
+		t.Fatalf("open fake database: %v", err)
+	}
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	if policy.StateTTL == 0 {
+		policy.StateTTL = 10 * time.Minute
+	}
+
+	m := newMemory("super_admin", "boarding_officer", "ship_captain")
+	return &oidcTest{
+		t:      t,
+		idp:    idp,
+		memory: m,
+		useCase: &UserUseCaseImpl{
+			DB:                       db,
+			Log:                      log,
+			Validate:                 validator.New(),
+			UserRepository:           fakeUserRepository{memory: m},
+			RoleRepository:           fakeRoleRepository{memory: m},
+			UserRoleRepository:       fakeUserRoleRepository{memory: m},
+			UserIdentityRepository:   fakeUserIdentityRepository{memory: m},
+			OIDCLoginStateRepository: fakeOIDCLoginStateRepository{memory: m},
+			LoginAttemptRepository:   fakeLoginAttemptRepository{},
+			UserSessionRepository:    fakeUserSessionRepository{},
+			RefreshTokenRepository:   fakeRefreshTokenRepository{},
+			PermissionRepository:     fakePermissionRepository{},
+			JWTService:               service.NewJWTService("access-secret", "refresh-secret", time.Hour, 24*time.Hour),
+			TwoFactorPolicy:          &service.TwoFactorPolicy{},
+			OIDCService: service.NewOIDCService(service.OIDCConfig{
+				Issuer:       idp.URL,
+				ClientID:     oidcClientID,
+				ClientSecret: oidcClientSecret,
+				RedirectURL:  oidcRedirectURL,
+			}),
+			OIDCPolicy: policy,
+		},
+	}
+}
+
+// authorize starts a login and lets the provider sign in the user named by loginHint, it returns the code and the
+// state the provider redirects back with
+func (o *oidcTest) authorize(loginHint string) (code string, state string) {
+	o.t.Helper()
+
+	authorization, err := o.useCase.OIDCAuthorize(context.Background())
+	if err != nil {
+		o.t.Fatalf("authorize: %v", err)
+	}
+
+	authorizationURL, err := url.Parse(authorization.AuthorizationURL)
+	if err != nil {
+		o.t.Fatalf("parse authorization url: %v", err)
+	}
+	query := authorizationURL.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" || query.Get("nonce") == "" {
+		o.t.Fatalf("authorization url %s has no PKCE challenge or nonce", authorizationURL)
+	}
+	query.Set("login_hint", loginHint)
+	authorizationURL.RawQuery = query.Encode()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	res, err := client.Get(authorizationURL.String())
+	if err != nil {
+		o.t.Fatalf("authorize at the provider: %v", err)
+	}
+	res.Body.Close()
+
+	location, err := url.Parse(res.Header.Get("Location"))
+	if err != nil || res.StatusCode != http.StatusFound {
+		o.t.Fatalf("provider answered %d without a redirect: %v", res.StatusCode, err)
+	}
+	if location.Query().Get("code") == "" {
+		o.t.Fatalf("provider redirected without a code: %s", location)
+	}
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func (o *oidcTest) callback(code string, state string) (*response.UserResponse, error) {
+	return o.useCase.OIDCCallback(context.Background(), &request.OIDCCallbackRequest{Code: code, State: state})
+}
+
+func (o *oidcTest) login(loginHint string) (*response.UserResponse, error) {
+	return o.callback(o.authorize(loginHint))
+}
+
+// link records an earlier login of the user with the provider subject
+func (o *oidcTest) link(user entity.User, subject string) {
+	o.memory.identities[o.idp.URL+" "+subject] = entity.UserIdentity{
+		ID:      uuid.NewString(),
+		UserID:  user.ID,
+		Issuer:  o.idp.URL,
+		Subject: subject,
+	}
+}
+
+func assertStatus(t *testing.T, err error, status int, message string) {
+	t.Helper()
+
+	var fiberErr *fiber.Error
+	if !errors.As(err, &fiberErr) {
+		t.Fatalf("expected a %d error, got %v", status, err)
+	}
+	if fiberErr.Code != status || (message != "" && fiberErr.Message != message) {
+		t.Fatalf("expected %d %q, got %d %q", status, message, fiberErr.Code, fiberErr.Message)
+	}
+}
+
+func TestOIDCCallbackSignsInAndConsumesTheState(t *testing.T) {
+	o := newOIDCTest(t, &service.OIDCPolicy{})
+	user := o.memory.addUser(entity.User{ID: uuid.NewString(), Username: "jane", Email: "jane@example.com", IsActive: true})
+	o.link(user, "jane-sub")
+	o.idp.AddUser(mockidp.User{Subject: "jane-sub", Email: "jane@example.com", EmailVerified: true})
+
+	code, state := o.authorize("jane-sub")
+	res, err := o.callback(code, state)
+	if err != nil {
+		t.Fatalf("callback: %v", err)
+	}
+	if res.ID != user.ID || res.Token == "" || res.RefreshToken == "" {
+		t.Fatalf("expected tokens for user %s, got %+v", user.ID, res)
+	}
+
+	// The state is single use, replaying the redirect fails before the provider is asked again
+	_, err = o.callback(code, state)
+	assertStatus(t, err, fiber.StatusUnauthorized, "invalid or expired login state")
+}
+
+func TestOIDCCallbackRejectsInvalidLoginStates(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  service.OIDCPolicy
+		tamper  func(state *entity.OIDCLoginState)
+		state   func(state string) string
+		message string
+	}{
+		{
+			name:    "unknown state",
+			state:   func(string) string { return "forged-state" },
+			message: "invalid or expired login state",
+		},
+		{
+			name:    "expired state",
+			policy:  service.OIDCPolicy{StateTTL: -time.Minute},
+			message: "invalid or expired login state",
+		},
+		{
+			name:    "nonce of another login",
+			tamper:  func(state *entity.OIDCLoginState) { state.Nonce = "another-nonce" },
+			message: "identity provider login failed",
+		},
+		{
+			name:    "code verifier of another login",
+			tamper:  func(state *entity.OIDCLoginState) { state.CodeVerifier = "another-code-verifier-of-43-characters-long" },
+			message: "identity provider login failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOIDCTest(t, &tt.policy)
+			user := o.memory.addUser(entity.User{ID: uuid.NewString(), Username: "jane", Email: "jane@example.com", IsActive: true})
+			o.link(user, "jane-sub")
+			o.idp.AddUser(mockidp.User{Subject: "jane-sub", Email: "jane@example.com", EmailVerified: true})
+
+			code, state := o.authorize("jane-sub")
+			stateHash := utils.HashToken(state)
+			if tt.tamper != nil {
+				loginState := o.memory.states[stateHash]
+				tt.tamper(&loginState)
+				o.memory.states[stateHash] = loginState
+			}
+			if tt.state != nil {
+				state = tt.state(state)
+			}
+
+			_, err := o.callback(code, state)
+			assertStatus(t, err, fiber.StatusUnauthorized, tt.message)
+
+			// A failed callback still used up the state, it can not be tried again
+			if _, ok := o.memory.states[stateHash]; ok && tt.state == nil {
+				t.Fatalf("login state is still stored after a failed callback")
+			}
+		})
+	}
+}
+
+func TestOIDCCallbackSyncsRolesFromGroups(t *testing.T) {
+	groupRoles := []service.OIDCGroupRole{
+		{Group: "inspectors", Role: "boarding_officer"},
+		{Group: "captains", Role: "ship_captain"},
+	}
+
+	tests := []struct {
+		name   string
+		groups []string
+		roles  []string
+		want   []string
+	}{
+		{
+			name:   "grants the role of a new group",
+			groups: []string{"inspectors"},
+			want:   []string{"boarding_officer"},
+		},
+		{
+			name:   "takes back the role of a group the user left",
+			groups: []string{"inspectors"},
+			roles:  []string{"boarding_officer", "ship_captain"},
+			want:   []string{"boarding_officer"},
+		},
+		{
+			name:   "keeps roles that are not mapped to a group",
+			groups: nil,
+			roles:  []string{"super_admin", "ship_captain"},
+			want:   []string{"super_admin"},
+		},
+		{
+			name:   "grants the roles of every group",
+			groups: []string{"captains", "inspectors", "unmapped"},
+			want:   []string{"boarding_officer", "ship_captain"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOIDCTest(t, &service.OIDCPolicy{GroupRoles: groupRoles})
+			user := o.memory.addUser(entity.User{ID: uuid.NewString(), Username: "jane", Email: "jane@example.com", IsActive: true}, tt.roles...)
+			o.link(user, "jane-sub")
+			o.idp.AddUser(mockidp.User{Subject: "jane-sub", Email: "jane@example.com", EmailVerified: true, Groups: tt.groups})
+
+			if _, err := o.login("jane-sub"); err != nil {
+				t.Fatalf("login: %v", err)
+			}
+
+			got := o.memory.roleNames(user.ID)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected roles %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("expected roles %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestOIDCCallbackResolvesUnknownIdentities(t *testing.T) {
+	tests := []struct {
+		name          string
+		autoProvision bool
+		existing      *entity.User
+		idpUser       mockidp.User
+		status        int
+		wantUsername  string
+	}{
+		{
+			name:          "provisions a verified identity",
+			autoProvision: true,
+			idpUser:       mockidp.User{Subject: "new-sub", Email: "new@example.com", EmailVerified: true, PreferredUsername: "new.user", GivenName: "New"},
+			wantUsername:  "new.user",
+		},
+		{
+			name:          "links the user with the same verified email instead of provisioning",
+			autoProvision: true,
+			existing:      &entity.User{ID: uuid.NewString(), Username: "jane", Email: "jane@example.com", IsActive: true},
+			idpUser:       mockidp.User{Subject: "jane-sub", Email: "jane@example.com", EmailVerified: true, PreferredUsername: "jane.doe"},
+			wantUsername:  "jane",
+		},
+		{
+			name:          "refuses an unverified email",
+			autoProvision: true,
+			existing:      &entity.User{ID: uuid.NewString(), Username: "jane", Email: "jane@example.com", IsActive: true},
+			idpUser:       mockidp.User{Subject: "mallory-sub", Email: "jane@example.com"},
+			status:        fiber.StatusForbidden,
+		},
+		{
+			name:    "refuses an unknown identity without auto provisioning",
+			idpUser: mockidp.User{Subject: "new-sub", Email: "new@example.com", EmailVerified: true},
+			status:  fiber.StatusForbidden,
+		},
+		{
+			name:          "refuses the deactivated user with the same email",
+			autoProvision: true,
+			existing:      &entity.User{ID: uuid.NewString(), Username: "gone", Email: "gone@example.com"},
+			idpUser:       mockidp.User{Subject: "gone-sub", Email: "gone@example.com", EmailVerified: true},
+			status:        fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOIDCTest(t, &service.OIDCPolicy{AutoProvision: tt.autoProvision})
+			if tt.existing != nil {
+				o.memory.addUser(*tt.existing)
+			}
+			o.idp.AddUser(tt.idpUser)
+			usersBefore := len(o.memory.users)
+
+			res, err := o.login(tt.idpUser.Subject)
+			if tt.status != 0 {
+				assertStatus(t, err, tt.status, "")
+				if len(o.memory.users) != usersBefore {
+					t.Fatalf("a refused login created a user")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("login: %v", err)
+			}
+
+			if res.Username != tt.wantUsername {
+				t.Fatalf("expected user %s, got %s", tt.wantUsername, res.Username)
+			}
+			identity, ok := o.memory.identities[o.idp.URL+" "+tt.idpUser.Subject]
+			if !ok || identity.UserID != res.ID {
+				t.Fatalf("identity %s is not linked to user %s", tt.idpUser.Subject, res.ID)
+			}
+
+			user := o.memory.users[res.ID]
+			if tt.existing == nil && (len(o.memory.users) != usersBefore+1 || !user.IsVerified || user.EmailVerifiedAt == nil) {
+				t.Fatalf("expected a new verified user, got %+v", user)
+			}
+			if tt.existing != nil && len(o.memory.users) != usersBefore {
+				t.Fatalf("expected the existing user to be linked, a user was provisioned")
+			}
+		})
+	}
+}
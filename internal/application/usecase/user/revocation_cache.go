@@ -0,0 +1,67 @@
+package user
+
+import (
+	"sync"
+	"time"
+)
+
+// revocationCacheTTL bounds how long a "not revoked" lookup is trusted before the denylist table is consulted again,
+// revocations made by other instances become visible after at most this duration
+const revocationCacheTTL = 30 * time.Second
+
+type revocationEntry struct {
+	revoked   bool
+	expiresAt time.Time
+}
+
+// revocationCache is an in-process cache in front of the revoked_tokens table
+type revocationCache struct {
+	mu        sync.RWMutex
+	entries   map[string]revocationEntry
+	lastSweep time.Time
+}
+
+func newRevocationCache() *revocationCache {
+	return &revocationCache{
+		entries: make(map[string]revocationEntry),
+	}
+}
+
+// Get returns whether the token is revoked and whether the cached answer is still valid
+func (c *revocationCache) Get(tokenID string) (revoked bool, found bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[tokenID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return false, false
+	}
+	return entry.revoked, true
+}
+
+// SetRevoked remembers a revoked token until the token itself expires
+func (c *revocationCache) SetRevoked(tokenID string, tokenExpiresAt time.Time) {
+	c.set(tokenID, revocationEntry{revoked: true, expiresAt: tokenExpiresAt})
+}
+
+// SetActive remembers a token that is not revoked for revocationCacheTTL
+func (c *revocationCache) SetActive(tokenID string) {
+	c.set(tokenID, revocationEntry{revoked: false, expiresAt: time.Now().Add(revocationCacheTTL)})
+}
+
+func (c *revocationCache) set(tokenID string, entry revocationEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Drop stale entries from time to time so the map does not grow with every token ever seen
+	now := time.Now()
+	if now.Sub(c.lastSweep) > revocationCacheTTL {
+		for id, existing := range c.entries {
+			if now.After(existing.expiresAt) {
+				delete(c.entries, id)
+			}
+		}
+		c.lastSweep = now
+	}
+	c.entries[tokenID] = entry
+}
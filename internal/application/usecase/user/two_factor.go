@@ -0,0 +1,500 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"mkp-boarding-test/internal/delivery/http/dto/request"
+	"mkp-boarding-test/internal/delivery/http/dto/response"
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// recoveryCodeCount is the number of recovery codes handed out on confirmation and regeneration
+const recoveryCodeCount = 10
+
+func (c *UserUseCaseImpl) EnrollTwoFactor(ctx context.Context, request *request.EnrollTwoFactorRequest) (*response.TwoFactorEnrollmentResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return nil, fiber.ErrNotFound
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, fiber.NewError(fiber.StatusConflict, "two-factor authentication is already enabled")
+	}
+
+	secret, err := c.TOTPService.GenerateSecret()
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate totp secret")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	sealed, err := c.TOTPService.Seal(secret)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to encrypt totp secret")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Starting over replaces an enrollment that was never confirmed
+	if err := c.TwoFactorRepository.DeleteByUserID(tx, user.ID); err != nil {
+		c.Log.WithError(err).Error("failed to delete pending two-factor enrollment")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	twoFactor := &entity.UserTwoFactor{
+		UserID: user.ID,
+		Secret: sealed,
+	}
+
+	if err := c.TwoFactorRepository.Create(tx, twoFactor); err != nil {
+		c.Log.WithError(err).Error("failed to create two-factor enrollment")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &response.TwoFactorEnrollmentResponse{
+		Secret:     secret,
+		OTPAuthURI: c.TOTPService.ProvisioningURI(secret, user.Username),
+	}, nil
+}
+
+func (c *UserUseCaseImpl) ConfirmTwoFactor(ctx context.Context, request *request.ConfirmTwoFactorRequest) (*response.RecoveryCodesResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return nil, fiber.ErrNotFound
+	}
+
+	twoFactor := new(entity.UserTwoFactor)
+	if err := c.TwoFactorRepository.FindByUserID(tx, twoFactor, user.ID); err != nil || twoFactor.ConfirmedAt != nil {
+		c.Log.WithError(err).Warnf("User %s has no pending two-factor enrollment", user.ID)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "no pending two-factor enrollment")
+	}
+
+	secret, err := c.TOTPService.Open(twoFactor.Secret)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to decrypt totp secret")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	now := time.Now()
+	step, ok := c.TOTPService.Validate(secret, request.Code, now, 0)
+	if !ok {
+		c.Log.Warnf("Invalid two-factor code while confirming enrollment of user %s", user.ID)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "invalid verification code")
+	}
+
+	confirmedAt := now.UnixMilli()
+	twoFactor.ConfirmedAt = &confirmedAt
+	twoFactor.LastUsedStep = step
+
+	if err := c.TwoFactorRepository.Update(tx, twoFactor); err != nil {
+		c.Log.WithError(err).Error("failed to confirm two-factor enrollment")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	user.TwoFactorEnabled = true
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.WithError(err).Error("failed to enable two-factor authentication")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	codes, err := c.generateRecoveryCodes(tx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &response.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (c *UserUseCaseImpl) DisableTwoFactor(ctx context.Context, request *request.DisableTwoFactorRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return fiber.ErrNotFound
+	}
+
+	if !user.TwoFactorEnabled {
+		return fiber.NewError(fiber.StatusBadRequest, "two-factor authentication is not enabled")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password)); err != nil {
+		c.Log.WithError(err).Warn("current password does not match")
+		return fiber.NewError(fiber.StatusBadRequest, "password or code is incorrect")
+	}
+
+	ok, err := c.verifySecondFactor(tx, user.ID, request.Code, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		c.Log.Warnf("Invalid two-factor code while disabling two-factor authentication of user %s", user.ID)
+		return fiber.NewError(fiber.StatusBadRequest, "password or code is incorrect")
+	}
+
+	if err := c.clearTwoFactor(tx, user); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *UserUseCaseImpl) RegenerateRecoveryCodes(ctx context.Context, request *request.RegenerateRecoveryCodesRequest) (*response.RecoveryCodesResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return nil, fiber.ErrNotFound
+	}
+
+	if !user.TwoFactorEnabled {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "two-factor authentication is not enabled")
+	}
+
+	ok, err := c.verifySecondFactor(tx, user.ID, request.Code, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		c.Log.Warnf("Invalid two-factor code while regenerating recovery codes of user %s", user.ID)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "invalid verification code")
+	}
+
+	codes, err := c.generateRecoveryCodes(tx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &response.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// ResetTwoFactor lets an administrator switch off two-factor authentication for a user who lost both the device and the recovery codes
+func (c *UserUseCaseImpl) ResetTwoFactor(ctx context.Context, request *request.ResetTwoFactorRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil || user.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return fiber.ErrNotFound
+	}
+
+	if err := c.clearTwoFactor(tx, user); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *UserUseCaseImpl) LoginTwoFactor(ctx context.Context, request *request.LoginTwoFactorRequest) (*response.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	now := time.Now()
+	challenge := new(entity.TwoFactorChallenge)
+	if err := c.TwoFactorChallengeRepository.FindByTokenHash(tx, challenge, utils.HashToken(request.TwoFactorToken)); err != nil {
+		c.Log.WithError(err).Warn("two-factor challenge is not known")
+		return nil, fiber.ErrUnauthorized
+	}
+
+	if challenge.ExpiresAt < now.Unix() {
+		c.Log.Warnf("Two-factor challenge of user %s has expired", challenge.UserID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, challenge.UserID); err != nil {
+		c.Log.WithError(err).Warn("failed to find user by id")
+		return nil, fiber.ErrUnauthorized
+	}
+
+	if user.DeletedAt != nil || !user.IsActive {
+		c.Log.Warnf("User %s is deleted or not active", user.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	// Wrong codes count as failed logins, so guessing codes is throttled like guessing passwords
+	keys := c.loginKeys(user.Username, request.IPAddress)
+	if err := c.checkLoginThrottle(ctx, keys, now); err != nil {
+		return nil, err
+	}
+
+	ok, err := c.verifySecondFactor(tx, user.ID, request.Code, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		c.Log.Warnf("Invalid two-factor code for user %s", user.ID)
+		c.recordLoginFailure(ctx, keys, now)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	if err := c.TwoFactorChallengeRepository.DeleteByUserID(tx, user.ID); err != nil {
+		c.Log.WithError(err).Error("failed to delete two-factor challenges")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response, err := c.completeLogin(tx, user, request.UserAgent, request.IPAddress, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return response, nil
+}
+
+// createTwoFactorChallenge returns the token that carries a password-checked login over to the second step
+func (c *UserUseCaseImpl) createTwoFactorChallenge(tx *gorm.DB, user *entity.User, now time.Time) (string, error) {
+	if err := c.TwoFactorChallengeRepository.DeleteByUserID(tx, user.ID); err != nil {
+		c.Log.WithError(err).Error("failed to delete two-factor challenges")
+		return "", fiber.ErrInternalServerError
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate two-factor challenge")
+		return "", fiber.ErrInternalServerError
+	}
+
+	challenge := &entity.TwoFactorChallenge{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: now.Add(c.TwoFactorPolicy.ChallengeTTL).Unix(),
+	}
+
+	if err := c.TwoFactorChallengeRepository.Create(tx, challenge); err != nil {
+		c.Log.WithError(err).Error("failed to create two-factor challenge")
+		return "", fiber.ErrInternalServerError
+	}
+
+	return token, nil
+}
+
+// verifySecondFactor accepts either a current TOTP code or an unused recovery code, both are single use
+func (c *UserUseCaseImpl) verifySecondFactor(tx *gorm.DB, userID string, code string, now time.Time) (bool, error) {
+	twoFactor := new(entity.UserTwoFactor)
+	if err := c.TwoFactorRepository.FindByUserID(tx, twoFactor, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		c.Log.WithError(err).Error("failed to find two-factor enrollment")
+		return false, fiber.ErrInternalServerError
+	}
+
+	if twoFactor.ConfirmedAt == nil {
+		return false, nil
+	}
+
+	code = strings.TrimSpace(code)
+	if isTOTPCode(code) {
+		secret, err := c.TOTPService.Open(twoFactor.Secret)
+		if err != nil {
+			c.Log.WithError(err).Error("failed to decrypt totp secret")
+			return false, fiber.ErrInternalServerError
+		}
+
+		step, ok := c.TOTPService.Validate(secret, code, now, twoFactor.LastUsedStep)
+		if !ok {
+			return false, nil
+		}
+
+		twoFactor.LastUsedStep = step
+		if err := c.TwoFactorRepository.Update(tx, twoFactor); err != nil {
+			c.Log.WithError(err).Error("failed to update two-factor enrollment")
+			return false, fiber.ErrInternalServerError
+		}
+		return true, nil
+	}
+
+	recoveryCode := new(entity.RecoveryCode)
+	if err := c.RecoveryCodeRepository.FindUnusedByUserIDAndCodeHash(tx, recoveryCode, userID, utils.HashToken(normalizeRecoveryCode(code))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		c.Log.WithError(err).Error("failed to find recovery code")
+		return false, fiber.ErrInternalServerError
+	}
+
+	usedAt := now.UnixMilli()
+	recoveryCode.UsedAt = &usedAt
+	if err := c.RecoveryCodeRepository.Update(tx, recoveryCode); err != nil {
+		c.Log.WithError(err).Error("failed to use recovery code")
+		return false, fiber.ErrInternalServerError
+	}
+
+	c.Log.Infof("User %s signed in with a recovery code", userID)
+	return true, nil
+}
+
+// generateRecoveryCodes replaces every recovery code of the user, only hashes are stored
+func (c *UserUseCaseImpl) generateRecoveryCodes(tx *gorm.DB, userID string) ([]string, error) {
+	if err := c.RecoveryCodeRepository.DeleteByUserID(tx, userID); err != nil {
+		c.Log.WithError(err).Error("failed to delete recovery codes")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		raw, err := utils.GenerateRandomToken(5)
+		if err != nil {
+			c.Log.WithError(err).Error("failed to generate recovery code")
+			return nil, fiber.ErrInternalServerError
+		}
+
+		recoveryCode := &entity.RecoveryCode{
+			ID:       uuid.NewString(),
+			UserID:   userID,
+			CodeHash: utils.HashToken(raw),
+		}
+
+		if err := c.RecoveryCodeRepository.Create(tx, recoveryCode); err != nil {
+			c.Log.WithError(err).Error("failed to create recovery code")
+			return nil, fiber.ErrInternalServerError
+		}
+
+		codes[i] = raw[:5] + "-" + raw[5:]
+	}
+
+	return codes, nil
+}
+
+func (c *UserUseCaseImpl) clearTwoFactor(tx *gorm.DB, user *entity.User) error {
+	if err := c.TwoFactorRepository.DeleteByUserID(tx, user.ID); err != nil {
+		c.Log.WithError(err).Error("failed to delete two-factor enrollment")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.RecoveryCodeRepository.DeleteByUserID(tx, user.ID); err != nil {
+		c.Log.WithError(err).Error("failed to delete recovery codes")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.TwoFactorChallengeRepository.DeleteByUserID(tx, user.ID); err != nil {
+		c.Log.WithError(err).Error("failed to delete two-factor challenges")
+		return fiber.ErrInternalServerError
+	}
+
+	user.TwoFactorEnabled = false
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.WithError(err).Error("failed to disable two-factor authentication")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// requiresTwoFactorEnrollment reports whether the policy forces the user to enroll because of the permissions it holds
+func (c *UserUseCaseImpl) requiresTwoFactorEnrollment(tx *gorm.DB, userID string) (bool, error) {
+	if !c.TwoFactorPolicy.MandatoryForPrivileged {
+		return false, nil
+	}
+
+	permissions, err := c.PermissionRepository.FindAllByUserID(tx, userID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find user permissions")
+		return false, fiber.ErrInternalServerError
+	}
+
+	names := make([]string, len(permissions))
+	for i, permission := range permissions {
+		names[i] = permission.Name
+	}
+
+	return c.TwoFactorPolicy.RequiresEnrollment(names), nil
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != 6 {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"mkp-boarding-test/internal/delivery/http/dto/request"
@@ -9,9 +10,12 @@ import (
 	"mkp-boarding-test/internal/domain/entity"
 	"mkp-boarding-test/internal/domain/repository"
 	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/gateway/mail"
 	"mkp-boarding-test/internal/gateway/messaging"
+	"mkp-boarding-test/internal/model"
 	"mkp-boarding-test/internal/model/converter"
 	"mkp-boarding-test/pkg/service"
+	"mkp-boarding-test/pkg/utils"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -19,26 +23,107 @@ import (
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// sessionLastSeenInterval is how stale last_seen_at may get before an authenticated request refreshes it
+const sessionLastSeenInterval = time.Minute
+
+// verificationTokenTTL is how long an email verification token stays valid
+const verificationTokenTTL = 24 * time.Hour
+
+// passwordResetTokenTTL is how long a password reset token stays valid
+const passwordResetTokenTTL = time.Hour
+
 type UserUseCaseImpl struct {
-	DB             *gorm.DB
-	Log            *logrus.Logger
-	Validate       *validator.Validate
-	UserRepository repository.UserRepository
-	UserProducer   *messaging.UserProducer
-	JWTService     service.JWTService
+	DB                              *gorm.DB
+	Log                             *logrus.Logger
+	Validate                        *validator.Validate
+	UserRepository                  repository.UserRepository
+	RoleRepository                  repository.RoleRepository
+	UserRoleRepository              repository.UserRoleRepository
+	RefreshTokenRepository          repository.RefreshTokenRepository
+	RevokedTokenRepository          repository.RevokedTokenRepository
+	UserSessionRepository           repository.UserSessionRepository
+	VerificationRepository          repository.EmailVerificationTokenRepository
+	PasswordResetRepository         repository.PasswordResetTokenRepository
+	PasswordHistoryRepository       repository.PasswordHistoryRepository
+	LoginAttemptRepository          repository.LoginAttemptRepository
+	PermissionRepository            repository.PermissionRepository
+	TwoFactorRepository             repository.UserTwoFactorRepository
+	RecoveryCodeRepository          repository.RecoveryCodeRepository
+	TwoFactorChallengeRepository    repository.TwoFactorChallengeRepository
+	UserIdentityRepository          repository.UserIdentityRepository
+	OIDCLoginStateRepository        repository.OIDCLoginStateRepository
+	ImpersonationAuditLogRepository repository.ImpersonationAuditLogRepository
+	UserProducer                    *messaging.UserProducer
+	UserMailer                      *mail.UserMailer
+	JWTService                      service.JWTService
+	PasswordPolicy                  *service.PasswordPolicy
+	LockoutPolicy                   *service.LockoutPolicy
+	TOTPService                     service.TOTPService
+	TwoFactorPolicy                 *service.TwoFactorPolicy
+	// OIDCService is nil when login through the identity provider is disabled
+	OIDCService         service.OIDCService
+	OIDCPolicy          *service.OIDCPolicy
+	ImpersonationPolicy *service.ImpersonationPolicy
+
+	revocations       *revocationCache
+	dummyPasswordHash []byte
 }
 
 func NewUserUseCase(db *gorm.DB, logger *logrus.Logger, validate *validator.Validate,
-	userRepository repository.UserRepository, userProducer *messaging.UserProducer, jwtService service.JWTService) usecase.UserUseCase {
+	userRepository repository.UserRepository, roleRepository repository.RoleRepository,
+	userRoleRepository repository.UserRoleRepository, refreshTokenRepository repository.RefreshTokenRepository,
+	revokedTokenRepository repository.RevokedTokenRepository, userSessionRepository repository.UserSessionRepository,
+	verificationRepository repository.EmailVerificationTokenRepository, passwordResetRepository repository.PasswordResetTokenRepository,
+	passwordHistoryRepository repository.PasswordHistoryRepository, loginAttemptRepository repository.LoginAttemptRepository,
+	permissionRepository repository.PermissionRepository, twoFactorRepository repository.UserTwoFactorRepository,
+	recoveryCodeRepository repository.RecoveryCodeRepository, twoFactorChallengeRepository repository.TwoFactorChallengeRepository,
+	userIdentityRepository repository.UserIdentityRepository, oidcLoginStateRepository repository.OIDCLoginStateRepository,
+	impersonationAuditLogRepository repository.ImpersonationAuditLogRepository,
+	userProducer *messaging.UserProducer, userMailer *mail.UserMailer, jwtService service.JWTService,
+	passwordPolicy *service.PasswordPolicy, lockoutPolicy *service.LockoutPolicy,
+	totpService service.TOTPService, twoFactorPolicy *service.TwoFactorPolicy,
+	oidcService service.OIDCService, oidcPolicy *service.OIDCPolicy,
+	impersonationPolicy *service.ImpersonationPolicy) usecase.UserUseCase {
+	// Only used to spend the time of a real password check, the plaintext is random and discarded
+	dummyPassword, _ := utils.GenerateRandomToken(16)
+	dummyPasswordHash, _ := bcrypt.GenerateFromPassword([]byte(dummyPassword), bcrypt.DefaultCost)
+
 	return &UserUseCaseImpl{
-		DB:             db,
-		Log:            logger,
-		Validate:       validate,
-		UserRepository: userRepository,
-		UserProducer:   userProducer,
-		JWTService:     jwtService,
+		DB:                              db,
+		Log:                             logger,
+		Validate:                        validate,
+		UserRepository:                  userRepository,
+		RoleRepository:                  roleRepository,
+		UserRoleRepository:              userRoleRepository,
+		RefreshTokenRepository:          refreshTokenRepository,
+		RevokedTokenRepository:          revokedTokenRepository,
+		UserSessionRepository:           userSessionRepository,
+		VerificationRepository:          verificationRepository,
+		PasswordResetRepository:         passwordResetRepository,
+		PasswordHistoryRepository:       passwordHistoryRepository,
+		LoginAttemptRepository:          loginAttemptRepository,
+		PermissionRepository:            permissionRepository,
+		TwoFactorRepository:             twoFactorRepository,
+		RecoveryCodeRepository:          recoveryCodeRepository,
+		TwoFactorChallengeRepository:    twoFactorChallengeRepository,
+		UserIdentityRepository:          userIdentityRepository,
+		OIDCLoginStateRepository:        oidcLoginStateRepository,
+		ImpersonationAuditLogRepository: impersonationAuditLogRepository,
+		UserProducer:                    userProducer,
+		UserMailer:                      userMailer,
+		JWTService:                      jwtService,
+		PasswordPolicy:                  passwordPolicy,
+		LockoutPolicy:                   lockoutPolicy,
+		TOTPService:                     totpService,
+		TwoFactorPolicy:                 twoFactorPolicy,
+		OIDCService:                     oidcService,
+		OIDCPolicy:                      oidcPolicy,
+		ImpersonationPolicy:             impersonationPolicy,
+		revocations:                     newRevocationCache(),
+		dummyPasswordHash:               dummyPasswordHash,
 	}
 }
 
@@ -51,12 +136,40 @@ func (c *UserUseCaseImpl) Verify(ctx context.Context, request *request.VerifyUse
 		return nil, fiber.ErrBadRequest
 	}
 
+	verificationToken := new(entity.EmailVerificationToken)
+	if err := c.VerificationRepository.FindByTokenHash(tx, verificationToken, utils.HashToken(request.Token)); err != nil {
+		c.Log.WithError(err).Error("failed to find verification token")
+		return nil, fiber.ErrNotFound
+	}
+
+	if verificationToken.ExpiresAt < time.Now().Unix() {
+		c.Log.Warnf("Verification token of user %s has expired", verificationToken.UserID)
+		return nil, fiber.ErrBadRequest
+	}
+
 	user := new(entity.User)
-	if err := c.UserRepository.FindByToken(tx, user, request.Token); err != nil {
-		c.Log.WithError(err).Error("failed to find user by token")
+	if err := c.UserRepository.FindById(tx, user, verificationToken.UserID); err != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
 		return nil, fiber.ErrNotFound
 	}
 
+	if !user.IsVerified {
+		verifiedAt := time.Now().UnixMilli()
+		user.IsVerified = true
+		user.EmailVerifiedAt = &verifiedAt
+
+		if err := c.UserRepository.Update(tx, user); err != nil {
+			c.Log.WithError(err).Error("failed to update user")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	// Tokens are single-use
+	if err := c.VerificationRepository.DeleteByUserID(tx, user.ID); err != nil {
+		c.Log.WithError(err).Error("failed to delete verification tokens")
+		return nil, fiber.ErrInternalServerError
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.WithError(err).Error("failed to commit transaction")
 		return nil, fiber.ErrInternalServerError
@@ -65,6 +178,171 @@ func (c *UserUseCaseImpl) Verify(ctx context.Context, request *request.VerifyUse
 	return converter.UserToResponse(user), nil
 }
 
+func (c *UserUseCaseImpl) ResendVerification(ctx context.Context, request *request.ResendVerificationRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	// Unknown or already verified addresses are ignored silently so the endpoint cannot be used to probe accounts
+	user := new(entity.User)
+	if err := c.UserRepository.FindByEmail(tx, user, request.Email); err != nil {
+		c.Log.WithError(err).Warn("failed to find user by email")
+		return nil
+	}
+
+	if user.IsVerified || user.DeletedAt != nil {
+		c.Log.Warnf("User %s does not need email verification", user.ID)
+		return nil
+	}
+
+	token, err := c.createVerificationToken(tx, user)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	c.sendVerificationEmail(user, token)
+	return nil
+}
+
+func (c *UserUseCaseImpl) ForgotPassword(ctx context.Context, request *request.ForgotPasswordRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	// Unknown or unusable accounts are ignored silently so the endpoint cannot be used to probe accounts
+	user := new(entity.User)
+	if err := c.UserRepository.FindByEmail(tx, user, request.Email); err != nil {
+		c.Log.WithError(err).Warn("failed to find user by email")
+		return nil
+	}
+
+	if user.DeletedAt != nil || !user.IsActive {
+		c.Log.Warnf("User %s is deleted or not active", user.ID)
+		return nil
+	}
+
+	if err := c.PasswordResetRepository.DeleteByUserID(tx, user.ID); err != nil {
+		c.Log.WithError(err).Error("failed to delete password reset tokens")
+		return fiber.ErrInternalServerError
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate password reset token")
+		return fiber.ErrInternalServerError
+	}
+
+	resetToken := &entity.PasswordResetToken{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(passwordResetTokenTTL).Unix(),
+	}
+
+	if err := c.PasswordResetRepository.Create(tx, resetToken); err != nil {
+		c.Log.WithError(err).Error("failed to create password reset token")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	if c.UserMailer != nil {
+		if err := c.UserMailer.SendPasswordReset(user.Email, user.Username, token); err != nil {
+			c.Log.WithError(err).Errorf("failed to send password reset email to user %s", user.ID)
+		}
+	}
+
+	return nil
+}
+
+func (c *UserUseCaseImpl) ResetPassword(ctx context.Context, request *request.ResetPasswordRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	resetToken := new(entity.PasswordResetToken)
+	if err := c.PasswordResetRepository.FindByTokenHash(tx, resetToken, utils.HashToken(request.Token)); err != nil {
+		c.Log.WithError(err).Warn("failed to find password reset token")
+		return fiber.ErrBadRequest
+	}
+
+	if resetToken.ExpiresAt < time.Now().Unix() {
+		c.Log.Warnf("Password reset token of user %s has expired", resetToken.UserID)
+		return fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, resetToken.UserID); err != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return fiber.ErrBadRequest
+	}
+
+	if user.DeletedAt != nil || !user.IsActive {
+		c.Log.Warnf("User %s is deleted or not active", user.ID)
+		return fiber.ErrBadRequest
+	}
+
+	if err := c.checkNewPassword(tx, user, request.NewPassword); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := c.setPassword(tx, user, request.NewPassword); err != nil {
+		return err
+	}
+
+	user.Token = nil
+	user.TokenExpiresAt = nil
+	user.RefreshToken = nil
+	user.RefreshExpiresAt = nil
+
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.WithError(err).Error("failed to update user")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.recordPasswordHistory(tx, user); err != nil {
+		return err
+	}
+
+	// Tokens are single-use
+	if err := c.PasswordResetRepository.DeleteByUserID(tx, user.ID); err != nil {
+		c.Log.WithError(err).Error("failed to delete password reset tokens")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.revokeAllSessions(tx, user.ID, now.UnixMilli()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
 func (c *UserUseCaseImpl) Create(ctx context.Context, request *request.RegisterUserRequest) (*response.UserResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
@@ -82,6 +360,11 @@ func (c *UserUseCaseImpl) Create(ctx context.Context, request *request.RegisterU
 		return nil, fiber.ErrConflict
 	}
 
+	if err := c.PasswordPolicy.Validate(request.Password, request.Username, request.Email); err != nil {
+		c.Log.WithError(err).Warn("password does not satisfy the password policy")
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
 	password, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
 	if err != nil {
 		c.Log.WithError(err).Error("failed to generate bcrypt hash")
@@ -114,11 +397,21 @@ func (c *UserUseCaseImpl) Create(ctx context.Context, request *request.RegisterU
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if err := c.recordPasswordHistory(tx, user); err != nil {
+		return nil, err
+	}
+
+	token, err := c.createVerificationToken(tx, user)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		c.Log.WithError(err).Error("failed to commit transaction")
 		return nil, fiber.ErrInternalServerError
 	}
 
+	c.sendVerificationEmail(user, token)
 	return converter.UserToResponse(user), nil
 }
 
@@ -131,42 +424,45 @@ func (c *UserUseCaseImpl) Login(ctx context.Context, request *request.LoginUserR
 		return nil, fiber.ErrBadRequest
 	}
 
+	now := time.Now()
+	keys := c.loginKeys(request.Username, request.IPAddress)
+	if err := c.checkLoginThrottle(ctx, keys, now); err != nil {
+		return nil, err
+	}
+
+	// Unknown users, wrong passwords and unusable accounts all fail the same way so accounts can not be probed
 	user := new(entity.User)
 	if err := c.UserRepository.FindByUsername(tx, user, request.Username); err != nil {
-		c.Log.WithError(err).Error("failed to find user by username")
-		return nil, fiber.ErrNotFound
+		c.Log.WithError(err).Warn("failed to find user by username")
+		// Compare anyway to keep the response time of unknown usernames in line with known ones
+		_ = bcrypt.CompareHashAndPassword(c.dummyPasswordHash, []byte(request.Password))
+		c.recordLoginFailure(ctx, keys, now)
+		return nil, fiber.ErrUnauthorized
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password)); err != nil {
-		c.Log.WithError(err).Error("invalid password")
+		c.Log.WithError(err).Warn("invalid password")
+		c.recordLoginFailure(ctx, keys, now)
 		return nil, fiber.ErrUnauthorized
 	}
 
-	// Generate JWT tokens
-	token, err := c.JWTService.GenerateToken(user)
-	if err != nil {
-		c.Log.WithError(err).Error("failed to generate JWT token")
-		return nil, fiber.ErrInternalServerError
+	if user.DeletedAt != nil || !user.IsActive {
+		c.Log.Warnf("User %s is deleted or not active", user.ID)
+		c.recordLoginFailure(ctx, keys, now)
+		return nil, fiber.ErrUnauthorized
 	}
 
-	refreshToken, err := c.JWTService.GenerateRefreshToken(user)
-	if err != nil {
-		c.Log.WithError(err).Error("failed to generate refresh token")
-		return nil, fiber.ErrInternalServerError
+	// The failure counter is only reset once the second factor was accepted as well,
+	// otherwise every correct password would buy another round of code guesses
+	var response *response.UserResponse
+	var err error
+	if user.TwoFactorEnabled {
+		response, err = c.startTwoFactorLogin(tx, user, now)
+	} else {
+		response, err = c.completeLogin(tx, user, request.UserAgent, request.IPAddress, now)
 	}
-
-	// Update user with tokens
-	now := time.Now()
-	tokenExpiresAt := now.Add(24 * time.Hour).Unix()       // Token expires in 24 hours
-	refreshExpiresAt := now.Add(7 * 24 * time.Hour).Unix() // Refresh token expires in 7 days
-	user.Token = &token
-	user.TokenExpiresAt = &tokenExpiresAt
-	user.RefreshToken = &refreshToken
-	user.RefreshExpiresAt = &refreshExpiresAt
-
-	if err := c.UserRepository.Update(tx, user); err != nil {
-		c.Log.WithError(err).Error("failed to update user with tokens")
-		return nil, fiber.ErrInternalServerError
+	if err != nil {
+		return nil, err
 	}
 
 	if err := tx.Commit().Error; err != nil {
@@ -174,71 +470,73 @@ func (c *UserUseCaseImpl) Login(ctx context.Context, request *request.LoginUserR
 		return nil, fiber.ErrInternalServerError
 	}
 
-	response := converter.UserToResponse(user)
-	response.Token = token
-	response.RefreshToken = refreshToken
-
 	return response, nil
 }
 
-func (c *UserUseCaseImpl) Current(ctx context.Context, request *request.GetUserRequest) (*response.UserResponse, error) {
-	tx := c.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
-
-	if err := c.Validate.Struct(request); err != nil {
-		c.Log.WithError(err).Error("failed to validate request body")
-		return nil, fiber.ErrBadRequest
+// startTwoFactorLogin answers a login whose first factor was accepted with a challenge for the second one
+func (c *UserUseCaseImpl) startTwoFactorLogin(tx *gorm.DB, user *entity.User, now time.Time) (*response.UserResponse, error) {
+	token, err := c.createTwoFactorChallenge(tx, user, now)
+	if err != nil {
+		return nil, err
 	}
 
-	user := new(entity.User)
-	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
-		c.Log.WithError(err).Error("failed to find user by id")
-		return nil, fiber.ErrNotFound
-	}
+	return &response.UserResponse{
+		ID:                user.ID,
+		Username:          user.Username,
+		Email:             user.Email,
+		TwoFactorEnabled:  true,
+		TwoFactorRequired: true,
+		TwoFactorToken:    token,
+	}, nil
+}
 
-	if err := tx.Commit().Error; err != nil {
-		c.Log.WithError(err).Error("failed to commit transaction")
+// completeLogin starts a session for a user whose credentials were fully checked and issues its tokens
+func (c *UserUseCaseImpl) completeLogin(tx *gorm.DB, user *entity.User, userAgent string, ipAddress string, now time.Time) (*response.UserResponse, error) {
+	if err := c.LoginAttemptRepository.DeleteByKey(tx, accountLoginKey(user.Username)); err != nil {
+		c.Log.WithError(err).Error("failed to reset login attempts")
 		return nil, fiber.ErrInternalServerError
 	}
 
-	return converter.UserToResponse(user), nil
-}
-
-func (c *UserUseCaseImpl) Logout(ctx context.Context, request *request.LogoutUserRequest) (bool, error) {
-	tx := c.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	lastLoginAt := now.UnixMilli()
+	user.LastLoginAt = &lastLoginAt
 
-	if err := c.Validate.Struct(request); err != nil {
-		c.Log.Warnf("Invalid request body : %+v", err)
-		return false, fiber.ErrBadRequest
+	session := &entity.UserSession{
+		ID:         uuid.NewString(),
+		UserID:     user.ID,
+		FamilyID:   uuid.NewString(),
+		UserAgent:  nullableString(userAgent),
+		IPAddress:  nullableString(ipAddress),
+		LastSeenAt: now.UnixMilli(),
 	}
 
-	user := new(entity.User)
-	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
-		c.Log.Warnf("Failed find user by id : %+v", err)
-		return false, fiber.ErrNotFound
+	if err := c.UserSessionRepository.Create(tx, session); err != nil {
+		c.Log.WithError(err).Error("failed to create user session")
+		return nil, fiber.ErrInternalServerError
 	}
 
-	// Clear tokens from database
-	user.Token = nil
-	user.TokenExpiresAt = nil
-	user.RefreshToken = nil
-	user.RefreshExpiresAt = nil
+	response, _, err := c.issueTokens(tx, user, session)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := c.UserRepository.Update(tx, user); err != nil {
-		c.Log.WithError(err).Error("failed to clear user tokens")
-		return false, fiber.ErrInternalServerError
+	// Roles are attached after the user row is saved so the save does not cascade into the associations
+	if err := c.loadRoles(tx, user); err != nil {
+		return nil, err
 	}
+	response.Roles = converter.UserToResponse(user).Roles
 
-	if err := tx.Commit().Error; err != nil {
-		c.Log.WithError(err).Error("failed to commit transaction")
-		return false, fiber.ErrInternalServerError
+	if !user.TwoFactorEnabled {
+		required, err := c.requiresTwoFactorEnrollment(tx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		response.TwoFactorEnrollmentRequired = required
 	}
 
-	return true, nil
+	return response, nil
 }
 
-func (c *UserUseCaseImpl) Update(ctx context.Context, request *request.UpdateUserRequest) (*response.UserResponse, error) {
+func (c *UserUseCaseImpl) Refresh(ctx context.Context, request *request.RefreshTokenRequest) (*response.UserResponse, error) {
 	tx := c.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
@@ -247,58 +545,84 @@ func (c *UserUseCaseImpl) Update(ctx context.Context, request *request.UpdateUse
 		return nil, fiber.ErrBadRequest
 	}
 
-	user := new(entity.User)
-	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
-		c.Log.WithError(err).Error("failed to find user by id")
-		return nil, fiber.ErrNotFound
+	if _, err := c.JWTService.ExtractClaimsFromRefreshToken(request.RefreshToken); err != nil {
+		c.Log.WithError(err).Warn("invalid refresh token")
+		return nil, fiber.ErrUnauthorized
 	}
 
-	if request.FirstName != nil {
-		user.FirstName = *request.FirstName
+	// The token row stays locked until commit, a concurrent refresh with the same token waits and then sees it rotated
+	refreshToken := new(entity.RefreshToken)
+	if err := c.RefreshTokenRepository.FindByTokenHash(tx.Clauses(clause.Locking{Strength: "UPDATE"}), refreshToken, utils.HashToken(request.RefreshToken)); err != nil {
+		c.Log.WithError(err).Warn("refresh token is not known")
+		return nil, fiber.ErrUnauthorized
 	}
 
-	if request.LastName != nil {
-		user.LastName = *request.LastName
+	session := new(entity.UserSession)
+	if err := c.UserSessionRepository.FindByFamilyID(tx, session, refreshToken.FamilyID); err != nil {
+		c.Log.WithError(err).Warn("failed to find session of refresh token")
+		return nil, fiber.ErrUnauthorized
 	}
 
-	if request.Phone != nil {
-		user.Phone = request.Phone
+	if session.RevokedAt != nil {
+		c.Log.Warnf("Session %s has been revoked", session.ID)
+		return nil, fiber.ErrUnauthorized
 	}
 
-	if request.Password != nil && *request.Password != "" {
-		password, err := bcrypt.GenerateFromPassword([]byte(*request.Password), bcrypt.DefaultCost)
-		if err != nil {
-			c.Log.WithError(err).Error("failed to generate bcrypt hash")
+	// A refresh token that was already rotated is being replayed, so the whole family is compromised
+	if refreshToken.RevokedAt != nil {
+		c.Log.Warnf("Refresh token reuse detected for user %s, revoking family %s", refreshToken.UserID, refreshToken.FamilyID)
+		if err := c.revokeSession(tx, session); err != nil {
+			return nil, err
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			c.Log.WithError(err).Error("failed to commit transaction")
 			return nil, fiber.ErrInternalServerError
 		}
-		user.Password = string(password)
+
+		return nil, fiber.ErrUnauthorized
 	}
 
-	if err := c.UserRepository.Update(tx, user); err != nil {
-		c.Log.WithError(err).Error("failed to update user")
-		return nil, fiber.ErrInternalServerError
+	if refreshToken.ExpiresAt < time.Now().Unix() {
+		c.Log.Warn("refresh token is expired")
+		return nil, fiber.ErrUnauthorized
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		c.Log.WithError(err).Error("failed to commit transaction")
-		return nil, fiber.ErrInternalServerError
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, refreshToken.UserID); err != nil {
+		c.Log.WithError(err).Warn("failed to find user by id")
+		return nil, fiber.ErrUnauthorized
 	}
 
-	return converter.UserToResponse(user), nil
-}
+	if !user.IsActive {
+		c.Log.Warnf("User %s is not active", user.ID)
+		return nil, fiber.ErrUnauthorized
+	}
 
-func (c *UserUseCaseImpl) FindByRoleID(ctx context.Context, roleID string) ([]*response.UserResponse, error) {
-	tx := c.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	session.LastSeenAt = time.Now().UnixMilli()
+	if request.UserAgent != "" {
+		session.UserAgent = &request.UserAgent
+	}
+	if request.IPAddress != "" {
+		session.IPAddress = &request.IPAddress
+	}
 
-	if roleID == "" {
-		c.Log.Error("roleID is required")
-		return nil, fiber.ErrBadRequest
+	if err := c.UserSessionRepository.Update(tx, session); err != nil {
+		c.Log.WithError(err).Error("failed to update user session")
+		return nil, fiber.ErrInternalServerError
 	}
 
-	users, err := c.UserRepository.FindByRoleID(tx, roleID)
+	response, issued, err := c.issueTokens(tx, user, session)
 	if err != nil {
-		c.Log.WithError(err).Error("failed to find users by role id")
+		return nil, err
+	}
+
+	// Invalidate the presented token and link it to its replacement
+	revokedAt := time.Now().UnixMilli()
+	refreshToken.RevokedAt = &revokedAt
+	refreshToken.ReplacedBy = &issued.ID
+	if err := c.RefreshTokenRepository.Update(tx, refreshToken); err != nil {
+		c.Log.WithError(err).Error("failed to revoke refresh token")
 		return nil, fiber.ErrInternalServerError
 	}
 
@@ -307,5 +631,882 @@ func (c *UserUseCaseImpl) FindByRoleID(ctx context.Context, roleID string) ([]*r
 		return nil, fiber.ErrInternalServerError
 	}
 
-	return converter.UserToResponseList(users), nil
+	return response, nil
+}
+
+func (c *UserUseCaseImpl) Authenticate(ctx context.Context, request *request.AuthenticateUserRequest) (*response.UserResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	revoked, found := c.revocations.Get(request.TokenID)
+	if !found {
+		count, err := c.RevokedTokenRepository.CountById(tx, request.TokenID)
+		if err != nil {
+			c.Log.WithError(err).Error("failed to count revoked token by id")
+			return nil, fiber.ErrInternalServerError
+		}
+
+		revoked = count > 0
+		if !revoked {
+			c.revocations.SetActive(request.TokenID)
+		}
+	}
+
+	if revoked {
+		c.Log.Warnf("Token %s has been revoked", request.TokenID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserID); err != nil {
+		c.Log.WithError(err).Warn("failed to find user by id")
+		return nil, fiber.ErrUnauthorized
+	}
+
+	if user.DeletedAt != nil || !user.IsActive {
+		c.Log.Warnf("User %s is deleted or not active", user.ID)
+		return nil, fiber.ErrUnauthorized
+	}
+
+	// An impersonation token is only good while the admin who acts as the user is still active
+	if request.ActorID != "" {
+		actor := new(entity.User)
+		if err := c.UserRepository.FindById(tx, actor, request.ActorID); err != nil {
+			c.Log.WithError(err).Warn("failed to find actor by id")
+			return nil, fiber.ErrUnauthorized
+		}
+
+		if actor.DeletedAt != nil || !actor.IsActive {
+			c.Log.Warnf("Actor %s is deleted or not active", actor.ID)
+			return nil, fiber.ErrUnauthorized
+		}
+	}
+
+	if request.SessionID != "" {
+		session := new(entity.UserSession)
+		if err := c.UserSessionRepository.FindById(tx, session, request.SessionID); err != nil {
+			c.Log.WithError(err).Warn("failed to find user session")
+			return nil, fiber.ErrUnauthorized
+		}
+
+		if session.UserID != user.ID || session.RevokedAt != nil {
+			c.Log.Warnf("Session %s has been revoked", session.ID)
+			return nil, fiber.ErrUnauthorized
+		}
+
+		// Only touch last_seen_at once in a while to avoid a write on every request
+		now := time.Now()
+		if now.Sub(time.UnixMilli(session.LastSeenAt)) > sessionLastSeenInterval {
+			session.LastSeenAt = now.UnixMilli()
+			if err := c.UserSessionRepository.Update(tx, session); err != nil {
+				c.Log.WithError(err).Error("failed to update user session")
+				return nil, fiber.ErrInternalServerError
+			}
+		}
+	}
+
+	return converter.UserToResponse(user), nil
+}
+
+func (c *UserUseCaseImpl) Current(ctx context.Context, request *request.GetUserRequest) (*response.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := c.loadRoles(tx, user); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToResponse(user), nil
+}
+
+func (c *UserUseCaseImpl) Logout(ctx context.Context, request *request.LogoutUserRequest) (bool, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.Warnf("Invalid request body : %+v", err)
+		return false, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
+		c.Log.Warnf("Failed find user by id : %+v", err)
+		return false, fiber.ErrNotFound
+	}
+
+	// Ending an impersonation only revokes the impersonation token, the sessions of the user stay untouched
+	if request.ActorID != "" {
+		if err := c.revokeAccessToken(tx, user.ID, request.TokenID, request.TokenExpiresAt); err != nil {
+			return false, err
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			c.Log.WithError(err).Error("failed to commit transaction")
+			return false, fiber.ErrInternalServerError
+		}
+
+		return true, nil
+	}
+
+	// Clear tokens from database
+	user.Token = nil
+	user.TokenExpiresAt = nil
+	user.RefreshToken = nil
+	user.RefreshExpiresAt = nil
+
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.WithError(err).Error("failed to clear user tokens")
+		return false, fiber.ErrInternalServerError
+	}
+
+	if request.SessionID != "" {
+		session := new(entity.UserSession)
+		if err := c.UserSessionRepository.FindById(tx, session, request.SessionID); err != nil {
+			c.Log.WithError(err).Error("failed to find user session")
+			return false, fiber.ErrNotFound
+		}
+
+		if err := c.revokeSession(tx, session); err != nil {
+			return false, err
+		}
+	} else if err := c.RefreshTokenRepository.RevokeByUserID(tx, user.ID, time.Now().UnixMilli()); err != nil {
+		c.Log.WithError(err).Error("failed to revoke refresh tokens")
+		return false, fiber.ErrInternalServerError
+	}
+
+	if request.TokenID != "" {
+		if err := c.revokeAccessToken(tx, user.ID, request.TokenID, request.TokenExpiresAt); err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return false, fiber.ErrInternalServerError
+	}
+
+	return true, nil
+}
+
+// Update changes the profile of the current user, the password only changes through ChangePassword and ResetPassword
+func (c *UserUseCaseImpl) Update(ctx context.Context, request *request.UpdateUserRequest) (*response.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return nil, fiber.ErrNotFound
+	}
+
+	if request.FirstName != nil {
+		user.FirstName = *request.FirstName
+	}
+
+	if request.LastName != nil {
+		user.LastName = *request.LastName
+	}
+
+	if request.Phone != nil {
+		user.Phone = request.Phone
+	}
+
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.WithError(err).Error("failed to update user")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToResponse(user), nil
+}
+
+func (c *UserUseCaseImpl) ChangePassword(ctx context.Context, request *request.ChangePasswordRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return fiber.ErrNotFound
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.CurrentPassword)); err != nil {
+		c.Log.WithError(err).Warn("current password does not match")
+		return fiber.NewError(fiber.StatusBadRequest, "current password is incorrect")
+	}
+
+	if err := c.checkNewPassword(tx, user, request.NewPassword); err != nil {
+		return err
+	}
+
+	if err := c.setPassword(tx, user, request.NewPassword); err != nil {
+		return err
+	}
+
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.WithError(err).Error("failed to update user")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.recordPasswordHistory(tx, user); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *UserUseCaseImpl) ListSessions(ctx context.Context, request *request.ListSessionRequest) ([]*response.SessionResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	sessions, err := c.UserSessionRepository.FindActiveByUserID(tx, request.UserID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find user sessions")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*response.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = converter.SessionToResponse(&session)
+		responses[i].Current = session.ID == request.CurrentSessionID
+	}
+
+	return responses, nil
+}
+
+func (c *UserUseCaseImpl) RevokeSession(ctx context.Context, request *request.RevokeSessionRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	session := new(entity.UserSession)
+	if err := c.UserSessionRepository.FindById(tx, session, request.ID); err != nil {
+		c.Log.WithError(err).Error("failed to find user session")
+		return fiber.ErrNotFound
+	}
+
+	// Sessions of other users are reported as missing
+	if session.UserID != request.UserID || session.RevokedAt != nil {
+		c.Log.Warnf("Session %s does not belong to user %s", session.ID, request.UserID)
+		return fiber.ErrNotFound
+	}
+
+	if err := c.revokeSession(tx, session); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *UserUseCaseImpl) List(ctx context.Context, request *request.ListUserRequest) (*model.WebResponse[[]*response.UserResponse], error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	query := tx.Model(&entity.User{}).Where("deleted_at IS NULL")
+
+	if request.IsActive != nil {
+		query = query.Where("is_active = ?", *request.IsActive)
+	}
+	if request.IsVerified != nil {
+		query = query.Where("is_verified = ?", *request.IsVerified)
+	}
+	if request.Username != nil && *request.Username != "" {
+		query = query.Where("username ILIKE ?", "%"+*request.Username+"%")
+	}
+	if request.Email != nil && *request.Email != "" {
+		query = query.Where("email ILIKE ?", "%"+*request.Email+"%")
+	}
+
+	// Count total records
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.Log.WithError(err).Error("failed to count users")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Apply pagination
+	offset := (request.Page - 1) * request.Size
+	query = query.Order("created_at DESC").Offset(offset).Limit(request.Size)
+
+	var users []*entity.User
+	if err := query.Preload("UserRoles.Role").Find(&users).Error; err != nil {
+		c.Log.WithError(err).Error("failed to find users")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]*response.UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = converter.UserToResponse(user)
+	}
+
+	return &model.WebResponse[[]*response.UserResponse]{
+		Data: responses,
+		Meta: utils.CreatePaginationMeta(request.Page, request.Size, total),
+	}, nil
+}
+
+func (c *UserUseCaseImpl) Get(ctx context.Context, request *request.GetUserRequest) (*response.UserResponse, error) {
+	tx := c.DB.WithContext(ctx)
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil || user.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return nil, fiber.ErrNotFound
+	}
+
+	if err := c.loadRoles(tx, user); err != nil {
+		return nil, err
+	}
+
+	return converter.UserToResponse(user), nil
+}
+
+func (c *UserUseCaseImpl) AdminUpdate(ctx context.Context, request *request.AdminUpdateUserRequest) (*response.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil || user.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return nil, fiber.ErrNotFound
+	}
+
+	deactivated := false
+	if request.IsActive != nil {
+		deactivated = user.IsActive && !*request.IsActive
+		user.IsActive = *request.IsActive
+	}
+
+	if request.IsVerified != nil {
+		user.IsVerified = *request.IsVerified
+		if user.IsVerified && user.EmailVerifiedAt == nil {
+			verifiedAt := time.Now().UnixMilli()
+			user.EmailVerifiedAt = &verifiedAt
+		} else if !user.IsVerified {
+			user.EmailVerifiedAt = nil
+		}
+	}
+
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.WithError(err).Error("failed to update user")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// A deactivated user is signed out everywhere
+	if deactivated {
+		if err := c.revokeAllSessions(tx, user.ID, time.Now().UnixMilli()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.loadRoles(tx, user); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToResponse(user), nil
+}
+
+func (c *UserUseCaseImpl) Delete(ctx context.Context, request *request.DeleteUserRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil || user.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return fiber.ErrNotFound
+	}
+
+	// Users are soft deleted, the row stays for the records that reference it
+	now := time.Now().UnixMilli()
+	user.DeletedAt = &now
+	user.Token = nil
+	user.TokenExpiresAt = nil
+	user.RefreshToken = nil
+	user.RefreshExpiresAt = nil
+
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.WithError(err).Error("failed to delete user")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.revokeAllSessions(tx, user.ID, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (c *UserUseCaseImpl) Unlock(ctx context.Context, request *request.UnlockUserRequest) error {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.ID); err != nil || user.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return fiber.ErrNotFound
+	}
+
+	if err := c.LoginAttemptRepository.DeleteByKey(tx, accountLoginKey(user.Username)); err != nil {
+		c.Log.WithError(err).Error("failed to reset login attempts")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// AssignRoles gives roles to a user, a grantor can not hand out permissions they do not hold themselves
+func (c *UserUseCaseImpl) AssignRoles(ctx context.Context, request *request.AssignRolesRequest) (*response.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserID); err != nil || user.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return nil, fiber.ErrNotFound
+	}
+
+	for _, roleID := range request.RoleIDs {
+		role := new(entity.Role)
+		if err := c.RoleRepository.FindById(tx, role, roleID); err != nil || role.DeletedAt != nil {
+			c.Log.WithError(err).Errorf("failed to find role %s", roleID)
+			return nil, fiber.ErrNotFound
+		}
+
+		if err := c.checkGrantable(tx, role, request.GrantorPermissions); err != nil {
+			return nil, err
+		}
+
+		// Assigning a role the user already has is a no-op
+		if err := c.UserRoleRepository.FindByUserIDAndRoleID(tx, new(entity.UserRole), user.ID, role.ID); err == nil {
+			continue
+		}
+
+		userRole := &entity.UserRole{
+			UserID: user.ID,
+			RoleID: role.ID,
+		}
+
+		if err := c.UserRoleRepository.Create(tx, userRole); err != nil {
+			c.Log.WithError(err).Error("failed to create user role")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := c.loadRoles(tx, user); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToResponse(user), nil
+}
+
+// checkGrantable makes sure the grantor holds every permission of the role, including the inherited ones
+func (c *UserUseCaseImpl) checkGrantable(tx *gorm.DB, role *entity.Role, grantorPermissions service.PermissionSet) error {
+	permissions, err := c.PermissionRepository.FindAllByRoleID(tx, role.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find role permissions")
+		return fiber.ErrInternalServerError
+	}
+
+	for _, permission := range permissions {
+		if !grantorPermissions.Allows(permission.Name) {
+			return fiber.NewError(fiber.StatusForbidden, fmt.Sprintf("cannot assign role %s with permission %s that you do not hold", role.Name, permission.Name))
+		}
+	}
+	return nil
+}
+
+func (c *UserUseCaseImpl) RemoveRoles(ctx context.Context, request *request.RemoveRolesRequest) (*response.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		return nil, fiber.ErrBadRequest
+	}
+
+	user := new(entity.User)
+	if err := c.UserRepository.FindById(tx, user, request.UserID); err != nil || user.DeletedAt != nil {
+		c.Log.WithError(err).Error("failed to find user by id")
+		return nil, fiber.ErrNotFound
+	}
+
+	for _, roleID := range request.RoleIDs {
+		if err := c.UserRoleRepository.DeleteByUserIDAndRoleID(tx, user.ID, roleID); err != nil {
+			c.Log.WithError(err).Error("failed to delete user role")
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if err := c.loadRoles(tx, user); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToResponse(user), nil
+}
+
+func (c *UserUseCaseImpl) FindByRoleID(ctx context.Context, roleID string) ([]*response.UserResponse, error) {
+	tx := c.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if roleID == "" {
+		c.Log.Error("roleID is required")
+		return nil, fiber.ErrBadRequest
+	}
+
+	users, err := c.UserRepository.FindByRoleID(tx, roleID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find users by role id")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.Log.WithError(err).Error("failed to commit transaction")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.UserToResponseList(users), nil
+}
+
+// issueTokens generates a new access/refresh token pair for the session and records the refresh token in its family
+func (c *UserUseCaseImpl) issueTokens(tx *gorm.DB, user *entity.User, session *entity.UserSession) (*response.UserResponse, *entity.RefreshToken, error) {
+	token, err := c.JWTService.GenerateToken(user, session.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate JWT token")
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	refreshToken, err := c.JWTService.GenerateRefreshToken(user)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate refresh token")
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	// Update user with tokens
+	now := time.Now()
+	tokenExpiresAt := now.Add(c.JWTService.TokenExpiry()).Unix()
+	refreshExpiresAt := now.Add(c.JWTService.RefreshExpiry()).Unix()
+	user.Token = &token
+	user.TokenExpiresAt = &tokenExpiresAt
+	user.RefreshToken = &refreshToken
+	user.RefreshExpiresAt = &refreshExpiresAt
+
+	if err := c.UserRepository.Update(tx, user); err != nil {
+		c.Log.WithError(err).Error("failed to update user with tokens")
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	issued := &entity.RefreshToken{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		FamilyID:  session.FamilyID,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: refreshExpiresAt,
+	}
+
+	if err := c.RefreshTokenRepository.Create(tx, issued); err != nil {
+		c.Log.WithError(err).Error("failed to store refresh token")
+		return nil, nil, fiber.ErrInternalServerError
+	}
+
+	response := converter.UserToResponse(user)
+	response.Token = token
+	response.RefreshToken = refreshToken
+
+	return response, issued, nil
+}
+
+// revokeAccessToken adds the token to the denylist until it would have expired anyway
+func (c *UserUseCaseImpl) revokeAccessToken(tx *gorm.DB, userID string, tokenID string, expiresAt int64) error {
+	if err := c.RevokedTokenRepository.DeleteExpired(tx, time.Now().Unix()); err != nil {
+		c.Log.WithError(err).Error("failed to delete expired revoked tokens")
+		return fiber.ErrInternalServerError
+	}
+
+	if count, err := c.RevokedTokenRepository.CountById(tx, tokenID); err != nil {
+		c.Log.WithError(err).Error("failed to count revoked token by id")
+		return fiber.ErrInternalServerError
+	} else if count == 0 {
+		revokedToken := &entity.RevokedToken{
+			ID:        tokenID,
+			UserID:    userID,
+			ExpiresAt: expiresAt,
+		}
+
+		if err := c.RevokedTokenRepository.Create(tx, revokedToken); err != nil {
+			c.Log.WithError(err).Error("failed to revoke access token")
+			return fiber.ErrInternalServerError
+		}
+	}
+
+	c.revocations.SetRevoked(tokenID, time.Unix(expiresAt, 0))
+	return nil
+}
+
+// revokeSession ends the session and invalidates every refresh token of its family,
+// access tokens carrying the session are rejected by Authenticate from then on
+func (c *UserUseCaseImpl) revokeSession(tx *gorm.DB, session *entity.UserSession) error {
+	revokedAt := time.Now().UnixMilli()
+
+	if err := c.RefreshTokenRepository.RevokeByFamilyID(tx, session.FamilyID, revokedAt); err != nil {
+		c.Log.WithError(err).Error("failed to revoke refresh token family")
+		return fiber.ErrInternalServerError
+	}
+
+	session.RevokedAt = &revokedAt
+	if err := c.UserSessionRepository.Update(tx, session); err != nil {
+		c.Log.WithError(err).Error("failed to revoke user session")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// createVerificationToken replaces any pending verification token of the user and returns the raw token to be mailed
+func (c *UserUseCaseImpl) createVerificationToken(tx *gorm.DB, user *entity.User) (string, error) {
+	if err := c.VerificationRepository.DeleteByUserID(tx, user.ID); err != nil {
+		c.Log.WithError(err).Error("failed to delete verification tokens")
+		return "", fiber.ErrInternalServerError
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate verification token")
+		return "", fiber.ErrInternalServerError
+	}
+
+	verificationToken := &entity.EmailVerificationToken{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(verificationTokenTTL).Unix(),
+	}
+
+	if err := c.VerificationRepository.Create(tx, verificationToken); err != nil {
+		c.Log.WithError(err).Error("failed to create verification token")
+		return "", fiber.ErrInternalServerError
+	}
+
+	return token, nil
+}
+
+// sendVerificationEmail is best effort, a failed delivery can be retried through the resend endpoint
+func (c *UserUseCaseImpl) sendVerificationEmail(user *entity.User, token string) {
+	if c.UserMailer == nil {
+		return
+	}
+
+	if err := c.UserMailer.SendEmailVerification(user.Email, user.Username, token); err != nil {
+		c.Log.WithError(err).Errorf("failed to send verification email to user %s", user.ID)
+	}
+}
+
+// loadRoles attaches every role of the user so it ends up in the user response
+func (c *UserUseCaseImpl) loadRoles(tx *gorm.DB, user *entity.User) error {
+	userRoles, err := c.UserRoleRepository.FindAllByUserID(tx, user.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find user roles")
+		return fiber.ErrInternalServerError
+	}
+
+	user.UserRoles = userRoles
+	return nil
+}
+
+// checkNewPassword enforces the password policy and rejects the current password as well as the recent ones kept in the history
+func (c *UserUseCaseImpl) checkNewPassword(tx *gorm.DB, user *entity.User, password string) error {
+	if err := c.PasswordPolicy.Validate(password, user.Username, user.Email); err != nil {
+		c.Log.WithError(err).Warn("password does not satisfy the password policy")
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if c.PasswordPolicy.HistorySize <= 0 {
+		return nil
+	}
+
+	history, err := c.PasswordHistoryRepository.FindLatestByUserID(tx, user.ID, c.PasswordPolicy.HistorySize)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to find password history")
+		return fiber.ErrInternalServerError
+	}
+
+	hashes := []string{user.Password}
+	for _, entry := range history {
+		hashes = append(hashes, entry.PasswordHash)
+	}
+
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			c.Log.Warnf("User %s tried to reuse a recent password", user.ID)
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("password must not be one of the last %d passwords", c.PasswordPolicy.HistorySize))
+		}
+	}
+
+	return nil
+}
+
+// setPassword hashes the new password onto the user, the caller persists the user
+func (c *UserUseCaseImpl) setPassword(tx *gorm.DB, user *entity.User, password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to generate bcrypt hash")
+		return fiber.ErrInternalServerError
+	}
+
+	changedAt := time.Now().UnixMilli()
+	user.Password = string(hash)
+	user.PasswordChangedAt = &changedAt
+	return nil
+}
+
+// recordPasswordHistory stores the current password hash of the user and drops entries beyond the policy history size
+func (c *UserUseCaseImpl) recordPasswordHistory(tx *gorm.DB, user *entity.User) error {
+	if c.PasswordPolicy.HistorySize <= 0 {
+		return nil
+	}
+
+	entry := &entity.PasswordHistory{
+		ID:           uuid.NewString(),
+		UserID:       user.ID,
+		PasswordHash: user.Password,
+	}
+
+	if err := c.PasswordHistoryRepository.Create(tx, entry); err != nil {
+		c.Log.WithError(err).Error("failed to create password history")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.PasswordHistoryRepository.PruneByUserID(tx, user.ID, c.PasswordPolicy.HistorySize); err != nil {
+		c.Log.WithError(err).Error("failed to prune password history")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// revokeAllSessions signs the user out everywhere by revoking every session and refresh token
+func (c *UserUseCaseImpl) revokeAllSessions(tx *gorm.DB, userID string, revokedAt int64) error {
+	if err := c.UserSessionRepository.RevokeByUserID(tx, userID, revokedAt); err != nil {
+		c.Log.WithError(err).Error("failed to revoke user sessions")
+		return fiber.ErrInternalServerError
+	}
+
+	if err := c.RefreshTokenRepository.RevokeByUserID(tx, userID, revokedAt); err != nil {
+		c.Log.WithError(err).Error("failed to revoke refresh tokens")
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
 }
@@ -1,5 +1,7 @@
 package request
 
+import "mkp-boarding-test/pkg/service"
+
 type VerifyUserRequest struct {
 	Token string `validate:"required,max=100"`
 }
@@ -17,7 +19,6 @@ type UpdateUserRequest struct {
 	ID        string  `json:"-" validate:"required,max=100,uuid"`
 	Username  *string `json:"username" validate:"omitempty,max=100"`
 	Email     *string `json:"email" validate:"omitempty,email,max=255"`
-	Password  *string `json:"password" validate:"omitempty,min=8,max=100"`
 	FirstName *string `json:"first_name" validate:"omitempty,max=100"`
 	LastName  *string `json:"last_name" validate:"omitempty,max=100"`
 	Phone     *string `json:"phone" validate:"omitempty,max=20"`
@@ -26,12 +27,42 @@ type UpdateUserRequest struct {
 }
 
 type LoginUserRequest struct {
-	Username string `json:"username" validate:"required,max=100"`
-	Password string `json:"password" validate:"required,max=100"`
+	Username  string `json:"username" validate:"required,max=100"`
+	Password  string `json:"password" validate:"required,max=100"`
+	UserAgent string `json:"-" validate:"max=500"`
+	IPAddress string `json:"-" validate:"max=45"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,max=500"`
+	UserAgent    string `json:"-" validate:"max=500"`
+	IPAddress    string `json:"-" validate:"max=45"`
+}
+
+type AuthenticateUserRequest struct {
+	UserID    string `json:"-" validate:"required,max=100,uuid"`
+	TokenID   string `json:"-" validate:"required,max=100"`
+	SessionID string `json:"-" validate:"omitempty,max=100,uuid"`
+	ActorID   string `json:"-" validate:"omitempty,max=100,uuid"`
 }
 
 type LogoutUserRequest struct {
-	ID string `json:"-" validate:"required,max=100,uuid"`
+	ID             string `json:"-" validate:"required,max=100,uuid"`
+	SessionID      string `json:"-" validate:"omitempty,max=100,uuid"`
+	TokenID        string `json:"-" validate:"omitempty,max=100"`
+	TokenExpiresAt int64  `json:"-"`
+	// Set when the token impersonates the user, only that token is revoked then
+	ActorID string `json:"-" validate:"omitempty,max=100,uuid"`
+}
+
+type ListSessionRequest struct {
+	UserID           string `json:"-" validate:"required,max=100,uuid"`
+	CurrentSessionID string `json:"-" validate:"omitempty,max=100,uuid"`
+}
+
+type RevokeSessionRequest struct {
+	UserID string `json:"-" validate:"required,max=100,uuid"`
+	ID     string `json:"-" validate:"required,max=100,uuid"`
 }
 
 type GetUserRequest struct {
@@ -42,6 +73,16 @@ type DeleteUserRequest struct {
 	ID string `json:"-" validate:"required,max=100,uuid"`
 }
 
+type UnlockUserRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type AdminUpdateUserRequest struct {
+	ID         string `json:"-" validate:"required,max=100,uuid"`
+	IsActive   *bool  `json:"is_active"`
+	IsVerified *bool  `json:"is_verified"`
+}
+
 type ListUserRequest struct {
 	Page       int     `json:"page" validate:"min=1"`
 	Size       int     `json:"size" validate:"min=1,max=100"`
@@ -74,9 +115,49 @@ type ResetPasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=8,max=100"`
 }
 
+type LoginTwoFactorRequest struct {
+	TwoFactorToken string `json:"two_factor_token" validate:"required,max=255"`
+	Code           string `json:"code" validate:"required,max=20"`
+	UserAgent      string `json:"-" validate:"max=500"`
+	IPAddress      string `json:"-" validate:"max=45"`
+}
+
+type OIDCCallbackRequest struct {
+	Code      string `json:"code" validate:"required,max=2048"`
+	State     string `json:"state" validate:"required,max=255"`
+	UserAgent string `json:"-" validate:"max=500"`
+	IPAddress string `json:"-" validate:"max=45"`
+}
+
+type EnrollTwoFactorRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type ConfirmTwoFactorRequest struct {
+	ID   string `json:"-" validate:"required,max=100,uuid"`
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type DisableTwoFactorRequest struct {
+	ID       string `json:"-" validate:"required,max=100,uuid"`
+	Password string `json:"password" validate:"required,max=100"`
+	Code     string `json:"code" validate:"required,max=20"`
+}
+
+type RegenerateRecoveryCodesRequest struct {
+	ID   string `json:"-" validate:"required,max=100,uuid"`
+	Code string `json:"code" validate:"required,max=20"`
+}
+
+type ResetTwoFactorRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+// AssignRolesRequest assigns roles to a user, the grantor must hold every permission of the roles
 type AssignRolesRequest struct {
-	UserID  string   `json:"-" validate:"required,max=100,uuid"`
-	RoleIDs []string `json:"role_ids" validate:"required,dive,uuid"`
+	UserID             string                `json:"-" validate:"required,max=100,uuid"`
+	RoleIDs            []string              `json:"role_ids" validate:"required,dive,uuid"`
+	GrantorPermissions service.PermissionSet `json:"-"`
 }
 
 type RemoveRolesRequest struct {
@@ -87,3 +168,26 @@ type RemoveRolesRequest struct {
 type GetUsersByRoleIdRequest struct {
 	RoleID string `json:"-" validate:"required,max=100,uuid"`
 }
+
+type ImpersonateUserRequest struct {
+	ActorID   string `json:"-" validate:"required,max=100,uuid"`
+	UserID    string `json:"-" validate:"required,max=100,uuid"`
+	Reason    string `json:"reason" validate:"required,max=255"`
+	Method    string `json:"-" validate:"required,max=10"`
+	Path      string `json:"-" validate:"required,max=500"`
+	IPAddress string `json:"-" validate:"max=45"`
+}
+
+type StartImpersonatedWriteRequest struct {
+	ActorID   string `json:"-" validate:"required,max=100,uuid"`
+	UserID    string `json:"-" validate:"required,max=100,uuid"`
+	TokenID   string `json:"-" validate:"required,max=100"`
+	Method    string `json:"-" validate:"required,max=10"`
+	Path      string `json:"-" validate:"required,max=500"`
+	IPAddress string `json:"-" validate:"max=45"`
+}
+
+type FinishImpersonatedWriteRequest struct {
+	ID         string `json:"-" validate:"required,max=100,uuid"`
+	StatusCode int    `json:"-" validate:"required"`
+}
@@ -13,8 +13,54 @@ type UserResponse struct {
 	LastLoginAt       *int64  `json:"last_login_at"`
 	EmailVerifiedAt   *int64  `json:"email_verified_at"`
 	PasswordChangedAt *int64  `json:"password_changed_at"`
+	TwoFactorEnabled  bool    `json:"two_factor_enabled"`
 	Token             string  `json:"token,omitempty"`
 	RefreshToken      string  `json:"refresh_token,omitempty"`
 	CreatedAt         int64   `json:"created_at"`
 	UpdatedAt         int64   `json:"updated_at"`
+
+	// Set by login when the password was accepted but a second factor still has to be submitted to /login/2fa
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	TwoFactorToken    string `json:"two_factor_token,omitempty"`
+	// Set by login when the account must enroll in two-factor authentication before it can use its permissions
+	TwoFactorEnrollmentRequired bool `json:"two_factor_enrollment_required,omitempty"`
+	// Set on the current user while an admin impersonates the user
+	ImpersonatedBy *ImpersonatorResponse `json:"impersonated_by,omitempty"`
+
+	Roles []*RoleResponse `json:"roles,omitempty"`
+}
+
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+type TwoFactorEnrollmentResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type SessionResponse struct {
+	ID         string  `json:"id"`
+	UserAgent  *string `json:"user_agent"`
+	IPAddress  *string `json:"ip_address"`
+	Current    bool    `json:"current"`
+	LastSeenAt int64   `json:"last_seen_at"`
+	CreatedAt  int64   `json:"created_at"`
+}
+
+type ImpersonatorResponse struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+type ImpersonationResponse struct {
+	// Token is a short-lived access token of the user, it carries the admin in the act claim and can not be refreshed
+	Token     string        `json:"token"`
+	ExpiresAt int64         `json:"expires_at"`
+	ActorID   string        `json:"actor_id"`
+	User      *UserResponse `json:"user"`
 }
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"mkp-boarding-test/internal/delivery/http/middleware"
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type AuthzController struct {
+	UseCase usecase.AuthzUseCase
+	Log     *logrus.Logger
+}
+
+func NewAuthzController(useCase usecase.AuthzUseCase, log *logrus.Logger) *AuthzController {
+	return &AuthzController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+// CurrentPermissions godoc
+// @Summary Get current user permissions
+// @Description Resolve what the current user may do: roles, granted permissions including inherited and wildcard grants, the expanded permission names, the harbor scope and the operator the user's ships are limited to
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.SwaggerWebResponse "Permissions retrieved successfully"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/_current/permissions [get]
+func (c *AuthzController) CurrentPermissions(ctx *fiber.Ctx) error {
+	request := &model.CurrentPermissionsRequest{
+		UserID: middleware.GetUser(ctx).ID,
+	}
+
+	response, err := c.UseCase.CurrentPermissions(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to resolve current user permissions")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve permissions", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Permissions retrieved successfully", response)
+}
+
+// Check godoc
+// @Summary Check authorization
+// @Description Explain whether a user may perform an action, optionally on one ship, harbor, boarding, deficiency, port call or berth: which roles grant it, whether the two-factor policy blocks it and whether the record is in the user's scope
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.AuthzCheckRequest true "Authorization check request"
+// @Success 200 {object} model.SwaggerWebResponse "Authorization checked successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "User not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/authz/check [post]
+func (c *AuthzController) Check(ctx *fiber.Ctx) error {
+	request := new(model.AuthzCheckRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	response, err := c.UseCase.Check(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to check authorization")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to check authorization", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Authorization checked successfully", response)
+}
@@ -0,0 +1,398 @@
+package handler
+
+import (
+	"mkp-boarding-test/internal/delivery/http/middleware"
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type BerthController struct {
+	UseCase usecase.BerthUseCase
+	Log     *logrus.Logger
+}
+
+func NewBerthController(useCase usecase.BerthUseCase, log *logrus.Logger) *BerthController {
+	return &BerthController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+// Create godoc
+// @Summary Register berth
+// @Description Register a berth of a harbor with its length and depth in meters and the ship types it takes. A berth without ship types takes any ship
+// @Tags Berths
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param harborId path string true "Harbor ID"
+// @Param request body model.CreateBerthRequest true "Create berth request"
+// @Success 200 {object} model.SwaggerWebResponse "Berth created successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Harbor not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Berth code already exists in the harbor"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/harbors/{harborId}/berths [post]
+func (c *BerthController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateBerthRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.HarborID = ctx.Params("harborId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Create(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to create berth")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to create berth", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Berth created successfully", response)
+}
+
+// ListByHarbor godoc
+// @Summary List harbor berths
+// @Description Get all berths of a harbor ordered by code
+// @Tags Berths
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param harborId path string true "Harbor ID"
+// @Success 200 {object} model.SwaggerWebResponse "List of berths"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Harbor not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/harbors/{harborId}/berths [get]
+func (c *BerthController) ListByHarbor(ctx *fiber.Ctx) error {
+	request := &model.ListHarborBerthRequest{
+		HarborID: ctx.Params("harborId"),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	responses, err := c.UseCase.ListByHarbor(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to list harbor berths")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve berths", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Berths retrieved successfully", responses)
+}
+
+// Get godoc
+// @Summary Get berth
+// @Description Get a berth with the ship types it takes
+// @Tags Berths
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param berthId path string true "Berth ID"
+// @Success 200 {object} model.SwaggerWebResponse "Berth details"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Berth not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/berths/{berthId} [get]
+func (c *BerthController) Get(ctx *fiber.Ctx) error {
+	request := &model.GetBerthRequest{
+		ID: ctx.Params("berthId"),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get berth")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve berth", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Berth retrieved successfully", response)
+}
+
+// Update godoc
+// @Summary Update berth
+// @Description Update a berth. Ship types replace the ones of the berth when given, an empty list lets the berth take any ship
+// @Tags Berths
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param berthId path string true "Berth ID"
+// @Param request body model.UpdateBerthRequest true "Update berth request"
+// @Success 200 {object} model.SwaggerWebResponse "Berth updated successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Berth not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Berth code already exists in the harbor"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/berths/{berthId} [put]
+func (c *BerthController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdateBerthRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("berthId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Update(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to update berth")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to update berth", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Berth updated successfully", response)
+}
+
+// Delete godoc
+// @Summary Delete berth
+// @Description Delete a berth that was never allocated, allocated berths are deactivated instead to keep their history
+// @Tags Berths
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param berthId path string true "Berth ID"
+// @Success 200 {object} model.SwaggerWebResponse "Berth deleted successfully"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Berth not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Berth has allocations"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/berths/{berthId} [delete]
+func (c *BerthController) Delete(ctx *fiber.Ctx) error {
+	request := &model.DeleteBerthRequest{
+		ID: ctx.Params("berthId"),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	if err := c.UseCase.Delete(ctx.UserContext(), request, auth.ID); err != nil {
+		c.Log.WithError(err).Error("failed to delete berth")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to delete berth", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Berth deleted successfully", true)
+}
+
+// ListAllocationsByBerth godoc
+// @Summary List berth allocations
+// @Description Get the allocations of a berth with their port calls in the order of their windows, optionally only those overlapping from..to (Unix milliseconds)
+// @Tags Berths
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param berthId path string true "Berth ID"
+// @Param from query int false "Only allocations ending after this time"
+// @Param to query int false "Only allocations starting before this time"
+// @Success 200 {object} model.SwaggerWebResponse "List of berth allocations"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Berth not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/berths/{berthId}/allocations [get]
+func (c *BerthController) ListAllocationsByBerth(ctx *fiber.Ctx) error {
+	request := &model.ListBerthAllocationRequest{
+		BerthID: ctx.Params("berthId"),
+		From:    int64(ctx.QueryInt("from", 0)),
+		To:      int64(ctx.QueryInt("to", 0)),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	responses, err := c.UseCase.ListAllocationsByBerth(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to list berth allocations")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve berth allocations", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Berth allocations retrieved successfully", responses)
+}
+
+// Allocate godoc
+// @Summary Allocate berth
+// @Description Allocate a berth of the harbor to a port call for a time window. The window starts at the ATA or ETA and ends at the ETD unless given. The berth must be active, take the length, draft and type of the ship and be free during the window
+// @Tags Berths
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param portCallId path string true "Port call ID"
+// @Param request body model.AllocateBerthRequest true "Allocate berth request"
+// @Success 200 {object} model.SwaggerWebResponse "Berth allocated successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Port call or berth not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Berth does not take the ship or is already allocated"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/port-calls/{portCallId}/berth-allocations [post]
+func (c *BerthController) Allocate(ctx *fiber.Ctx) error {
+	request := new(model.AllocateBerthRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.PortCallID = ctx.Params("portCallId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Allocate(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to allocate berth")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to allocate berth", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Berth allocated successfully", response)
+}
+
+// ListAllocationsByPortCall godoc
+// @Summary List port call berth allocations
+// @Description Get the berths allocated to a port call in the order of their windows
+// @Tags Berths
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param portCallId path string true "Port call ID"
+// @Success 200 {object} model.SwaggerWebResponse "List of berth allocations"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Port call not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/port-calls/{portCallId}/berth-allocations [get]
+func (c *BerthController) ListAllocationsByPortCall(ctx *fiber.Ctx) error {
+	request := &model.ListPortCallBerthAllocationRequest{
+		PortCallID: ctx.Params("portCallId"),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	responses, err := c.UseCase.ListAllocationsByPortCall(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to list port call berth allocations")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve berth allocations", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Berth allocations retrieved successfully", responses)
+}
+
+// Suggest godoc
+// @Summary Suggest berths
+// @Description Get the active berths of the harbor that take the ship of a port call and are free during the window, the tightest fit first. The window defaults to the ATA or ETA until the ETD (Unix milliseconds)
+// @Tags Berths
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param portCallId path string true "Port call ID"
+// @Param starts_at query int false "Start of the window"
+// @Param ends_at query int false "End of the window"
+// @Success 200 {object} model.SwaggerWebResponse "List of free berths"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Port call not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Port call is closed"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/port-calls/{portCallId}/berth-suggestions [get]
+func (c *BerthController) Suggest(ctx *fiber.Ctx) error {
+	request := &model.SuggestBerthRequest{
+		PortCallID: ctx.Params("portCallId"),
+	}
+	if startsAt := int64(ctx.QueryInt("starts_at", 0)); startsAt > 0 {
+		request.StartsAt = &startsAt
+	}
+	if endsAt := int64(ctx.QueryInt("ends_at", 0)); endsAt > 0 {
+		request.EndsAt = &endsAt
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	responses, err := c.UseCase.Suggest(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to suggest berths")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to suggest berths", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Berths suggested successfully", responses)
+}
+
+// Release godoc
+// @Summary Release berth allocation
+// @Description Release a berth allocation so that the berth is free again during its window
+// @Tags Berths
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param allocationId path string true "Berth allocation ID"
+// @Success 200 {object} model.SwaggerWebResponse "Berth allocation released successfully"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Berth allocation not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/berth-allocations/{allocationId} [delete]
+func (c *BerthController) Release(ctx *fiber.Ctx) error {
+	request := &model.ReleaseBerthAllocationRequest{
+		ID: ctx.Params("allocationId"),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	if err := c.UseCase.Release(ctx.UserContext(), request, auth.ID); err != nil {
+		c.Log.WithError(err).Error("failed to release berth allocation")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to release berth allocation", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Berth allocation released successfully", true)
+}
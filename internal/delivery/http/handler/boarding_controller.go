@@ -0,0 +1,417 @@
+package handler
+
+import (
+	"mkp-boarding-test/internal/delivery/http/middleware"
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type BoardingController struct {
+	UseCase usecase.BoardingUseCase
+	Log     *logrus.Logger
+}
+
+func NewBoardingController(useCase usecase.BoardingUseCase, log *logrus.Logger) *BoardingController {
+	return &BoardingController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+// Create godoc
+// @Summary Plan a boarding
+// @Description Plan the boarding of a ship in a harbor, the inspector defaults to the current user. Ship and harbor must be in the scope of the current user
+// @Tags Boardings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.CreateBoardingRequest true "Create boarding request"
+// @Success 200 {object} model.SwaggerWebResponse "Boarding created successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Ship, harbor or inspector not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/boardings [post]
+func (c *BoardingController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateBoardingRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Create(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to create boarding")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to create boarding", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Boarding created successfully", response)
+}
+
+// List godoc
+// @Summary List boardings
+// @Description Get list of boardings in the harbors and of the ships in the scope of the current user, latest planned first
+// @Tags Boardings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param ship_id query string false "Filter by ship ID"
+// @Param harbor_id query string false "Filter by harbor ID"
+// @Param inspector_id query string false "Filter by inspector ID"
+// @Param status query string false "Filter by status (planned, in_progress, completed, aborted)"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {object} model.SwaggerPageResponse "List of boardings"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/boardings [get]
+func (c *BoardingController) List(ctx *fiber.Ctx) error {
+	shipID := ctx.Query("ship_id", "")
+	harborID := ctx.Query("harbor_id", "")
+	inspectorID := ctx.Query("inspector_id", "")
+	status := ctx.Query("status", "")
+
+	request := &model.ListBoardingRequest{
+		ShipID:      &shipID,
+		HarborID:    &harborID,
+		InspectorID: &inspectorID,
+		Status:      &status,
+		Page:        ctx.QueryInt("page", 1),
+		Size:        ctx.QueryInt("size", 10),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	responses, err := c.UseCase.List(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to list boardings")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve boardings", err.Error())
+	}
+
+	response := utils.SuccessResponseWithMeta("Boardings retrieved successfully", responses.Data, responses.Meta)
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+// Get godoc
+// @Summary Get boarding by ID
+// @Description Get boarding details with its ship and harbor by boarding ID
+// @Tags Boardings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param boardingId path string true "Boarding ID"
+// @Success 200 {object} model.SwaggerWebResponse "Boarding details"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Boarding not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/boardings/{boardingId} [get]
+func (c *BoardingController) Get(ctx *fiber.Ctx) error {
+	request := &model.GetBoardingRequest{
+		ID: ctx.Params("boardingId"),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get boarding")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Boarding not found", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Boarding retrieved successfully", response)
+}
+
+// Update godoc
+// @Summary Update boarding
+// @Description Change the harbor, inspector, planned time or remarks of a planned boarding
+// @Tags Boardings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param boardingId path string true "Boarding ID"
+// @Param request body model.UpdateBoardingRequest true "Update boarding request"
+// @Success 200 {object} model.SwaggerWebResponse "Boarding updated successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Boarding, harbor or inspector not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Boarding is no longer planned"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/boardings/{boardingId} [put]
+func (c *BoardingController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdateBoardingRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("boardingId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Update(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to update boarding")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to update boarding", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Boarding updated successfully", response)
+}
+
+// Delete godoc
+// @Summary Delete boarding
+// @Description Delete a planned boarding, boardings that started are kept as inspection records and can only be aborted
+// @Tags Boardings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param boardingId path string true "Boarding ID"
+// @Success 200 {object} model.SwaggerWebResponse "Boarding deleted successfully"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Boarding not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Boarding is no longer planned"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/boardings/{boardingId} [delete]
+func (c *BoardingController) Delete(ctx *fiber.Ctx) error {
+	request := &model.DeleteBoardingRequest{
+		ID: ctx.Params("boardingId"),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	if err := c.UseCase.Delete(ctx.UserContext(), request, auth.ID); err != nil {
+		c.Log.WithError(err).Error("failed to delete boarding")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to delete boarding", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Boarding deleted successfully", true)
+}
+
+// Start godoc
+// @Summary Start boarding
+// @Description Record that the inspectors went on board of the ship, optionally with the position. The latest active checklist version for the ship type is chosen for the boarding. A ship can only be boarded by one team at a time
+// @Tags Boardings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param boardingId path string true "Boarding ID"
+// @Param request body model.StartBoardingRequest true "Start boarding request"
+// @Success 200 {object} model.SwaggerWebResponse "Boarding started successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Boarding not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Boarding is not planned or the ship is already being boarded"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/boardings/{boardingId}/start [post]
+func (c *BoardingController) Start(ctx *fiber.Ctx) error {
+	// The position is optional, a start without a body is accepted
+	request := new(model.StartBoardingRequest)
+	if err := ctx.BodyParser(request); err != nil && err != fiber.ErrUnprocessableEntity {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("boardingId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Start(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to start boarding")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to start boarding", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Boarding started successfully", response)
+}
+
+// Complete godoc
+// @Summary Complete boarding
+// @Description Close a boarding in progress with its outcome (satisfactory, deficiencies or detained) and remarks. Every required item of its checklist must be answered
+// @Tags Boardings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param boardingId path string true "Boarding ID"
+// @Param request body model.CompleteBoardingRequest true "Complete boarding request"
+// @Success 200 {object} model.SwaggerWebResponse "Boarding completed successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Boarding not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Boarding is not in progress or required checklist items are not answered"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/boardings/{boardingId}/complete [post]
+func (c *BoardingController) Complete(ctx *fiber.Ctx) error {
+	request := new(model.CompleteBoardingRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("boardingId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Complete(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to complete boarding")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to complete boarding", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Boarding completed successfully", response)
+}
+
+// Abort godoc
+// @Summary Abort boarding
+// @Description End a planned boarding or a boarding in progress without an outcome, the remarks explain why
+// @Tags Boardings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param boardingId path string true "Boarding ID"
+// @Param request body model.AbortBoardingRequest true "Abort boarding request"
+// @Success 200 {object} model.SwaggerWebResponse "Boarding aborted successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Boarding not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Boarding is already completed or aborted"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/boardings/{boardingId}/abort [post]
+func (c *BoardingController) Abort(ctx *fiber.Ctx) error {
+	request := new(model.AbortBoardingRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("boardingId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Abort(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to abort boarding")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to abort boarding", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Boarding aborted successfully", response)
+}
+
+// GetChecklist godoc
+// @Summary Get boarding checklist
+// @Description Get the checklist version chosen for the boarding when it started, with the answers given so far and the number of required items still unanswered
+// @Tags Boardings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param boardingId path string true "Boarding ID"
+// @Success 200 {object} model.SwaggerWebResponse "Boarding checklist"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Boarding not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/boardings/{boardingId}/checklist [get]
+func (c *BoardingController) GetChecklist(ctx *fiber.Ctx) error {
+	request := &model.GetBoardingChecklistRequest{
+		ID: ctx.Params("boardingId"),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.GetChecklist(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get boarding checklist")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve boarding checklist", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Boarding checklist retrieved successfully", response)
+}
+
+// AnswerChecklist godoc
+// @Summary Answer boarding checklist
+// @Description Answer items of the checklist of a boarding in progress, an item answered again is overwritten. Answers are frozen once the boarding is completed or aborted
+// @Tags Boardings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param boardingId path string true "Boarding ID"
+// @Param request body model.AnswerBoardingChecklistRequest true "Answer boarding checklist request"
+// @Success 200 {object} model.SwaggerWebResponse "Boarding checklist answered successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Boarding not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Boarding is not in progress or has no checklist"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/boardings/{boardingId}/checklist [put]
+func (c *BoardingController) AnswerChecklist(ctx *fiber.Ctx) error {
+	request := new(model.AnswerBoardingChecklistRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("boardingId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.AnswerChecklist(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to answer boarding checklist")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to answer boarding checklist", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Boarding checklist answered successfully", response)
+}
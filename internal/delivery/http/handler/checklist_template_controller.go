@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ChecklistTemplateController struct {
+	UseCase usecase.ChecklistTemplateUseCase
+	Log     *logrus.Logger
+}
+
+func NewChecklistTemplateController(useCase usecase.ChecklistTemplateUseCase, log *logrus.Logger) *ChecklistTemplateController {
+	return &ChecklistTemplateController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+// Create godoc
+// @Summary Create checklist template version
+// @Description Publish the next version of the inspection checklist for a ship type with its sections and items. Published versions never change, a changed checklist is created as the next version
+// @Tags Checklist Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.CreateChecklistTemplateRequest true "Create checklist template request"
+// @Success 200 {object} model.SwaggerWebResponse "Checklist template created successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/checklist-templates [post]
+func (c *ChecklistTemplateController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateChecklistTemplateRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	response, err := c.UseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to create checklist template")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to create checklist template", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Checklist template created successfully", response)
+}
+
+// List godoc
+// @Summary List checklist templates
+// @Description Get list of checklist template versions without their items, ordered by ship type and latest version first
+// @Tags Checklist Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param ship_type query string false "Filter by ship type"
+// @Param is_active query bool false "Filter by active status" default(true)
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {object} model.SwaggerPageResponse "List of checklist templates"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/checklist-templates [get]
+func (c *ChecklistTemplateController) List(ctx *fiber.Ctx) error {
+	shipType := ctx.Query("ship_type", "")
+	isActive := ctx.QueryBool("is_active", true)
+
+	request := &model.ListChecklistTemplateRequest{
+		ShipType: &shipType,
+		IsActive: &isActive,
+		Page:     ctx.QueryInt("page", 1),
+		Size:     ctx.QueryInt("size", 10),
+	}
+
+	responses, err := c.UseCase.List(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to list checklist templates")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve checklist templates", err.Error())
+	}
+
+	response := utils.SuccessResponseWithMeta("Checklist templates retrieved successfully", responses.Data, responses.Meta)
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+// Get godoc
+// @Summary Get checklist template by ID
+// @Description Get a checklist template version with its sections and items
+// @Tags Checklist Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param templateId path string true "Checklist template ID"
+// @Success 200 {object} model.SwaggerWebResponse "Checklist template details"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Checklist template not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/checklist-templates/{templateId} [get]
+func (c *ChecklistTemplateController) Get(ctx *fiber.Ctx) error {
+	request := &model.GetChecklistTemplateRequest{
+		ID: ctx.Params("templateId"),
+	}
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get checklist template")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Checklist template not found", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Checklist template retrieved successfully", response)
+}
+
+// Update godoc
+// @Summary Update checklist template
+// @Description Rename, activate or deactivate a checklist template version. Inactive versions are no longer chosen for new boardings
+// @Tags Checklist Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param templateId path string true "Checklist template ID"
+// @Param request body model.UpdateChecklistTemplateRequest true "Update checklist template request"
+// @Success 200 {object} model.SwaggerWebResponse "Checklist template updated successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Checklist template not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/checklist-templates/{templateId} [put]
+func (c *ChecklistTemplateController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdateChecklistTemplateRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("templateId")
+
+	response, err := c.UseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to update checklist template")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to update checklist template", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Checklist template updated successfully", response)
+}
+
+// Delete godoc
+// @Summary Delete checklist template
+// @Description Delete a checklist template version no boarding used, versions in use can only be deactivated
+// @Tags Checklist Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param templateId path string true "Checklist template ID"
+// @Success 200 {object} model.SwaggerWebResponse "Checklist template deleted successfully"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Checklist template not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Checklist template is used by boardings"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/checklist-templates/{templateId} [delete]
+func (c *ChecklistTemplateController) Delete(ctx *fiber.Ctx) error {
+	request := &model.DeleteChecklistTemplateRequest{
+		ID: ctx.Params("templateId"),
+	}
+
+	if err := c.UseCase.Delete(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("failed to delete checklist template")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to delete checklist template", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Checklist template deleted successfully", true)
+}
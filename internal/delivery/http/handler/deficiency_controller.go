@@ -0,0 +1,341 @@
+package handler
+
+import (
+	"mkp-boarding-test/internal/delivery/http/middleware"
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type DeficiencyController struct {
+	UseCase usecase.DeficiencyUseCase
+	Log     *logrus.Logger
+}
+
+func NewDeficiencyController(useCase usecase.DeficiencyUseCase, log *logrus.Logger) *DeficiencyController {
+	return &DeficiencyController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+// Create godoc
+// @Summary Record deficiency
+// @Description Record a deficiency found during a boarding in progress or completed, with its code, severity, corrective action deadline and whether it is detainable
+// @Tags Deficiencies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param boardingId path string true "Boarding ID"
+// @Param request body model.CreateDeficiencyRequest true "Create deficiency request"
+// @Success 200 {object} model.SwaggerWebResponse "Deficiency created successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Boarding not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Boarding is planned or aborted"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/boardings/{boardingId}/deficiencies [post]
+func (c *DeficiencyController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateDeficiencyRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.BoardingID = ctx.Params("boardingId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Create(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to create deficiency")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to create deficiency", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Deficiency created successfully", response)
+}
+
+// ListByBoarding godoc
+// @Summary List boarding deficiencies
+// @Description Get all deficiencies found during a boarding in the order they were recorded
+// @Tags Deficiencies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param boardingId path string true "Boarding ID"
+// @Success 200 {object} model.SwaggerWebResponse "List of deficiencies"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Boarding not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/boardings/{boardingId}/deficiencies [get]
+func (c *DeficiencyController) ListByBoarding(ctx *fiber.Ctx) error {
+	request := &model.ListBoardingDeficiencyRequest{
+		BoardingID: ctx.Params("boardingId"),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	responses, err := c.UseCase.ListByBoarding(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to list boarding deficiencies")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve deficiencies", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Deficiencies retrieved successfully", responses)
+}
+
+// ListByShip godoc
+// @Summary List ship deficiencies
+// @Description Get the deficiencies of a ship with the nearest deadline first. Without a status only the deficiencies that are not verified yet are listed
+// @Tags Deficiencies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param shipId path string true "Ship ID"
+// @Param status query string false "Filter by status (open, rectified, verified, overdue)"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {object} model.SwaggerPageResponse "List of deficiencies"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Ship not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/ships/{shipId}/deficiencies [get]
+func (c *DeficiencyController) ListByShip(ctx *fiber.Ctx) error {
+	status := ctx.Query("status", "")
+
+	request := &model.ListShipDeficiencyRequest{
+		ShipID: ctx.Params("shipId"),
+		Status: &status,
+		Page:   ctx.QueryInt("page", 1),
+		Size:   ctx.QueryInt("size", 10),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	responses, err := c.UseCase.ListByShip(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to list ship deficiencies")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve deficiencies", err.Error())
+	}
+
+	response := utils.SuccessResponseWithMeta("Deficiencies retrieved successfully", responses.Data, responses.Meta)
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+// Get godoc
+// @Summary Get deficiency by ID
+// @Description Get deficiency details with its corrective action follow-up
+// @Tags Deficiencies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param deficiencyId path string true "Deficiency ID"
+// @Success 200 {object} model.SwaggerWebResponse "Deficiency details"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Deficiency not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/deficiencies/{deficiencyId} [get]
+func (c *DeficiencyController) Get(ctx *fiber.Ctx) error {
+	request := &model.GetDeficiencyRequest{
+		ID: ctx.Params("deficiencyId"),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get deficiency")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Deficiency not found", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Deficiency retrieved successfully", response)
+}
+
+// Update godoc
+// @Summary Update deficiency
+// @Description Correct the code, severity, description, deadline or detainable flag of an open or overdue deficiency
+// @Tags Deficiencies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param deficiencyId path string true "Deficiency ID"
+// @Param request body model.UpdateDeficiencyRequest true "Update deficiency request"
+// @Success 200 {object} model.SwaggerWebResponse "Deficiency updated successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Deficiency not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Deficiency is rectified or verified"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/deficiencies/{deficiencyId} [put]
+func (c *DeficiencyController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdateDeficiencyRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("deficiencyId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Update(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to update deficiency")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to update deficiency", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Deficiency updated successfully", response)
+}
+
+// Rectify godoc
+// @Summary Rectify deficiency
+// @Description Report the corrective action of an open or overdue deficiency of a ship, it stays open until an inspector verifies it
+// @Tags Deficiencies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param deficiencyId path string true "Deficiency ID"
+// @Param request body model.RectifyDeficiencyRequest true "Rectify deficiency request"
+// @Success 200 {object} model.SwaggerWebResponse "Deficiency rectified successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Deficiency not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Deficiency is already rectified or verified"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/deficiencies/{deficiencyId}/rectify [post]
+func (c *DeficiencyController) Rectify(ctx *fiber.Ctx) error {
+	request := new(model.RectifyDeficiencyRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("deficiencyId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Rectify(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to rectify deficiency")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to rectify deficiency", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Deficiency rectified successfully", response)
+}
+
+// Verify godoc
+// @Summary Verify deficiency
+// @Description Confirm the corrective action of a rectified deficiency, which closes it
+// @Tags Deficiencies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param deficiencyId path string true "Deficiency ID"
+// @Param request body model.VerifyDeficiencyRequest true "Verify deficiency request"
+// @Success 200 {object} model.SwaggerWebResponse "Deficiency verified successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Deficiency not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Deficiency is not rectified"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/deficiencies/{deficiencyId}/verify [post]
+func (c *DeficiencyController) Verify(ctx *fiber.Ctx) error {
+	// The notes are optional, a verification without a body is accepted
+	request := new(model.VerifyDeficiencyRequest)
+	if err := ctx.BodyParser(request); err != nil && err != fiber.ErrUnprocessableEntity {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("deficiencyId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Verify(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to verify deficiency")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to verify deficiency", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Deficiency verified successfully", response)
+}
+
+// Reopen godoc
+// @Summary Reopen deficiency
+// @Description Reject the corrective action of a rectified deficiency, the notes explain why. It becomes open again, or overdue when its deadline passed
+// @Tags Deficiencies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param deficiencyId path string true "Deficiency ID"
+// @Param request body model.ReopenDeficiencyRequest true "Reopen deficiency request"
+// @Success 200 {object} model.SwaggerWebResponse "Deficiency reopened successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Deficiency not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Deficiency is not rectified"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/deficiencies/{deficiencyId}/reopen [post]
+func (c *DeficiencyController) Reopen(ctx *fiber.Ctx) error {
+	request := new(model.ReopenDeficiencyRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("deficiencyId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Reopen(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to reopen deficiency")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to reopen deficiency", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Deficiency reopened successfully", response)
+}
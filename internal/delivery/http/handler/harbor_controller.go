@@ -24,7 +24,7 @@ func NewHarborController(useCase usecase.HarborUseCase, log *logrus.Logger) *Har
 
 // Create godoc
 // @Summary Create a new harbor
-// @Description Create a new harbor with detailed information
+// @Description Create a new harbor with detailed information, the harbor is granted to every role of the creator
 // @Tags Harbors
 // @Accept json
 // @Produce json
@@ -33,6 +33,7 @@ func NewHarborController(useCase usecase.HarborUseCase, log *logrus.Logger) *Har
 // @Success 200 {object} model.SwaggerWebResponse "Harbor created successfully"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/harbors [post]
 func (c *HarborController) Create(ctx *fiber.Ctx) error {
@@ -42,10 +43,16 @@ func (c *HarborController) Create(ctx *fiber.Ctx) error {
 		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	response, err := c.UseCase.Create(ctx.UserContext(), request)
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Create(ctx.UserContext(), request, auth.ID)
 	if err != nil {
 		c.Log.WithError(err).Error("failed to create harbor")
-		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to create harbor", err.Error())
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to create harbor", err.Error())
 	}
 
 	return utils.SendSuccessResponse(ctx, "Harbor created successfully", response)
@@ -53,7 +60,7 @@ func (c *HarborController) Create(ctx *fiber.Ctx) error {
 
 // List godoc
 // @Summary List harbors
-// @Description Get list of harbors with optional filtering
+// @Description Get list of harbors granted to any of the caller's roles with optional filtering
 // @Tags Harbors
 // @Accept json
 // @Produce json
@@ -67,6 +74,7 @@ func (c *HarborController) Create(ctx *fiber.Ctx) error {
 // @Param size query int false "Page size" default(10)
 // @Success 200 {object} model.SwaggerPageResponse "List of harbors"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/harbors [get]
 func (c *HarborController) List(ctx *fiber.Ctx) error {
@@ -108,7 +116,8 @@ func (c *HarborController) List(ctx *fiber.Ctx) error {
 // @Param harborId path string true "Harbor ID"
 // @Success 200 {object} model.SwaggerWebResponse "Harbor details"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
-// @Failure 404 {object} model.SwaggerWebResponse "Harbor not found"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Harbor not found or not granted to the caller's roles"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/harbors/{harborId} [get]
 func (c *HarborController) Get(ctx *fiber.Ctx) error {
@@ -118,7 +127,9 @@ func (c *HarborController) Get(ctx *fiber.Ctx) error {
 		ID: harborId,
 	}
 
-	response, err := c.UseCase.Get(ctx.UserContext(), request)
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request, auth.ID)
 	if err != nil {
 		c.Log.WithError(err).Error("failed to get harbor")
 		return utils.SendErrorResponse(ctx, fiber.StatusNotFound, "Harbor not found", err.Error())
@@ -139,7 +150,8 @@ func (c *HarborController) Get(ctx *fiber.Ctx) error {
 // @Success 200 {object} model.SwaggerWebResponse "Harbor updated successfully"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
-// @Failure 404 {object} model.SwaggerWebResponse "Harbor not found"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Harbor not found or not granted to the caller's roles"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/harbors/{harborId} [put]
 func (c *HarborController) Update(ctx *fiber.Ctx) error {
@@ -151,10 +163,16 @@ func (c *HarborController) Update(ctx *fiber.Ctx) error {
 
 	request.ID = ctx.Params("harborId")
 
-	response, err := c.UseCase.Update(ctx.UserContext(), request)
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Update(ctx.UserContext(), request, auth.ID)
 	if err != nil {
 		c.Log.WithError(err).Error("failed to update harbor")
-		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to update harbor", err.Error())
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to update harbor", err.Error())
 	}
 
 	return utils.SendSuccessResponse(ctx, "Harbor updated successfully", response)
@@ -170,7 +188,9 @@ func (c *HarborController) Update(ctx *fiber.Ctx) error {
 // @Param harborId path string true "Harbor ID"
 // @Success 200 {object} model.SwaggerWebResponse "Harbor deleted successfully"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
-// @Failure 404 {object} model.SwaggerWebResponse "Harbor not found"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Harbor not found or not granted to the caller's roles"
+// @Failure 409 {object} model.SwaggerWebResponse "Harbor has boardings, port calls or berths"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/harbors/{harborId} [delete]
 func (c *HarborController) Delete(ctx *fiber.Ctx) error {
@@ -180,7 +200,9 @@ func (c *HarborController) Delete(ctx *fiber.Ctx) error {
 		ID: harborId,
 	}
 
-	if err := c.UseCase.Delete(ctx.UserContext(), request); err != nil {
+	auth := middleware.GetUser(ctx)
+
+	if err := c.UseCase.Delete(ctx.UserContext(), request, auth.ID); err != nil {
 		c.Log.WithError(err).Error("failed to delete harbor")
 		return utils.SendErrorResponse(ctx, fiber.StatusNotFound, "Harbor not found", err.Error())
 	}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"mkp-boarding-test/pkg/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type JWKSController struct {
+	JWTService service.JWTService
+	Log        *logrus.Logger
+}
+
+func NewJWKSController(jwtService service.JWTService, log *logrus.Logger) *JWKSController {
+	return &JWKSController{
+		JWTService: jwtService,
+		Log:        log,
+	}
+}
+
+// Get godoc
+// @Summary Get JSON Web Key Set
+// @Description Get the public keys used to verify access tokens. The set is empty when tokens are signed with a shared secret (HS256)
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} service.JSONWebKeySet "JSON Web Key Set"
+// @Router /.well-known/jwks.json [get]
+func (c *JWKSController) Get(ctx *fiber.Ctx) error {
+	// Served as a plain JWK Set instead of the usual response envelope so standard JWT libraries can consume it
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return ctx.JSON(c.JWTService.JWKS())
+}
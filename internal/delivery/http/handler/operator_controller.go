@@ -33,6 +33,7 @@ func NewOperatorController(useCase usecase.OperatorUseCase, log *logrus.Logger)
 // @Success 200 {object} model.SwaggerWebResponse "Operator created successfully"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/operators [post]
 func (c *OperatorController) Create(ctx *fiber.Ctx) error {
@@ -73,6 +74,7 @@ func (c *OperatorController) Create(ctx *fiber.Ctx) error {
 // @Param size query int false "Page size" default(10)
 // @Success 200 {object} model.SwaggerPageResponse "List of operators"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/operators [get]
 func (c *OperatorController) List(ctx *fiber.Ctx) error {
@@ -116,6 +118,7 @@ func (c *OperatorController) List(ctx *fiber.Ctx) error {
 // @Param operatorId path string true "Operator ID"
 // @Success 200 {object} model.SwaggerWebResponse "Operator details"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 404 {object} model.SwaggerWebResponse "Operator not found"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/operators/{operatorId} [get]
@@ -147,6 +150,7 @@ func (c *OperatorController) Get(ctx *fiber.Ctx) error {
 // @Success 200 {object} model.SwaggerWebResponse "Operator updated successfully"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 404 {object} model.SwaggerWebResponse "Operator not found"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/operators/{operatorId} [put]
@@ -178,6 +182,7 @@ func (c *OperatorController) Update(ctx *fiber.Ctx) error {
 // @Param operatorId path string true "Operator ID"
 // @Success 200 {object} model.SwaggerWebResponse "Operator deleted successfully"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 404 {object} model.SwaggerWebResponse "Operator not found"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/operators/{operatorId} [delete]
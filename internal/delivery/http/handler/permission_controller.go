@@ -32,6 +32,7 @@ func NewPermissionController(useCase usecase.PermissionUseCase, log *logrus.Logg
 // @Success 200 {object} model.SwaggerWebResponse "Permission created successfully"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/permissions [post]
 func (c *PermissionController) Create(ctx *fiber.Ctx) error {
@@ -65,6 +66,7 @@ func (c *PermissionController) Create(ctx *fiber.Ctx) error {
 // @Param size query int false "Page size" default(10)
 // @Success 200 {object} model.SwaggerPageResponse "List of permissions"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/permissions [get]
 func (c *PermissionController) List(ctx *fiber.Ctx) error {
@@ -102,6 +104,7 @@ func (c *PermissionController) List(ctx *fiber.Ctx) error {
 // @Param permissionId path string true "Permission ID"
 // @Success 200 {object} model.SwaggerWebResponse "Permission details"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 404 {object} model.SwaggerWebResponse "Permission not found"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/permissions/{permissionId} [get]
@@ -133,6 +136,7 @@ func (c *PermissionController) Get(ctx *fiber.Ctx) error {
 // @Success 200 {object} model.SwaggerWebResponse "Permission updated successfully"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 404 {object} model.SwaggerWebResponse "Permission not found"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/permissions/{permissionId} [put]
@@ -164,6 +168,7 @@ func (c *PermissionController) Update(ctx *fiber.Ctx) error {
 // @Param permissionId path string true "Permission ID"
 // @Success 200 {object} model.SwaggerWebResponse "Permission deleted successfully"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 404 {object} model.SwaggerWebResponse "Permission not found"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/permissions/{permissionId} [delete]
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"mkp-boarding-test/internal/delivery/http/middleware"
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type PortCallController struct {
+	UseCase usecase.PortCallUseCase
+	Log     *logrus.Logger
+}
+
+func NewPortCallController(useCase usecase.PortCallUseCase, log *logrus.Logger) *PortCallController {
+	return &PortCallController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+// Create godoc
+// @Summary Declare port call
+// @Description Declare the expected visit of a ship to a harbor with its ETA, ETD, previous and next port and purpose. Times are Unix milliseconds
+// @Tags Port Calls
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.CreatePortCallRequest true "Create port call request"
+// @Success 200 {object} model.SwaggerWebResponse "Port call declared successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Ship or harbor not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/port-calls [post]
+func (c *PortCallController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreatePortCallRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Create(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to create port call")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to declare port call", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Port call declared successfully", response)
+}
+
+// ListByHarbor godoc
+// @Summary List harbor port calls
+// @Description Get the port calls of a harbor. Ships in port are listed by latest arrival, expected ships by earliest ETA and departed ships by latest departure
+// @Tags Port Calls
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param harborId path string true "Harbor ID"
+// @Param status query string false "Filter by status (expected, in_port, departed, cancelled)"
+// @Param ship_id query string false "Filter by ship ID"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {object} model.SwaggerPageResponse "List of port calls"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Harbor not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/harbors/{harborId}/port-calls [get]
+func (c *PortCallController) ListByHarbor(ctx *fiber.Ctx) error {
+	status := ctx.Query("status", "")
+	shipID := ctx.Query("ship_id", "")
+
+	request := &model.ListHarborPortCallRequest{
+		HarborID: ctx.Params("harborId"),
+		Status:   &status,
+		ShipID:   &shipID,
+		Page:     ctx.QueryInt("page", 1),
+		Size:     ctx.QueryInt("size", 10),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	responses, err := c.UseCase.ListByHarbor(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to list harbor port calls")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve port calls", err.Error())
+	}
+
+	response := utils.SuccessResponseWithMeta("Port calls retrieved successfully", responses.Data, responses.Meta)
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+// Get godoc
+// @Summary Get port call
+// @Description Get a port call with its ship and harbor
+// @Tags Port Calls
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param portCallId path string true "Port call ID"
+// @Success 200 {object} model.SwaggerWebResponse "Port call details"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Port call not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/port-calls/{portCallId} [get]
+func (c *PortCallController) Get(ctx *fiber.Ctx) error {
+	request := &model.GetPortCallRequest{
+		ID: ctx.Params("portCallId"),
+	}
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get port call")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve port call", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Port call retrieved successfully", response)
+}
+
+// Update godoc
+// @Summary Update port call
+// @Description Update the schedule, previous and next port, purpose or remarks of a port call that is expected or in port
+// @Tags Port Calls
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param portCallId path string true "Port call ID"
+// @Param request body model.UpdatePortCallRequest true "Update port call request"
+// @Success 200 {object} model.SwaggerWebResponse "Port call updated successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Port call not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Port call is closed"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/port-calls/{portCallId} [put]
+func (c *PortCallController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdatePortCallRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("portCallId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Update(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to update port call")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to update port call", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Port call updated successfully", response)
+}
+
+// Arrive godoc
+// @Summary Record arrival
+// @Description Record that an expected ship arrived in port. The ATA defaults to now, a ship can only be in port at one harbor at a time
+// @Tags Port Calls
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param portCallId path string true "Port call ID"
+// @Param request body model.ArrivePortCallRequest false "Arrive port call request"
+// @Success 200 {object} model.SwaggerWebResponse "Arrival recorded successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Port call not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Port call is not expected or ship is in port elsewhere"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/port-calls/{portCallId}/arrive [post]
+func (c *PortCallController) Arrive(ctx *fiber.Ctx) error {
+	// The ATA is optional, an arrival without a body is recorded at the current time
+	request := new(model.ArrivePortCallRequest)
+	if err := ctx.BodyParser(request); err != nil && err != fiber.ErrUnprocessableEntity {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("portCallId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Arrive(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to record port call arrival")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to record arrival", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Arrival recorded successfully", response)
+}
+
+// Close godoc
+// @Summary Close port call
+// @Description Close a port call. A ship in port departs at the ATD which defaults to now, an expected call is cancelled
+// @Tags Port Calls
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param portCallId path string true "Port call ID"
+// @Param request body model.ClosePortCallRequest false "Close port call request"
+// @Success 200 {object} model.SwaggerWebResponse "Port call closed successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Port call not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Port call is already closed"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/port-calls/{portCallId}/close [post]
+func (c *PortCallController) Close(ctx *fiber.Ctx) error {
+	// The ATD and remarks are optional, a call closed without a body ends at the current time
+	request := new(model.ClosePortCallRequest)
+	if err := ctx.BodyParser(request); err != nil && err != fiber.ErrUnprocessableEntity {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("portCallId")
+
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Close(ctx.UserContext(), request, auth.ID)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to close port call")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to close port call", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Port call closed successfully", response)
+}
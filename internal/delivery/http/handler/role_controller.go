@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"mkp-boarding-test/internal/delivery/http/middleware"
 	"mkp-boarding-test/internal/domain/usecase"
 	"mkp-boarding-test/internal/model"
 	"mkp-boarding-test/pkg/utils"
@@ -32,6 +33,9 @@ func NewRoleController(useCase usecase.RoleUseCase, log *logrus.Logger) *RoleCon
 // @Success 200 {object} model.SwaggerWebResponse "Role created successfully"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Parent role not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Role already exists"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/roles [post]
 func (c *RoleController) Create(ctx *fiber.Ctx) error {
@@ -44,7 +48,11 @@ func (c *RoleController) Create(ctx *fiber.Ctx) error {
 	response, err := c.UseCase.Create(ctx.UserContext(), request)
 	if err != nil {
 		c.Log.WithError(err).Error("failed to create role")
-		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to create role", err.Error())
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to create role", err.Error())
 	}
 
 	return utils.SendSuccessResponse(ctx, "Role created successfully", response)
@@ -63,6 +71,7 @@ func (c *RoleController) Create(ctx *fiber.Ctx) error {
 // @Param size query int false "Page size" default(10)
 // @Success 200 {object} model.SwaggerPageResponse "List of roles"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/roles [get]
 func (c *RoleController) List(ctx *fiber.Ctx) error {
@@ -96,6 +105,7 @@ func (c *RoleController) List(ctx *fiber.Ctx) error {
 // @Param roleId path string true "Role ID"
 // @Success 200 {object} model.SwaggerWebResponse "Role details"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 404 {object} model.SwaggerWebResponse "Role not found"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/roles/{roleId} [get]
@@ -115,6 +125,38 @@ func (c *RoleController) Get(ctx *fiber.Ctx) error {
 	return utils.SendSuccessResponse(ctx, "Role retrieved successfully", response)
 }
 
+// GetEffectivePermissions godoc
+// @Summary Get effective permissions of role
+// @Description Resolve the permissions holders of the role get, including the ones inherited from its parent roles, with wildcard grants expanded to the existing permissions
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param roleId path string true "Role ID"
+// @Success 200 {object} model.SwaggerWebResponse "Effective permissions"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Role not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/roles/{roleId}/effective-permissions [get]
+func (c *RoleController) GetEffectivePermissions(ctx *fiber.Ctx) error {
+	request := &model.GetRoleEffectivePermissionsRequest{
+		ID: ctx.Params("roleId"),
+	}
+
+	response, err := c.UseCase.GetEffectivePermissions(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get effective permissions of role")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve effective permissions", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Effective permissions retrieved successfully", response)
+}
+
 // Update godoc
 // @Summary Update role
 // @Description Update role information by role ID
@@ -127,6 +169,7 @@ func (c *RoleController) Get(ctx *fiber.Ctx) error {
 // @Success 200 {object} model.SwaggerWebResponse "Role updated successfully"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 404 {object} model.SwaggerWebResponse "Role not found"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/roles/{roleId} [put]
@@ -142,7 +185,11 @@ func (c *RoleController) Update(ctx *fiber.Ctx) error {
 	response, err := c.UseCase.Update(ctx.UserContext(), request)
 	if err != nil {
 		c.Log.WithError(err).Error("failed to update role")
-		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to update role", err.Error())
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to update role", err.Error())
 	}
 
 	return utils.SendSuccessResponse(ctx, "Role updated successfully", response)
@@ -158,6 +205,7 @@ func (c *RoleController) Update(ctx *fiber.Ctx) error {
 // @Param roleId path string true "Role ID"
 // @Success 200 {object} model.SwaggerWebResponse "Role deleted successfully"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 404 {object} model.SwaggerWebResponse "Role not found"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/roles/{roleId} [delete]
@@ -178,7 +226,7 @@ func (c *RoleController) Delete(ctx *fiber.Ctx) error {
 
 // AssignPermissions godoc
 // @Summary Assign permissions to role
-// @Description Assign multiple permissions to a specific role
+// @Description Assign multiple permissions to a specific role, permissions the role already has are skipped
 // @Tags Roles
 // @Accept json
 // @Produce json
@@ -188,7 +236,8 @@ func (c *RoleController) Delete(ctx *fiber.Ctx) error {
 // @Success 200 {object} model.SwaggerWebResponse "Permissions assigned successfully"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
-// @Failure 404 {object} model.SwaggerWebResponse "Role not found"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Role or permission not found"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/roles/{roleId}/permissions [post]
 func (c *RoleController) AssignPermissions(ctx *fiber.Ctx) error {
@@ -202,7 +251,11 @@ func (c *RoleController) AssignPermissions(ctx *fiber.Ctx) error {
 
 	if err := c.UseCase.AssignPermissions(ctx.UserContext(), request); err != nil {
 		c.Log.WithError(err).Error("failed to assign permissions to role")
-		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to assign permissions", err.Error())
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to assign permissions", err.Error())
 	}
 
 	return utils.SendSuccessResponse(ctx, "Permissions assigned successfully", true)
@@ -210,7 +263,7 @@ func (c *RoleController) AssignPermissions(ctx *fiber.Ctx) error {
 
 // RemovePermissions godoc
 // @Summary Remove permissions from role
-// @Description Remove multiple permissions from a specific role
+// @Description Remove multiple permissions from a specific role, permissions the role does not have are skipped
 // @Tags Roles
 // @Accept json
 // @Produce json
@@ -220,6 +273,7 @@ func (c *RoleController) AssignPermissions(ctx *fiber.Ctx) error {
 // @Success 200 {object} model.SwaggerWebResponse "Permissions removed successfully"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 404 {object} model.SwaggerWebResponse "Role not found"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/roles/{roleId}/permissions [delete]
@@ -234,8 +288,126 @@ func (c *RoleController) RemovePermissions(ctx *fiber.Ctx) error {
 
 	if err := c.UseCase.RemovePermissions(ctx.UserContext(), request); err != nil {
 		c.Log.WithError(err).Error("failed to remove permissions from role")
-		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to remove permissions", err.Error())
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to remove permissions", err.Error())
 	}
 
 	return utils.SendSuccessResponse(ctx, "Permissions removed successfully", true)
 }
+
+// SetPermissions godoc
+// @Summary Replace permissions of role
+// @Description Replace the whole permission set of a role, permissions missing from the list are removed and an empty list removes all of them
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param roleId path string true "Role ID"
+// @Param request body model.SetPermissionsRequest true "Set permissions request"
+// @Success 200 {object} model.SwaggerWebResponse "Permissions replaced successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Role or permission not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/roles/{roleId}/permissions [put]
+func (c *RoleController) SetPermissions(ctx *fiber.Ctx) error {
+	request := new(model.SetPermissionsRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.RoleID = ctx.Params("roleId")
+
+	response, err := c.UseCase.SetPermissions(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to replace permissions of role")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to replace permissions", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Permissions replaced successfully", response)
+}
+
+// AssignHarbors godoc
+// @Summary Assign harbors to role
+// @Description Grant harbors to a role, holders of the role can then see and manage them. Only harbors granted to one of the caller's roles can be assigned
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param roleId path string true "Role ID"
+// @Param request body model.AssignHarborsRequest true "Assign harbors request"
+// @Success 200 {object} model.SwaggerWebResponse "Harbors assigned successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Role or harbor not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/roles/{roleId}/harbors [post]
+func (c *RoleController) AssignHarbors(ctx *fiber.Ctx) error {
+	request := new(model.AssignHarborsRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.RoleID = ctx.Params("roleId")
+	request.UserID = middleware.GetUser(ctx).ID
+
+	if err := c.UseCase.AssignHarbors(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("failed to assign harbors to role")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to assign harbors", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Harbors assigned successfully", true)
+}
+
+// RemoveHarbors godoc
+// @Summary Remove harbors from role
+// @Description Take harbors away from a role. Only harbors granted to one of the caller's roles can be removed
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param roleId path string true "Role ID"
+// @Param request body model.RemoveHarborsRequest true "Remove harbors request"
+// @Success 200 {object} model.SwaggerWebResponse "Harbors removed successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Role or harbor not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/roles/{roleId}/harbors [delete]
+func (c *RoleController) RemoveHarbors(ctx *fiber.Ctx) error {
+	request := new(model.RemoveHarborsRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.RoleID = ctx.Params("roleId")
+	request.UserID = middleware.GetUser(ctx).ID
+
+	if err := c.UseCase.RemoveHarbors(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("failed to remove harbors from role")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to remove harbors", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Harbors removed successfully", true)
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"mkp-boarding-test/internal/delivery/http/middleware"
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/internal/model"
+	"mkp-boarding-test/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ServiceAccountController struct {
+	UseCase usecase.ServiceAccountUseCase
+	Log     *logrus.Logger
+}
+
+func NewServiceAccountController(useCase usecase.ServiceAccountUseCase, log *logrus.Logger) *ServiceAccountController {
+	return &ServiceAccountController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+// Create godoc
+// @Summary Create a new service account
+// @Description Create a service account for machine-to-machine access, it authenticates with API keys issued separately
+// @Tags Service Accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.CreateServiceAccountRequest true "Create service account request"
+// @Success 201 {object} model.SwaggerWebResponse "Service account created successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 409 {object} model.SwaggerWebResponse "Service account name already exists"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/service-accounts [post]
+func (c *ServiceAccountController) Create(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.CreateServiceAccountRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.CreatedBy = auth.ID
+
+	response, err := c.UseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to create service account")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to create service account", err.Error())
+	}
+
+	return utils.SendCreatedResponse(ctx, "Service account created successfully", response)
+}
+
+// List godoc
+// @Summary List service accounts
+// @Description Get list of service accounts with optional filtering
+// @Tags Service Accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name query string false "Filter by name"
+// @Param is_active query bool false "Filter by active status"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {object} model.SwaggerPageResponse "List of service accounts"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/service-accounts [get]
+func (c *ServiceAccountController) List(ctx *fiber.Ctx) error {
+	name := ctx.Query("name", "")
+
+	request := &model.ListServiceAccountRequest{
+		Name: &name,
+		Page: ctx.QueryInt("page", 1),
+		Size: ctx.QueryInt("size", 10),
+	}
+	if ctx.Query("is_active") != "" {
+		isActive := ctx.QueryBool("is_active")
+		request.IsActive = &isActive
+	}
+
+	responses, err := c.UseCase.List(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to list service accounts")
+		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to retrieve service accounts", err.Error())
+	}
+
+	return utils.SendSuccessResponseWithMeta(ctx, "Service accounts retrieved successfully", responses.Data, responses.Meta)
+}
+
+// Get godoc
+// @Summary Get service account by ID
+// @Description Get service account details by service account ID
+// @Tags Service Accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param serviceAccountId path string true "Service account ID"
+// @Success 200 {object} model.SwaggerWebResponse "Service account details"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Service account not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/service-accounts/{serviceAccountId} [get]
+func (c *ServiceAccountController) Get(ctx *fiber.Ctx) error {
+	request := &model.GetServiceAccountRequest{
+		ID: ctx.Params("serviceAccountId"),
+	}
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get service account")
+		return utils.SendErrorResponse(ctx, fiber.StatusNotFound, "Service account not found", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Service account retrieved successfully", response)
+}
+
+// Delete godoc
+// @Summary Delete service account
+// @Description Soft delete a service account and revoke all of its API keys
+// @Tags Service Accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param serviceAccountId path string true "Service account ID"
+// @Success 200 {object} model.SwaggerWebResponse "Service account deleted successfully"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Service account not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/service-accounts/{serviceAccountId} [delete]
+func (c *ServiceAccountController) Delete(ctx *fiber.Ctx) error {
+	request := &model.DeleteServiceAccountRequest{
+		ID: ctx.Params("serviceAccountId"),
+	}
+
+	if err := c.UseCase.Delete(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("failed to delete service account")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to delete service account", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Service account deleted successfully", true)
+}
+
+// CreateAPIKey godoc
+// @Summary Issue an API key
+// @Description Issue an API key for a service account scoped to the given permissions. The caller must hold every requested permission. The plain key is only returned in this response
+// @Tags Service Accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param serviceAccountId path string true "Service account ID"
+// @Param request body model.CreateAPIKeyRequest true "Create API key request"
+// @Success 201 {object} model.SwaggerWebResponse "API key created successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Service account not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/service-accounts/{serviceAccountId}/keys [post]
+func (c *ServiceAccountController) CreateAPIKey(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(model.CreateAPIKeyRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("failed to parse request body")
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ServiceAccountID = ctx.Params("serviceAccountId")
+	request.CreatedBy = auth.ID
+	request.GrantorPermissions = middleware.GetPermissions(ctx)
+
+	response, err := c.UseCase.CreateAPIKey(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to create api key")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to create API key", err.Error())
+	}
+
+	return utils.SendCreatedResponse(ctx, "API key created successfully", response)
+}
+
+// ListAPIKeys godoc
+// @Summary List API keys
+// @Description List the API keys of a service account including revoked and expired keys, the key secret is never returned
+// @Tags Service Accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param serviceAccountId path string true "Service account ID"
+// @Success 200 {object} model.SwaggerWebResponse "API keys retrieved successfully"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Service account not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/service-accounts/{serviceAccountId}/keys [get]
+func (c *ServiceAccountController) ListAPIKeys(ctx *fiber.Ctx) error {
+	request := &model.ListAPIKeyRequest{
+		ServiceAccountID: ctx.Params("serviceAccountId"),
+	}
+
+	response, err := c.UseCase.ListAPIKeys(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to list api keys")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve API keys", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "API keys retrieved successfully", response)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description Revoke an API key of a service account, requests with the key are rejected immediately
+// @Tags Service Accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param serviceAccountId path string true "Service account ID"
+// @Param keyId path string true "API key ID"
+// @Success 200 {object} model.SwaggerWebResponse "API key revoked successfully"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "API key not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/service-accounts/{serviceAccountId}/keys/{keyId} [delete]
+func (c *ServiceAccountController) RevokeAPIKey(ctx *fiber.Ctx) error {
+	request := &model.RevokeAPIKeyRequest{
+		ServiceAccountID: ctx.Params("serviceAccountId"),
+		ID:               ctx.Params("keyId"),
+	}
+
+	if err := c.UseCase.RevokeAPIKey(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("failed to revoke api key")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to revoke API key", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "API key revoked successfully", true)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"mkp-boarding-test/internal/delivery/http/middleware"
 	"mkp-boarding-test/internal/domain/usecase"
 	"mkp-boarding-test/internal/model"
 	"mkp-boarding-test/pkg/utils"
@@ -32,6 +33,9 @@ func NewShipController(useCase usecase.ShipUseCase, log *logrus.Logger) *ShipCon
 // @Success 200 {object} model.SwaggerWebResponse "Ship created successfully"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "Operator not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Ship already exists"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/ships [post]
 func (c *ShipController) Create(ctx *fiber.Ctx) error {
@@ -41,10 +45,16 @@ func (c *ShipController) Create(ctx *fiber.Ctx) error {
 		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	response, err := c.UseCase.Create(ctx.UserContext(), request)
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Create(ctx.UserContext(), request, auth.ID)
 	if err != nil {
 		c.Log.WithError(err).Error("failed to create ship")
-		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to create ship", err.Error())
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to create ship", err.Error())
 	}
 
 	return utils.SendSuccessResponse(ctx, "Ship created successfully", response)
@@ -67,6 +77,7 @@ func (c *ShipController) Create(ctx *fiber.Ctx) error {
 // @Param size query int false "Page size" default(10)
 // @Success 200 {object} model.SwaggerPageResponse "List of ships"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/ships [get]
 func (c *ShipController) List(ctx *fiber.Ctx) error {
@@ -88,10 +99,16 @@ func (c *ShipController) List(ctx *fiber.Ctx) error {
 		Size:       ctx.QueryInt("size", 10),
 	}
 
-	responses, err := c.UseCase.List(ctx.UserContext(), request)
+	auth := middleware.GetUser(ctx)
+
+	responses, err := c.UseCase.List(ctx.UserContext(), request, auth.ID)
 	if err != nil {
 		c.Log.WithError(err).Error("failed to list ships")
-		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to retrieve ships", err.Error())
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to retrieve ships", err.Error())
 	}
 
 	response := utils.SuccessResponseWithMeta("Ships retrieved successfully", responses.Data, responses.Meta)
@@ -108,6 +125,7 @@ func (c *ShipController) List(ctx *fiber.Ctx) error {
 // @Param shipId path string true "Ship ID"
 // @Success 200 {object} model.SwaggerWebResponse "Ship details"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 404 {object} model.SwaggerWebResponse "Ship not found"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/ships/{shipId} [get]
@@ -118,10 +136,16 @@ func (c *ShipController) Get(ctx *fiber.Ctx) error {
 		ID: shipId,
 	}
 
-	response, err := c.UseCase.Get(ctx.UserContext(), request)
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request, auth.ID)
 	if err != nil {
 		c.Log.WithError(err).Error("failed to get ship")
-		return utils.SendErrorResponse(ctx, fiber.StatusNotFound, "Ship not found", err.Error())
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Ship not found", err.Error())
 	}
 
 	return utils.SendSuccessResponse(ctx, "Ship retrieved successfully", response)
@@ -139,6 +163,7 @@ func (c *ShipController) Get(ctx *fiber.Ctx) error {
 // @Success 200 {object} model.SwaggerWebResponse "Ship updated successfully"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 404 {object} model.SwaggerWebResponse "Ship not found"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/ships/{shipId} [put]
@@ -151,10 +176,16 @@ func (c *ShipController) Update(ctx *fiber.Ctx) error {
 
 	request.ID = ctx.Params("shipId")
 
-	response, err := c.UseCase.Update(ctx.UserContext(), request)
+	auth := middleware.GetUser(ctx)
+
+	response, err := c.UseCase.Update(ctx.UserContext(), request, auth.ID)
 	if err != nil {
 		c.Log.WithError(err).Error("failed to update ship")
-		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to update ship", err.Error())
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to update ship", err.Error())
 	}
 
 	return utils.SendSuccessResponse(ctx, "Ship updated successfully", response)
@@ -170,7 +201,9 @@ func (c *ShipController) Update(ctx *fiber.Ctx) error {
 // @Param shipId path string true "Ship ID"
 // @Success 200 {object} model.SwaggerWebResponse "Ship deleted successfully"
 // @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 404 {object} model.SwaggerWebResponse "Ship not found"
+// @Failure 409 {object} model.SwaggerWebResponse "Ship has boardings or port calls"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/ships/{shipId} [delete]
 func (c *ShipController) Delete(ctx *fiber.Ctx) error {
@@ -180,9 +213,15 @@ func (c *ShipController) Delete(ctx *fiber.Ctx) error {
 		ID: shipId,
 	}
 
-	if err := c.UseCase.Delete(ctx.UserContext(), request); err != nil {
+	auth := middleware.GetUser(ctx)
+
+	if err := c.UseCase.Delete(ctx.UserContext(), request, auth.ID); err != nil {
 		c.Log.WithError(err).Error("failed to delete ship")
-		return utils.SendErrorResponse(ctx, fiber.StatusNotFound, "Ship not found", err.Error())
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to delete ship", err.Error())
 	}
 
 	return utils.SendSuccessResponse(ctx, "Ship deleted successfully", true)
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"mkp-boarding-test/internal/delivery/http/dto/request"
+	responseDTO "mkp-boarding-test/internal/delivery/http/dto/response"
 	"mkp-boarding-test/internal/delivery/http/middleware"
 	"mkp-boarding-test/internal/domain/usecase"
 	"mkp-boarding-test/pkg/utils"
@@ -30,7 +31,8 @@ func NewUserController(useCase usecase.UserUseCase, logger *logrus.Logger) *User
 // @Produce json
 // @Param request body request.RegisterUserRequest true "Register user request"
 // @Success 200 {object} model.SwaggerWebResponse "User registered successfully"
-// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request or password policy violation"
+// @Failure 409 {object} model.SwaggerWebResponse "User already exists"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /register [post]
 func (c *UserController) Register(ctx *fiber.Ctx) error {
@@ -44,15 +46,143 @@ func (c *UserController) Register(ctx *fiber.Ctx) error {
 	response, err := c.UseCase.Create(ctx.UserContext(), request)
 	if err != nil {
 		c.Log.Warnf("Failed to register user : %+v", err)
-		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to register user", err.Error())
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to register user", err.Error())
 	}
 
 	return utils.SendSuccessResponse(ctx, "User registered successfully", response)
 }
 
+// VerifyEmail godoc
+// @Summary Verify email address
+// @Description Confirm the email address of a user with the token sent on registration. Tokens expire after 24 hours and can be used once
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.VerifyEmailRequest true "Verify email request"
+// @Success 200 {object} model.SwaggerWebResponse "Email verified successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Invalid or expired verification token"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /verify-email [post]
+func (c *UserController) VerifyEmail(ctx *fiber.Ctx) error {
+	body := new(request.VerifyEmailRequest)
+	if err := ctx.BodyParser(body); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request := &request.VerifyUserRequest{
+		Token: body.Token,
+	}
+
+	response, err := c.UseCase.Verify(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to verify email : %+v", err)
+		if err == fiber.ErrInternalServerError {
+			return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to verify email", err.Error())
+		}
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid or expired verification token", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Email verified successfully", response)
+}
+
+// ResendVerification godoc
+// @Summary Resend verification email
+// @Description Send a new verification token to the given address. The response is the same whether or not the address belongs to an unverified account
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.ResendVerificationRequest true "Resend verification request"
+// @Success 202 {object} model.SwaggerWebResponse "Verification email sent"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /verify-email/resend [post]
+func (c *UserController) ResendVerification(ctx *fiber.Ctx) error {
+	request := new(request.ResendVerificationRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	if err := c.UseCase.ResendVerification(ctx.UserContext(), request); err != nil {
+		c.Log.Warnf("Failed to resend verification email : %+v", err)
+		if err == fiber.ErrBadRequest {
+			return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+		}
+		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to resend verification email", err.Error())
+	}
+
+	return utils.SendAcceptedResponse(ctx, "If the address belongs to an unverified account, a verification email has been sent", true)
+}
+
+// ForgotPassword godoc
+// @Summary Request password reset
+// @Description Mail a single-use password reset token valid for one hour. The response is the same whether or not the address belongs to an account
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.ForgotPasswordRequest true "Forgot password request"
+// @Success 202 {object} model.SwaggerWebResponse "Password reset email sent"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /password/forgot [post]
+func (c *UserController) ForgotPassword(ctx *fiber.Ctx) error {
+	request := new(request.ForgotPasswordRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	if err := c.UseCase.ForgotPassword(ctx.UserContext(), request); err != nil {
+		c.Log.Warnf("Failed to request password reset : %+v", err)
+		if err == fiber.ErrBadRequest {
+			return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+		}
+		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to request password reset", err.Error())
+	}
+
+	return utils.SendAcceptedResponse(ctx, "If the address belongs to an account, a password reset email has been sent", true)
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password with a password reset token. All sessions of the user are revoked
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.ResetPasswordRequest true "Reset password request"
+// @Success 200 {object} model.SwaggerWebResponse "Password reset successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Invalid or expired reset token, or password policy violation"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /password/reset [post]
+func (c *UserController) ResetPassword(ctx *fiber.Ctx) error {
+	request := new(request.ResetPasswordRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	if err := c.UseCase.ResetPassword(ctx.UserContext(), request); err != nil {
+		c.Log.Warnf("Failed to reset password : %+v", err)
+		if err == fiber.ErrInternalServerError {
+			return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to reset password", err.Error())
+		}
+		if err == fiber.ErrBadRequest {
+			return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid or expired reset token", err.Error())
+		}
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Failed to reset password", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Password reset successfully", true)
+}
+
 // Login godoc
 // @Summary User login
-// @Description Authenticate user with username/email and password
+// @Description Authenticate user with username/email and password. Repeated failures per account and per client address are throttled with exponential backoff and a temporary lockout. Accounts with two-factor authentication get a two_factor_token instead of tokens and finish at /login/2fa
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -60,6 +190,7 @@ func (c *UserController) Register(ctx *fiber.Ctx) error {
 // @Success 200 {object} model.SwaggerWebResponse "Login successful"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
 // @Failure 401 {object} model.SwaggerWebResponse "Invalid credentials"
+// @Failure 429 {object} model.SwaggerWebResponse "Too many failed login attempts"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /login [post]
 func (c *UserController) Login(ctx *fiber.Ctx) error {
@@ -70,18 +201,155 @@ func (c *UserController) Login(ctx *fiber.Ctx) error {
 		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
+	request.UserAgent = ctx.Get(fiber.HeaderUserAgent)
+	request.IPAddress = ctx.IP()
 	response, err := c.UseCase.Login(ctx.UserContext(), request)
 	if err != nil {
 		c.Log.Warnf("Failed to login user : %+v", err)
+		if e, ok := err.(*fiber.Error); ok && e.Code == fiber.StatusTooManyRequests {
+			return utils.SendErrorResponse(ctx, fiber.StatusTooManyRequests, "Too many failed login attempts", err.Error())
+		}
+		if err == fiber.ErrInternalServerError {
+			return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to login user", err.Error())
+		}
 		return utils.SendErrorResponse(ctx, fiber.StatusUnauthorized, "Invalid credentials", err.Error())
 	}
 
 	return utils.SendSuccessResponse(ctx, "Login successful", response)
 }
 
+// LoginTwoFactor godoc
+// @Summary Complete a two-factor login
+// @Description Submit the two_factor_token returned by /login together with a TOTP code or an unused recovery code to finish the login. Wrong codes count as failed logins
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body model.LoginTwoFactorRequest true "Two-factor login request"
+// @Success 200 {object} model.SwaggerWebResponse "Login successful"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Invalid or expired challenge or code"
+// @Failure 429 {object} model.SwaggerWebResponse "Too many failed login attempts"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /login/2fa [post]
+func (c *UserController) LoginTwoFactor(ctx *fiber.Ctx) error {
+	request := new(request.LoginTwoFactorRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.UserAgent = ctx.Get(fiber.HeaderUserAgent)
+	request.IPAddress = ctx.IP()
+	response, err := c.UseCase.LoginTwoFactor(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to complete two-factor login : %+v", err)
+		status := fiber.StatusUnauthorized
+		if e, ok := err.(*fiber.Error); ok && e.Code != fiber.StatusNotFound {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Invalid two-factor code", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Login successful", response)
+}
+
+// OIDCLogin godoc
+// @Summary Start an identity provider login
+// @Description Redirect to the OpenID Connect identity provider (authorization code flow with PKCE). The provider sends the user back to /oidc/callback
+// @Tags Auth
+// @Produce json
+// @Success 302 "Redirect to the identity provider"
+// @Failure 404 {object} model.SwaggerWebResponse "OIDC login is not enabled"
+// @Failure 502 {object} model.SwaggerWebResponse "Identity provider is not available"
+// @Router /oidc/login [get]
+func (c *UserController) OIDCLogin(ctx *fiber.Ctx) error {
+	response, err := c.UseCase.OIDCAuthorize(ctx.UserContext())
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to start oidc login")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to start identity provider login", err.Error())
+	}
+
+	return ctx.Redirect(response.AuthorizationURL, fiber.StatusFound)
+}
+
+// OIDCCallback godoc
+// @Summary Finish an identity provider login
+// @Description Redirect target of the identity provider. The authorization code is exchanged, the identity is mapped to a user (and its groups to roles) and the usual token pair is issued. Accounts with two-factor authentication get a two_factor_token and finish at /login/2fa
+// @Tags Auth
+// @Produce json
+// @Param code query string true "Authorization code"
+// @Param state query string true "State of the started login"
+// @Success 200 {object} model.SwaggerWebResponse "Login successful"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Login rejected by the identity provider or invalid state"
+// @Failure 403 {object} model.SwaggerWebResponse "No account for this identity"
+// @Failure 404 {object} model.SwaggerWebResponse "OIDC login is not enabled"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /oidc/callback [get]
+func (c *UserController) OIDCCallback(ctx *fiber.Ctx) error {
+	if providerError := ctx.Query("error"); providerError != "" {
+		c.Log.Warnf("Identity provider returned error %s : %s", providerError, ctx.Query("error_description"))
+		return utils.SendErrorResponse(ctx, fiber.StatusUnauthorized, "Identity provider login failed", providerError)
+	}
+
+	request := &request.OIDCCallbackRequest{
+		Code:      ctx.Query("code"),
+		State:     ctx.Query("state"),
+		UserAgent: ctx.Get(fiber.HeaderUserAgent),
+		IPAddress: ctx.IP(),
+	}
+
+	response, err := c.UseCase.OIDCCallback(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to finish oidc login")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Identity provider login failed", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Login successful", response)
+}
+
+// Refresh godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access/refresh token pair. The presented refresh token is invalidated and replaying it revokes the whole token family
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.RefreshTokenRequest true "Refresh token request"
+// @Success 200 {object} model.SwaggerWebResponse "Token refreshed successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Invalid refresh token"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /token/refresh [post]
+func (c *UserController) Refresh(ctx *fiber.Ctx) error {
+	request := new(request.RefreshTokenRequest)
+	err := ctx.BodyParser(request)
+	if err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.UserAgent = ctx.Get(fiber.HeaderUserAgent)
+	request.IPAddress = ctx.IP()
+	response, err := c.UseCase.Refresh(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to refresh token : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusUnauthorized, "Invalid refresh token", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Token refreshed successfully", response)
+}
+
 // Current godoc
 // @Summary Get current user
-// @Description Get current authenticated user information
+// @Description Get current authenticated user information, while impersonating the admin acting as the user is listed in impersonated_by
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -103,12 +371,19 @@ func (c *UserController) Current(ctx *fiber.Ctx) error {
 		return utils.SendErrorResponse(ctx, fiber.StatusNotFound, "User not found", err.Error())
 	}
 
+	if auth.IsImpersonated() {
+		response.ImpersonatedBy = &responseDTO.ImpersonatorResponse{
+			ID:       auth.ActorID,
+			Username: auth.ActorUsername,
+		}
+	}
+
 	return utils.SendSuccessResponse(ctx, "Current user retrieved successfully", response)
 }
 
 // Logout godoc
 // @Summary User logout
-// @Description Logout current authenticated user
+// @Description Logout current authenticated user. An impersonation token only revokes itself and leaves the sessions of the user untouched
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -121,7 +396,11 @@ func (c *UserController) Logout(ctx *fiber.Ctx) error {
 	auth := middleware.GetUser(ctx)
 
 	request := &request.LogoutUserRequest{
-		ID: auth.ID,
+		ID:             auth.ID,
+		TokenID:        auth.TokenID,
+		TokenExpiresAt: auth.TokenExpiresAt,
+		SessionID:      auth.SessionID,
+		ActorID:        auth.ActorID,
 	}
 
 	response, err := c.UseCase.Logout(ctx.UserContext(), request)
@@ -133,9 +412,240 @@ func (c *UserController) Logout(ctx *fiber.Ctx) error {
 	return utils.SendSuccessResponse(ctx, "Logout successful", response)
 }
 
+// ChangePassword godoc
+// @Summary Change current user password
+// @Description Change the password of the current authenticated user. The new password has to satisfy the password policy and must not match a recently used one
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.ChangePasswordRequest true "Change password request"
+// @Success 200 {object} model.SwaggerWebResponse "Password changed successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Incorrect current password or password policy violation"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/_current/password [patch]
+func (c *UserController) ChangePassword(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(request.ChangePasswordRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = auth.ID
+	if err := c.UseCase.ChangePassword(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Warnf("Failed to change password")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to change password", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Password changed successfully", true)
+}
+
+// EnrollTwoFactor godoc
+// @Summary Start two-factor enrollment
+// @Description Generate a TOTP secret for the current user. Add it to an authenticator app (the otpauth URI can be rendered as a QR code) and confirm it with a code to enable two-factor authentication
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.SwaggerWebResponse "Two-factor enrollment started"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 409 {object} model.SwaggerWebResponse "Two-factor authentication is already enabled"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/_current/2fa [post]
+func (c *UserController) EnrollTwoFactor(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &request.EnrollTwoFactorRequest{
+		ID: auth.ID,
+	}
+
+	response, err := c.UseCase.EnrollTwoFactor(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to start two-factor enrollment")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to start two-factor enrollment", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Two-factor enrollment started", response)
+}
+
+// ConfirmTwoFactor godoc
+// @Summary Confirm two-factor enrollment
+// @Description Confirm the pending enrollment with a code from the authenticator app. Two-factor authentication is enabled and a set of single-use recovery codes is returned, they are not shown again
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.ConfirmTwoFactorRequest true "Confirm two-factor request"
+// @Success 200 {object} model.SwaggerWebResponse "Two-factor authentication enabled"
+// @Failure 400 {object} model.SwaggerWebResponse "No pending enrollment or invalid code"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/_current/2fa/confirm [post]
+func (c *UserController) ConfirmTwoFactor(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(request.ConfirmTwoFactorRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = auth.ID
+	response, err := c.UseCase.ConfirmTwoFactor(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to confirm two-factor enrollment")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to confirm two-factor enrollment", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Two-factor authentication enabled", response)
+}
+
+// DisableTwoFactor godoc
+// @Summary Disable two-factor authentication
+// @Description Disable two-factor authentication of the current user, requires the password and a TOTP or recovery code
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.DisableTwoFactorRequest true "Disable two-factor request"
+// @Success 200 {object} model.SwaggerWebResponse "Two-factor authentication disabled"
+// @Failure 400 {object} model.SwaggerWebResponse "Not enabled or incorrect password or code"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/_current/2fa [delete]
+func (c *UserController) DisableTwoFactor(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(request.DisableTwoFactorRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = auth.ID
+	if err := c.UseCase.DisableTwoFactor(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Warnf("Failed to disable two-factor authentication")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to disable two-factor authentication", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Two-factor authentication disabled", true)
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Replace all recovery codes of the current user, requires a TOTP or recovery code. The previous codes stop working
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.RegenerateRecoveryCodesRequest true "Regenerate recovery codes request"
+// @Success 200 {object} model.SwaggerWebResponse "Recovery codes regenerated"
+// @Failure 400 {object} model.SwaggerWebResponse "Not enabled or invalid code"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/_current/2fa/recovery-codes [post]
+func (c *UserController) RegenerateRecoveryCodes(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := new(request.RegenerateRecoveryCodesRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = auth.ID
+	response, err := c.UseCase.RegenerateRecoveryCodes(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to regenerate recovery codes")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to regenerate recovery codes", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Recovery codes regenerated", response)
+}
+
+// ListSessions godoc
+// @Summary List current user sessions
+// @Description List active login sessions of the current authenticated user, the session of the presented token is flagged as current
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.SwaggerWebResponse "Sessions retrieved successfully"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/_current/sessions [get]
+func (c *UserController) ListSessions(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &request.ListSessionRequest{
+		UserID:           auth.ID,
+		CurrentSessionID: auth.SessionID,
+	}
+
+	response, err := c.UseCase.ListSessions(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to list user sessions")
+		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to list sessions", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Sessions retrieved successfully", response)
+}
+
+// RevokeSession godoc
+// @Summary Revoke current user session
+// @Description Revoke one login session of the current authenticated user, its refresh tokens and access tokens stop working immediately
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} model.SwaggerWebResponse "Session revoked successfully"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 404 {object} model.SwaggerWebResponse "Session not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/_current/sessions/{sessionId} [delete]
+func (c *UserController) RevokeSession(ctx *fiber.Ctx) error {
+	auth := middleware.GetUser(ctx)
+
+	request := &request.RevokeSessionRequest{
+		UserID: auth.ID,
+		ID:     ctx.Params("sessionId"),
+	}
+
+	if err := c.UseCase.RevokeSession(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Warnf("Failed to revoke user session")
+		return utils.SendErrorResponse(ctx, fiber.StatusNotFound, "Session not found", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Session revoked successfully", true)
+}
+
 // Update godoc
 // @Summary Update current user
-// @Description Update current authenticated user information
+// @Description Update current authenticated user information, the password is changed with PATCH /api/users/_current/password
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -165,6 +675,324 @@ func (c *UserController) Update(ctx *fiber.Ctx) error {
 	return utils.SendSuccessResponse(ctx, "User updated successfully", response)
 }
 
+// List godoc
+// @Summary List users
+// @Description Get paginated list of users with optional filtering
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param username query string false "Filter by username"
+// @Param email query string false "Filter by email"
+// @Param is_active query bool false "Filter by active status"
+// @Param is_verified query bool false "Filter by verification status"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {object} model.SwaggerPageResponse "List of users"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users [get]
+func (c *UserController) List(ctx *fiber.Ctx) error {
+	username := ctx.Query("username", "")
+	email := ctx.Query("email", "")
+
+	request := &request.ListUserRequest{
+		Username: &username,
+		Email:    &email,
+		Page:     ctx.QueryInt("page", 1),
+		Size:     ctx.QueryInt("size", 10),
+	}
+
+	if ctx.Query("is_active") != "" {
+		isActive := ctx.QueryBool("is_active")
+		request.IsActive = &isActive
+	}
+	if ctx.Query("is_verified") != "" {
+		isVerified := ctx.QueryBool("is_verified")
+		request.IsVerified = &isVerified
+	}
+
+	responses, err := c.UseCase.List(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to list users")
+		if err == fiber.ErrBadRequest {
+			return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid query parameters", err.Error())
+		}
+		return utils.SendErrorResponse(ctx, fiber.StatusInternalServerError, "Failed to retrieve users", err.Error())
+	}
+
+	return utils.SendSuccessResponseWithMeta(ctx, "Users retrieved successfully", responses.Data, responses.Meta)
+}
+
+// Get godoc
+// @Summary Get user by ID
+// @Description Get user details, including roles, by user ID
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User ID"
+// @Success 200 {object} model.SwaggerWebResponse "User details"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "User not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/{userId} [get]
+func (c *UserController) Get(ctx *fiber.Ctx) error {
+	request := &request.GetUserRequest{
+		ID: ctx.Params("userId"),
+	}
+
+	response, err := c.UseCase.Get(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to get user")
+		return utils.SendErrorResponse(ctx, fiber.StatusNotFound, "User not found", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "User retrieved successfully", response)
+}
+
+// AdminUpdate godoc
+// @Summary Update user
+// @Description Activate or deactivate a user and force the email verification status. Deactivating a user revokes all of its sessions
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User ID"
+// @Param request body request.AdminUpdateUserRequest true "Update user request"
+// @Success 200 {object} model.SwaggerWebResponse "User updated successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "User not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/{userId} [patch]
+func (c *UserController) AdminUpdate(ctx *fiber.Ctx) error {
+	request := new(request.AdminUpdateUserRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ID = ctx.Params("userId")
+	response, err := c.UseCase.AdminUpdate(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to update user")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to update user", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "User updated successfully", response)
+}
+
+// Delete godoc
+// @Summary Delete user
+// @Description Soft delete a user and revoke all of its sessions
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User ID"
+// @Success 200 {object} model.SwaggerWebResponse "User deleted successfully"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "User not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/{userId} [delete]
+func (c *UserController) Delete(ctx *fiber.Ctx) error {
+	request := &request.DeleteUserRequest{
+		ID: ctx.Params("userId"),
+	}
+
+	if err := c.UseCase.Delete(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("failed to delete user")
+		return utils.SendErrorResponse(ctx, fiber.StatusNotFound, "User not found", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "User deleted successfully", true)
+}
+
+// Unlock godoc
+// @Summary Unlock user
+// @Description Clear the failed login counter of a user so a locked account can log in again right away
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User ID"
+// @Success 200 {object} model.SwaggerWebResponse "User unlocked successfully"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "User not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/{userId}/unlock [post]
+func (c *UserController) Unlock(ctx *fiber.Ctx) error {
+	request := &request.UnlockUserRequest{
+		ID: ctx.Params("userId"),
+	}
+
+	if err := c.UseCase.Unlock(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("failed to unlock user")
+		return utils.SendErrorResponse(ctx, fiber.StatusNotFound, "User not found", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "User unlocked successfully", true)
+}
+
+// ResetTwoFactor godoc
+// @Summary Reset user two-factor authentication
+// @Description Disable two-factor authentication of a user who lost the authenticator and the recovery codes, the user can enroll again afterwards
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User ID"
+// @Success 200 {object} model.SwaggerWebResponse "Two-factor authentication reset successfully"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "User not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/{userId}/2fa [delete]
+func (c *UserController) ResetTwoFactor(ctx *fiber.Ctx) error {
+	request := &request.ResetTwoFactorRequest{
+		ID: ctx.Params("userId"),
+	}
+
+	if err := c.UseCase.ResetTwoFactor(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("failed to reset two-factor authentication")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to reset two-factor authentication", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Two-factor authentication reset successfully", true)
+}
+
+// Impersonate godoc
+// @Summary Impersonate user
+// @Description Issue a short-lived token to act as another user, for example to reproduce what an operator user sees. The token carries the admin in the act claim, can not be refreshed and every write made with it is recorded in the impersonation audit log together with the admin
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User ID"
+// @Param request body request.ImpersonateUserRequest true "Impersonate user request"
+// @Success 200 {object} model.SwaggerWebResponse "Impersonation started successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "User not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/{userId}/impersonate [post]
+func (c *UserController) Impersonate(ctx *fiber.Ctx) error {
+	request := new(request.ImpersonateUserRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.ActorID = middleware.GetUser(ctx).ID
+	request.UserID = ctx.Params("userId")
+	request.Method = ctx.Method()
+	request.Path = ctx.Path()
+	request.IPAddress = ctx.IP()
+
+	response, err := c.UseCase.Impersonate(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("failed to impersonate user")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to impersonate user", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Impersonation started successfully", response)
+}
+
+// AssignRoles godoc
+// @Summary Assign roles to user
+// @Description Grant one or more roles to a user, roles the user already has are left untouched. The caller must hold every permission of the roles
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User ID"
+// @Param request body request.AssignRolesRequest true "Assign roles request"
+// @Success 200 {object} model.SwaggerWebResponse "Roles assigned successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden or role grants permissions the caller does not hold"
+// @Failure 404 {object} model.SwaggerWebResponse "User or role not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/{userId}/roles [post]
+func (c *UserController) AssignRoles(ctx *fiber.Ctx) error {
+	request := new(request.AssignRolesRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.UserID = ctx.Params("userId")
+	request.GrantorPermissions = middleware.GetPermissions(ctx)
+	response, err := c.UseCase.AssignRoles(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to assign roles")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to assign roles", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Roles assigned successfully", response)
+}
+
+// RemoveRoles godoc
+// @Summary Remove roles from user
+// @Description Revoke one or more roles from a user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userId path string true "User ID"
+// @Param request body request.RemoveRolesRequest true "Remove roles request"
+// @Success 200 {object} model.SwaggerWebResponse "Roles removed successfully"
+// @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
+// @Failure 404 {object} model.SwaggerWebResponse "User not found"
+// @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
+// @Router /api/users/{userId}/roles [delete]
+func (c *UserController) RemoveRoles(ctx *fiber.Ctx) error {
+	request := new(request.RemoveRolesRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return utils.SendErrorResponse(ctx, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	request.UserID = ctx.Params("userId")
+	response, err := c.UseCase.RemoveRoles(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Warnf("Failed to remove roles")
+		status := fiber.StatusInternalServerError
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		return utils.SendErrorResponse(ctx, status, "Failed to remove roles", err.Error())
+	}
+
+	return utils.SendSuccessResponse(ctx, "Roles removed successfully", response)
+}
+
 // FindByRoleID godoc
 // @Summary Get users by role ID
 // @Description Get list of users by role ID
@@ -175,6 +1003,8 @@ func (c *UserController) Update(ctx *fiber.Ctx) error {
 // @Param roleId path string true "Role ID"
 // @Success 200 {object} model.SwaggerWebResponse "Users retrieved successfully"
 // @Failure 400 {object} model.SwaggerWebResponse "Bad request"
+// @Failure 401 {object} model.SwaggerWebResponse "Unauthorized"
+// @Failure 403 {object} model.SwaggerWebResponse "Forbidden"
 // @Failure 500 {object} model.SwaggerWebResponse "Internal server error"
 // @Router /api/users/roles/{roleId} [get]
 func (c *UserController) FindByRoleID(ctx *fiber.Ctx) error {
@@ -3,6 +3,7 @@ package middleware
 import (
 	"strings"
 
+	"mkp-boarding-test/internal/delivery/http/dto/request"
 	"mkp-boarding-test/internal/domain/usecase"
 	"mkp-boarding-test/internal/model"
 	"mkp-boarding-test/pkg/service"
@@ -12,8 +13,13 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func NewAuth(userUserCase usecase.UserUseCase, jwtService service.JWTService, log *logrus.Logger) fiber.Handler {
+func NewAuth(userUserCase usecase.UserUseCase, serviceAccountUseCase usecase.ServiceAccountUseCase, jwtService service.JWTService, requireVerifiedEmail bool, log *logrus.Logger) fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
+		// Service accounts authenticate with an API key instead of a bearer token
+		if apiKey := ctx.Get("X-API-Key", ""); apiKey != "" {
+			return authenticateAPIKey(ctx, serviceAccountUseCase, apiKey, log)
+		}
+
 		authorization := ctx.Get("Authorization", "")
 		if authorization == "" {
 			log.Warn("Authorization header is missing")
@@ -55,10 +61,64 @@ func NewAuth(userUserCase usecase.UserUseCase, jwtService service.JWTService, lo
 			return fiber.ErrUnauthorized
 		}
 
+		tokenID, ok := claims["token_id"].(string)
+		if !ok || tokenID == "" {
+			log.Warn("token_id not found in token claims")
+			return fiber.ErrUnauthorized
+		}
+
+		// Tokens issued before session tracking carry no session_id
+		sessionID, _ := claims["session_id"].(string)
+
+		// Impersonation tokens name the admin acting as the user in the act claim
+		var actorID, actorUsername string
+		if act, exists := claims["act"]; exists {
+			actor, ok := act.(map[string]interface{})
+			if ok {
+				actorID, _ = actor["user_id"].(string)
+				actorUsername, _ = actor["username"].(string)
+			}
+			if actorID == "" {
+				log.Warn("act claim without user_id in token claims")
+				return fiber.ErrUnauthorized
+			}
+		}
+
+		var tokenExpiresAt int64
+		if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+			tokenExpiresAt = exp.Unix()
+		}
+
+		// Reject revoked tokens and users that were removed or deactivated after the token was issued
+		user, err := userUserCase.Authenticate(ctx.UserContext(), &request.AuthenticateUserRequest{
+			UserID:    userID,
+			TokenID:   tokenID,
+			SessionID: sessionID,
+			ActorID:   actorID,
+		})
+		if err != nil {
+			log.Warnf("Failed to authenticate user : %+v", err)
+			if err == fiber.ErrInternalServerError {
+				return err
+			}
+			return fiber.ErrUnauthorized
+		}
+
+		if requireVerifiedEmail && !user.IsVerified {
+			log.Warnf("User %s has not verified the email address", userID)
+			return fiber.ErrForbidden
+		}
+
 		auth := &model.Auth{
-			ID:       userID,
-			Username: username,
-			Email:    email,
+			ID:               userID,
+			Username:         username,
+			Email:            email,
+			TokenID:          tokenID,
+			TokenExpiresAt:   tokenExpiresAt,
+			SessionID:        sessionID,
+			TwoFactorEnabled: user.TwoFactorEnabled,
+			ActorID:          actorID,
+			ActorUsername:    actorUsername,
 		}
 
 		log.Debugf("User : %+v", auth.ID)
@@ -67,6 +127,54 @@ func NewAuth(userUserCase usecase.UserUseCase, jwtService service.JWTService, lo
 	}
 }
 
+func authenticateAPIKey(ctx *fiber.Ctx, serviceAccountUseCase usecase.ServiceAccountUseCase, apiKey string, log *logrus.Logger) error {
+	principal, err := serviceAccountUseCase.AuthenticateAPIKey(ctx.UserContext(), &model.AuthenticateAPIKeyRequest{
+		Key:       apiKey,
+		IPAddress: ctx.IP(),
+	})
+	if err != nil {
+		log.Warnf("Failed to authenticate api key : %+v", err)
+		if err == fiber.ErrInternalServerError {
+			return err
+		}
+		return fiber.ErrUnauthorized
+	}
+
+	auth := &model.Auth{
+		ID:               principal.ServiceAccountID,
+		Username:         principal.ServiceAccountName,
+		ServiceAccountID: principal.ServiceAccountID,
+		APIKeyID:         principal.APIKeyID,
+	}
+
+	// The key scope replaces role based permissions, the permission middleware reads it from the request cache
+	permissions := service.NewPermissionSet(principal.Permissions)
+
+	log.Debugf("Service account : %+v", auth.ID)
+	ctx.Locals("auth", auth)
+	ctx.Locals("permissions", permissions)
+	return ctx.Next()
+}
+
+// RequireUser rejects service accounts on routes that only make sense for a human user, such as the `_current` routes
+func RequireUser(ctx *fiber.Ctx) error {
+	if GetUser(ctx).IsServiceAccount() {
+		return fiber.ErrForbidden
+	}
+	return ctx.Next()
+}
+
+// DenyImpersonation rejects impersonation tokens on routes that manage the credentials of the user, such as the
+// password and two-factor routes, and on the impersonation route itself
+func DenyImpersonation(ctx *fiber.Ctx) error {
+	if GetUser(ctx).IsImpersonated() {
+		return fiber.ErrForbidden
+	}
+	return ctx.Next()
+}
+
+// GetUser returns the authenticated principal. ID is the user the request acts as, while impersonating
+// ActorID is the admin who really sent it.
 func GetUser(ctx *fiber.Ctx) *model.Auth {
 	return ctx.Locals("auth").(*model.Auth)
 }
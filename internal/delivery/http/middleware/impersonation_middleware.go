@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"mkp-boarding-test/internal/delivery/http/dto/request"
+	"mkp-boarding-test/internal/domain/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// NewImpersonationAudit records every write request made with an impersonation token together with the admin who
+// sent it. The audit log is written before the request runs, a request that could not be recorded is rejected.
+func NewImpersonationAudit(userUseCase usecase.UserUseCase, log *logrus.Logger) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		auth := GetUser(ctx)
+		if !auth.IsImpersonated() || isReadMethod(ctx.Method()) {
+			return ctx.Next()
+		}
+
+		auditLogID, err := userUseCase.StartImpersonatedWrite(ctx.UserContext(), &request.StartImpersonatedWriteRequest{
+			ActorID:   auth.ActorID,
+			UserID:    auth.ID,
+			TokenID:   auth.TokenID,
+			Method:    ctx.Method(),
+			Path:      ctx.Path(),
+			IPAddress: ctx.IP(),
+		})
+		if err != nil {
+			log.Warnf("Failed to record impersonated request : %+v", err)
+			return fiber.ErrInternalServerError
+		}
+
+		err = ctx.Next()
+
+		status := ctx.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			if e, ok := err.(*fiber.Error); ok {
+				status = e.Code
+			}
+		}
+
+		if finishErr := userUseCase.FinishImpersonatedWrite(ctx.UserContext(), &request.FinishImpersonatedWriteRequest{
+			ID:         auditLogID,
+			StatusCode: status,
+		}); finishErr != nil {
+			log.Warnf("Failed to record status of impersonated request %s : %+v", auditLogID, finishErr)
+		}
+
+		return err
+	}
+}
+
+func isReadMethod(method string) bool {
+	switch method {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+		return true
+	}
+	return false
+}
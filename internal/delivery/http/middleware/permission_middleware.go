@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"mkp-boarding-test/internal/domain/usecase"
+	"mkp-boarding-test/pkg/service"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PermissionHandler builds a handler that requires the given `resource.action` permission
+type PermissionHandler func(permission string) fiber.Handler
+
+func NewPermission(permissionUseCase usecase.PermissionUseCase, twoFactorPolicy *service.TwoFactorPolicy, log *logrus.Logger) PermissionHandler {
+	return func(permission string) fiber.Handler {
+		return func(ctx *fiber.Ctx) error {
+			permissions, err := loadPermissions(ctx, permissionUseCase)
+			if err != nil {
+				log.WithError(err).Error("failed to resolve user permissions")
+				return fiber.ErrInternalServerError
+			}
+
+			// Accounts that must use two-factor authentication can not use any permission until they enrolled
+			auth := GetUser(ctx)
+			if !auth.IsServiceAccount() && !auth.TwoFactorEnabled && twoFactorPolicy.RequiresEnrollment(permissions.Grants()) {
+				log.Warnf("User %s must enable two-factor authentication", auth.ID)
+				return fiber.NewError(fiber.StatusForbidden, "two-factor authentication must be enabled for this account")
+			}
+
+			if !permissions.Allows(permission) {
+				log.Warnf("User %s is missing permission %s", GetUser(ctx).ID, permission)
+				return fiber.ErrForbidden
+			}
+
+			return ctx.Next()
+		}
+	}
+}
+
+// loadPermissions resolves the permissions of the authenticated user once per request, including the ones inherited
+// through parent roles. Wildcard grants are kept as they are and matched by PermissionSet.Allows.
+func loadPermissions(ctx *fiber.Ctx, permissionUseCase usecase.PermissionUseCase) (service.PermissionSet, error) {
+	if permissions, ok := ctx.Locals("permissions").(service.PermissionSet); ok {
+		return permissions, nil
+	}
+
+	names, err := permissionUseCase.FindNamesByUserID(ctx.UserContext(), GetUser(ctx).ID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := service.NewPermissionSet(names)
+	ctx.Locals("permissions", permissions)
+	return permissions, nil
+}
+
+func GetPermissions(ctx *fiber.Ctx) service.PermissionSet {
+	permissions, _ := ctx.Locals("permissions").(service.PermissionSet)
+	return permissions
+}
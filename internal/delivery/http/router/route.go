@@ -3,20 +3,31 @@ package route
 import (
 	"mkp-boarding-test/docs"
 	"mkp-boarding-test/internal/delivery/http/handler"
+	"mkp-boarding-test/internal/delivery/http/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
 )
 
 type RouteConfig struct {
-	App                  *fiber.App
-	UserController       *handler.UserController
-	RoleController       *handler.RoleController
-	PermissionController *handler.PermissionController
-	OperatorController   *handler.OperatorController
-	ShipController       *handler.ShipController
-	HarborController     *handler.HarborController
-	AuthMiddleware       fiber.Handler
+	App                         *fiber.App
+	UserController              *handler.UserController
+	RoleController              *handler.RoleController
+	PermissionController        *handler.PermissionController
+	OperatorController          *handler.OperatorController
+	ShipController              *handler.ShipController
+	HarborController            *handler.HarborController
+	BoardingController          *handler.BoardingController
+	ChecklistTemplateController *handler.ChecklistTemplateController
+	DeficiencyController        *handler.DeficiencyController
+	PortCallController          *handler.PortCallController
+	BerthController             *handler.BerthController
+	ServiceAccountController    *handler.ServiceAccountController
+	JWKSController              *handler.JWKSController
+	AuthzController             *handler.AuthzController
+	AuthMiddleware              fiber.Handler
+	ImpersonationMiddleware     fiber.Handler
+	PermissionMiddleware        middleware.PermissionHandler
 }
 
 func (c *RouteConfig) Setup() {
@@ -36,52 +47,145 @@ func (c *RouteConfig) SetupSwaggerRoute() {
 func (c *RouteConfig) SetupGuestRoute() {
 	c.App.Post("/register", c.UserController.Register)
 	c.App.Post("/login", c.UserController.Login)
+	c.App.Post("/login/2fa", c.UserController.LoginTwoFactor)
+	c.App.Get("/oidc/login", c.UserController.OIDCLogin)
+	c.App.Get("/oidc/callback", c.UserController.OIDCCallback)
+	c.App.Post("/token/refresh", c.UserController.Refresh)
+	c.App.Post("/verify-email", c.UserController.VerifyEmail)
+	c.App.Post("/verify-email/resend", c.UserController.ResendVerification)
+	c.App.Post("/password/forgot", c.UserController.ForgotPassword)
+	c.App.Post("/password/reset", c.UserController.ResetPassword)
+	c.App.Get("/.well-known/jwks.json", c.JWKSController.Get)
 }
 
 func (c *RouteConfig) SetupAuthRoute() {
-	// Create API group with auth middleware
-	api := c.App.Group("/api", c.AuthMiddleware)
+	// Create API group with auth middleware, writes made while impersonating are audited
+	api := c.App.Group("/api", c.AuthMiddleware, c.ImpersonationMiddleware)
 
 	// User routes
-	api.Delete("/users", c.UserController.Logout)
-	api.Patch("/users/_current", c.UserController.Update)
-	api.Get("/users/_current", c.UserController.Current)
-	api.Get("/users/roles/:roleId", c.UserController.FindByRoleID)
+	api.Delete("/users", middleware.RequireUser, c.UserController.Logout)
+	api.Patch("/users/_current", middleware.RequireUser, middleware.DenyImpersonation, c.UserController.Update)
+	api.Get("/users/_current", middleware.RequireUser, c.UserController.Current)
+	api.Patch("/users/_current/password", middleware.RequireUser, middleware.DenyImpersonation, c.UserController.ChangePassword)
+	api.Post("/users/_current/2fa", middleware.RequireUser, middleware.DenyImpersonation, c.UserController.EnrollTwoFactor)
+	api.Post("/users/_current/2fa/confirm", middleware.RequireUser, middleware.DenyImpersonation, c.UserController.ConfirmTwoFactor)
+	api.Delete("/users/_current/2fa", middleware.RequireUser, middleware.DenyImpersonation, c.UserController.DisableTwoFactor)
+	api.Post("/users/_current/2fa/recovery-codes", middleware.RequireUser, middleware.DenyImpersonation, c.UserController.RegenerateRecoveryCodes)
+	api.Get("/users/_current/sessions", middleware.RequireUser, c.UserController.ListSessions)
+	api.Get("/users/_current/permissions", middleware.RequireUser, c.AuthzController.CurrentPermissions)
+	api.Delete("/users/_current/sessions/:sessionId", middleware.RequireUser, middleware.DenyImpersonation, c.UserController.RevokeSession)
+	api.Get("/users/roles/:roleId", c.PermissionMiddleware("user.index"), c.UserController.FindByRoleID)
+	api.Get("/users", c.PermissionMiddleware("user.index"), c.UserController.List)
+	api.Get("/users/:userId", c.PermissionMiddleware("user.index"), c.UserController.Get)
+	api.Patch("/users/:userId", c.PermissionMiddleware("user.update"), c.UserController.AdminUpdate)
+	api.Delete("/users/:userId", c.PermissionMiddleware("user.destroy"), c.UserController.Delete)
+	api.Post("/users/:userId/impersonate", middleware.RequireUser, middleware.DenyImpersonation, c.PermissionMiddleware("user.impersonate"), c.UserController.Impersonate)
+	api.Post("/users/:userId/unlock", c.PermissionMiddleware("user.update"), c.UserController.Unlock)
+	api.Delete("/users/:userId/2fa", c.PermissionMiddleware("user.update"), c.UserController.ResetTwoFactor)
+	api.Post("/users/:userId/roles", c.PermissionMiddleware("user.update"), c.UserController.AssignRoles)
+	api.Delete("/users/:userId/roles", c.PermissionMiddleware("user.update"), c.UserController.RemoveRoles)
 
 	// Role routes
-	api.Get("/roles", c.RoleController.List)
-	api.Post("/roles", c.RoleController.Create)
-	api.Put("/roles/:roleId", c.RoleController.Update)
-	api.Get("/roles/:roleId", c.RoleController.Get)
-	api.Delete("/roles/:roleId", c.RoleController.Delete)
-	api.Post("/roles/:roleId/permissions", c.RoleController.AssignPermissions)
-	api.Delete("/roles/:roleId/permissions", c.RoleController.RemovePermissions)
+	api.Get("/roles", c.PermissionMiddleware("role.index"), c.RoleController.List)
+	api.Post("/roles", c.PermissionMiddleware("role.store"), c.RoleController.Create)
+	api.Put("/roles/:roleId", c.PermissionMiddleware("role.update"), c.RoleController.Update)
+	api.Get("/roles/:roleId", c.PermissionMiddleware("role.index"), c.RoleController.Get)
+	api.Get("/roles/:roleId/effective-permissions", c.PermissionMiddleware("role.index"), c.RoleController.GetEffectivePermissions)
+	api.Delete("/roles/:roleId", c.PermissionMiddleware("role.destroy"), c.RoleController.Delete)
+	api.Post("/roles/:roleId/permissions", c.PermissionMiddleware("role.update"), c.RoleController.AssignPermissions)
+	api.Delete("/roles/:roleId/permissions", c.PermissionMiddleware("role.update"), c.RoleController.RemovePermissions)
+	api.Put("/roles/:roleId/permissions", c.PermissionMiddleware("role.update"), c.RoleController.SetPermissions)
+	api.Post("/roles/:roleId/harbors", c.PermissionMiddleware("role.update"), c.RoleController.AssignHarbors)
+	api.Delete("/roles/:roleId/harbors", c.PermissionMiddleware("role.update"), c.RoleController.RemoveHarbors)
 
 	// Permission routes
-	api.Get("/permissions", c.PermissionController.List)
-	api.Post("/permissions", c.PermissionController.Create)
-	api.Put("/permissions/:permissionId", c.PermissionController.Update)
-	api.Get("/permissions/:permissionId", c.PermissionController.Get)
-	api.Delete("/permissions/:permissionId", c.PermissionController.Delete)
+	api.Get("/permissions", c.PermissionMiddleware("permission.index"), c.PermissionController.List)
+	api.Post("/permissions", c.PermissionMiddleware("permission.store"), c.PermissionController.Create)
+	api.Put("/permissions/:permissionId", c.PermissionMiddleware("permission.update"), c.PermissionController.Update)
+	api.Get("/permissions/:permissionId", c.PermissionMiddleware("permission.index"), c.PermissionController.Get)
+	api.Delete("/permissions/:permissionId", c.PermissionMiddleware("permission.destroy"), c.PermissionController.Delete)
 
 	// Operator routes
-	api.Get("/operators", c.OperatorController.List)
-	api.Post("/operators", c.OperatorController.Create)
-	api.Put("/operators/:operatorId", c.OperatorController.Update)
-	api.Get("/operators/:operatorId", c.OperatorController.Get)
-	api.Delete("/operators/:operatorId", c.OperatorController.Delete)
+	api.Get("/operators", c.PermissionMiddleware("operator.index"), c.OperatorController.List)
+	api.Post("/operators", middleware.RequireUser, c.PermissionMiddleware("operator.store"), c.OperatorController.Create)
+	api.Put("/operators/:operatorId", c.PermissionMiddleware("operator.update"), c.OperatorController.Update)
+	api.Get("/operators/:operatorId", c.PermissionMiddleware("operator.index"), c.OperatorController.Get)
+	api.Delete("/operators/:operatorId", c.PermissionMiddleware("operator.destroy"), c.OperatorController.Delete)
 
 	// Ship routes
-	api.Get("/ships", c.ShipController.List)
-	api.Post("/ships", c.ShipController.Create)
-	api.Put("/ships/:shipId", c.ShipController.Update)
-	api.Get("/ships/:shipId", c.ShipController.Get)
-	api.Delete("/ships/:shipId", c.ShipController.Delete)
+	api.Get("/ships", c.PermissionMiddleware("ship.index"), c.ShipController.List)
+	api.Post("/ships", c.PermissionMiddleware("ship.store"), c.ShipController.Create)
+	api.Put("/ships/:shipId", c.PermissionMiddleware("ship.update"), c.ShipController.Update)
+	api.Get("/ships/:shipId", c.PermissionMiddleware("ship.index"), c.ShipController.Get)
+	api.Delete("/ships/:shipId", c.PermissionMiddleware("ship.destroy"), c.ShipController.Delete)
+	api.Get("/ships/:shipId/deficiencies", c.PermissionMiddleware("deficiency.index"), c.DeficiencyController.ListByShip)
 
 	// Harbor routes
-	api.Get("/harbors", c.HarborController.List)
-	api.Post("/harbors", c.HarborController.Create)
-	api.Put("/harbors/:harborId", c.HarborController.Update)
-	api.Get("/harbors/:harborId", c.HarborController.Get)
-	api.Delete("/harbors/:harborId", c.HarborController.Delete)
+	api.Get("/harbors", c.PermissionMiddleware("harbor.index"), c.HarborController.List)
+	api.Post("/harbors", c.PermissionMiddleware("harbor.store"), c.HarborController.Create)
+	api.Put("/harbors/:harborId", c.PermissionMiddleware("harbor.update"), c.HarborController.Update)
+	api.Get("/harbors/:harborId", c.PermissionMiddleware("harbor.index"), c.HarborController.Get)
+	api.Delete("/harbors/:harborId", c.PermissionMiddleware("harbor.destroy"), c.HarborController.Delete)
+	api.Get("/harbors/:harborId/port-calls", c.PermissionMiddleware("port_call.index"), c.PortCallController.ListByHarbor)
+	api.Get("/harbors/:harborId/berths", c.PermissionMiddleware("berth.index"), c.BerthController.ListByHarbor)
+	api.Post("/harbors/:harborId/berths", c.PermissionMiddleware("berth.store"), c.BerthController.Create)
+
+	// Boarding routes, answering the checklist stores the acting user and rejects service accounts
+	api.Get("/boardings", c.PermissionMiddleware("boarding.conduct"), c.BoardingController.List)
+	api.Post("/boardings", c.PermissionMiddleware("boarding.conduct"), c.BoardingController.Create)
+	api.Put("/boardings/:boardingId", c.PermissionMiddleware("boarding.conduct"), c.BoardingController.Update)
+	api.Get("/boardings/:boardingId", c.PermissionMiddleware("boarding.conduct"), c.BoardingController.Get)
+	api.Delete("/boardings/:boardingId", c.PermissionMiddleware("boarding.conduct"), c.BoardingController.Delete)
+	api.Post("/boardings/:boardingId/start", c.PermissionMiddleware("boarding.conduct"), c.BoardingController.Start)
+	api.Post("/boardings/:boardingId/complete", c.PermissionMiddleware("boarding.conduct"), c.BoardingController.Complete)
+	api.Post("/boardings/:boardingId/abort", c.PermissionMiddleware("boarding.conduct"), c.BoardingController.Abort)
+	api.Get("/boardings/:boardingId/checklist", c.PermissionMiddleware("boarding.conduct"), c.BoardingController.GetChecklist)
+	api.Put("/boardings/:boardingId/checklist", middleware.RequireUser, c.PermissionMiddleware("boarding.conduct"), c.BoardingController.AnswerChecklist)
+	api.Get("/boardings/:boardingId/deficiencies", c.PermissionMiddleware("boarding.conduct"), c.DeficiencyController.ListByBoarding)
+	api.Post("/boardings/:boardingId/deficiencies", middleware.RequireUser, c.PermissionMiddleware("boarding.conduct"), c.DeficiencyController.Create)
+
+	// Deficiency routes, inspectors correct, verify and reopen deficiencies while operators report the corrective action.
+	// Recording, rectifying, verifying and reopening store the acting user, service accounts are rejected there
+	api.Get("/deficiencies/:deficiencyId", c.PermissionMiddleware("deficiency.index"), c.DeficiencyController.Get)
+	api.Put("/deficiencies/:deficiencyId", c.PermissionMiddleware("boarding.conduct"), c.DeficiencyController.Update)
+	api.Post("/deficiencies/:deficiencyId/rectify", middleware.RequireUser, c.PermissionMiddleware("deficiency.rectify"), c.DeficiencyController.Rectify)
+	api.Post("/deficiencies/:deficiencyId/verify", middleware.RequireUser, c.PermissionMiddleware("boarding.conduct"), c.DeficiencyController.Verify)
+	api.Post("/deficiencies/:deficiencyId/reopen", middleware.RequireUser, c.PermissionMiddleware("boarding.conduct"), c.DeficiencyController.Reopen)
+
+	// Port call routes, operators declare the visits of their ships and harbor staff record arrivals and departures.
+	// Declaring a call and allocating a berth store the acting user, service accounts are rejected there
+	api.Post("/port-calls", middleware.RequireUser, c.PermissionMiddleware("port_call.store"), c.PortCallController.Create)
+	api.Put("/port-calls/:portCallId", c.PermissionMiddleware("port_call.update"), c.PortCallController.Update)
+	api.Get("/port-calls/:portCallId", c.PermissionMiddleware("port_call.index"), c.PortCallController.Get)
+	api.Post("/port-calls/:portCallId/arrive", c.PermissionMiddleware("port_call.update"), c.PortCallController.Arrive)
+	api.Post("/port-calls/:portCallId/close", c.PermissionMiddleware("port_call.update"), c.PortCallController.Close)
+	api.Get("/port-calls/:portCallId/berth-allocations", c.PermissionMiddleware("berth.index"), c.BerthController.ListAllocationsByPortCall)
+	api.Post("/port-calls/:portCallId/berth-allocations", middleware.RequireUser, c.PermissionMiddleware("berth.allocate"), c.BerthController.Allocate)
+	api.Get("/port-calls/:portCallId/berth-suggestions", c.PermissionMiddleware("berth.index"), c.BerthController.Suggest)
+
+	// Berth routes, harbor staff keep the berth registry and allocate berths to port calls
+	api.Put("/berths/:berthId", c.PermissionMiddleware("berth.update"), c.BerthController.Update)
+	api.Get("/berths/:berthId", c.PermissionMiddleware("berth.index"), c.BerthController.Get)
+	api.Delete("/berths/:berthId", c.PermissionMiddleware("berth.destroy"), c.BerthController.Delete)
+	api.Get("/berths/:berthId/allocations", c.PermissionMiddleware("berth.index"), c.BerthController.ListAllocationsByBerth)
+	api.Delete("/berth-allocations/:allocationId", c.PermissionMiddleware("berth.allocate"), c.BerthController.Release)
+
+	// Checklist template routes
+	api.Get("/checklist-templates", c.PermissionMiddleware("checklist.index"), c.ChecklistTemplateController.List)
+	api.Post("/checklist-templates", c.PermissionMiddleware("checklist.store"), c.ChecklistTemplateController.Create)
+	api.Put("/checklist-templates/:templateId", c.PermissionMiddleware("checklist.update"), c.ChecklistTemplateController.Update)
+	api.Get("/checklist-templates/:templateId", c.PermissionMiddleware("checklist.index"), c.ChecklistTemplateController.Get)
+	api.Delete("/checklist-templates/:templateId", c.PermissionMiddleware("checklist.destroy"), c.ChecklistTemplateController.Delete)
+
+	// Service account routes, only users may manage service accounts and issue API keys
+	api.Get("/service-accounts", middleware.RequireUser, c.PermissionMiddleware("service_account.index"), c.ServiceAccountController.List)
+	api.Post("/service-accounts", middleware.RequireUser, c.PermissionMiddleware("service_account.store"), c.ServiceAccountController.Create)
+	api.Get("/service-accounts/:serviceAccountId", middleware.RequireUser, c.PermissionMiddleware("service_account.index"), c.ServiceAccountController.Get)
+	api.Delete("/service-accounts/:serviceAccountId", middleware.RequireUser, c.PermissionMiddleware("service_account.destroy"), c.ServiceAccountController.Delete)
+	api.Get("/service-accounts/:serviceAccountId/keys", middleware.RequireUser, c.PermissionMiddleware("service_account.index"), c.ServiceAccountController.ListAPIKeys)
+	api.Post("/service-accounts/:serviceAccountId/keys", middleware.RequireUser, c.PermissionMiddleware("service_account.update"), c.ServiceAccountController.CreateAPIKey)
+	api.Delete("/service-accounts/:serviceAccountId/keys/:keyId", middleware.RequireUser, c.PermissionMiddleware("service_account.update"), c.ServiceAccountController.RevokeAPIKey)
+
+	// Authorization routes
+	api.Post("/authz/check", c.PermissionMiddleware("authz.check"), c.AuthzController.Check)
 }
@@ -0,0 +1,25 @@
+package entity
+
+// APIKey is a struct that represents a hashed API key of a service account
+type APIKey struct {
+	ID               string  `gorm:"column:id;primaryKey"`
+	ServiceAccountID string  `gorm:"column:service_account_id"`
+	Name             string  `gorm:"column:name"`
+	Prefix           string  `gorm:"column:prefix;uniqueIndex"`
+	KeyHash          string  `gorm:"column:key_hash"`
+	ExpiresAt        *int64  `gorm:"column:expires_at"`
+	LastUsedAt       *int64  `gorm:"column:last_used_at"`
+	LastUsedIP       *string `gorm:"column:last_used_ip"`
+	RevokedAt        *int64  `gorm:"column:revoked_at"`
+	CreatedBy        *string `gorm:"column:created_by"`
+	CreatedAt        int64   `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt        int64   `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+
+	// Relations
+	ServiceAccount    ServiceAccount     `gorm:"foreignKey:service_account_id;references:id"`
+	APIKeyPermissions []APIKeyPermission `gorm:"foreignKey:api_key_id;references:id"`
+}
+
+func (ak *APIKey) TableName() string {
+	return "api_keys"
+}
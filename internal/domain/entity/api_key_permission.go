@@ -0,0 +1,16 @@
+package entity
+
+// APIKeyPermission is a struct that represents an api_key_permission junction entity
+type APIKeyPermission struct {
+	APIKeyID     string `gorm:"column:api_key_id;primaryKey"`
+	PermissionID string `gorm:"column:permission_id;primaryKey"`
+	CreatedAt    int64  `gorm:"column:created_at;autoCreateTime:milli"`
+
+	// Relations
+	APIKey     APIKey     `gorm:"foreignKey:api_key_id;references:id"`
+	Permission Permission `gorm:"foreignKey:permission_id;references:id"`
+}
+
+func (akp *APIKeyPermission) TableName() string {
+	return "api_key_permissions"
+}
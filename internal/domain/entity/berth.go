@@ -0,0 +1,22 @@
+package entity
+
+// Berth is a struct that represents a mooring place of a harbor with the length and depth of water it offers
+type Berth struct {
+	ID        string  `gorm:"column:id;primaryKey"`
+	HarborID  string  `gorm:"column:harbor_id"`
+	Code      string  `gorm:"column:code"`
+	Name      string  `gorm:"column:name"`
+	Length    float64 `gorm:"column:length"`
+	Depth     float64 `gorm:"column:depth"`
+	IsActive  bool    `gorm:"column:is_active;default:true"`
+	Notes     *string `gorm:"column:notes"`
+	CreatedAt int64   `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt int64   `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+
+	// Relations
+	ShipTypes []BerthShipType `gorm:"foreignKey:berth_id;references:id"`
+}
+
+func (b *Berth) TableName() string {
+	return "berths"
+}
@@ -0,0 +1,22 @@
+package entity
+
+// BerthAllocation is a struct that represents the reservation of a berth for a port call during a time window
+type BerthAllocation struct {
+	ID          string  `gorm:"column:id;primaryKey"`
+	BerthID     string  `gorm:"column:berth_id"`
+	PortCallID  string  `gorm:"column:port_call_id"`
+	StartsAt    int64   `gorm:"column:starts_at"`
+	EndsAt      int64   `gorm:"column:ends_at"`
+	Remarks     *string `gorm:"column:remarks"`
+	AllocatedBy string  `gorm:"column:allocated_by"`
+	CreatedAt   int64   `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt   int64   `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+
+	// Relations
+	Berth    Berth    `gorm:"foreignKey:berth_id;references:id"`
+	PortCall PortCall `gorm:"foreignKey:port_call_id;references:id"`
+}
+
+func (ba *BerthAllocation) TableName() string {
+	return "berth_allocations"
+}
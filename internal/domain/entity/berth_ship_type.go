@@ -0,0 +1,12 @@
+package entity
+
+// BerthShipType is a struct that represents a ship type a berth is allowed to take
+type BerthShipType struct {
+	BerthID   string `gorm:"column:berth_id;primaryKey"`
+	ShipType  string `gorm:"column:ship_type;primaryKey"`
+	CreatedAt int64  `gorm:"column:created_at;autoCreateTime:milli"`
+}
+
+func (bst *BerthShipType) TableName() string {
+	return "berth_ship_types"
+}
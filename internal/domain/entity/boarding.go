@@ -0,0 +1,48 @@
+package entity
+
+// Boarding lifecycle: planned -> in_progress -> completed or aborted, a planned boarding may be aborted as well
+const (
+	BoardingStatusPlanned    = "planned"
+	BoardingStatusInProgress = "in_progress"
+	BoardingStatusCompleted  = "completed"
+	BoardingStatusAborted    = "aborted"
+)
+
+// BoardingLifecycle lists the statuses a boarding may move to, completed and aborted are final
+var BoardingLifecycle = Lifecycle{
+	Record: "boarding",
+	Transitions: map[string][]string{
+		BoardingStatusPlanned:    {BoardingStatusInProgress, BoardingStatusAborted},
+		BoardingStatusInProgress: {BoardingStatusCompleted, BoardingStatusAborted},
+	},
+}
+
+// Boarding is a struct that represents the inspection of a ship in a harbor by an inspecting user
+type Boarding struct {
+	ID          string `gorm:"column:id;primaryKey"`
+	ShipID      string `gorm:"column:ship_id"`
+	HarborID    string `gorm:"column:harbor_id"`
+	InspectorID string `gorm:"column:inspector_id"`
+	// ChecklistTemplateID is the checklist version chosen by ship type when the boarding started
+	ChecklistTemplateID *string  `gorm:"column:checklist_template_id"`
+	Status              string   `gorm:"column:status;default:planned"`
+	PlannedAt           int64    `gorm:"column:planned_at"`
+	StartedAt           *int64   `gorm:"column:started_at"`
+	CompletedAt         *int64   `gorm:"column:completed_at"`
+	AbortedAt           *int64   `gorm:"column:aborted_at"`
+	Latitude            *float64 `gorm:"column:latitude"`
+	Longitude           *float64 `gorm:"column:longitude"`
+	Outcome             *string  `gorm:"column:outcome"`
+	Remarks             *string  `gorm:"column:remarks"`
+	CreatedAt           int64    `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt           int64    `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+
+	// Relations
+	Ship      Ship   `gorm:"foreignKey:ship_id;references:id"`
+	Harbor    Harbor `gorm:"foreignKey:harbor_id;references:id"`
+	Inspector User   `gorm:"foreignKey:inspector_id;references:id"`
+}
+
+func (b *Boarding) TableName() string {
+	return "boardings"
+}
@@ -0,0 +1,18 @@
+package entity
+
+// BoardingChecklistAnswer is a struct that represents the answer of an inspector to a checklist item during a boarding
+type BoardingChecklistAnswer struct {
+	ID         string  `gorm:"column:id;primaryKey"`
+	BoardingID string  `gorm:"column:boarding_id"`
+	ItemID     string  `gorm:"column:item_id"`
+	Value      *string `gorm:"column:value"`
+	PhotoURL   *string `gorm:"column:photo_url"`
+	Remarks    *string `gorm:"column:remarks"`
+	AnsweredBy string  `gorm:"column:answered_by"`
+	CreatedAt  int64   `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt  int64   `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (bca *BoardingChecklistAnswer) TableName() string {
+	return "boarding_checklist_answers"
+}
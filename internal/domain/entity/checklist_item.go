@@ -0,0 +1,17 @@
+package entity
+
+// ChecklistItem is a struct that represents a question of a checklist section and the kind of answer it expects
+type ChecklistItem struct {
+	ID         string  `gorm:"column:id;primaryKey"`
+	SectionID  string  `gorm:"column:section_id"`
+	Prompt     string  `gorm:"column:prompt"`
+	Guidance   *string `gorm:"column:guidance"`
+	AnswerType string  `gorm:"column:answer_type"`
+	IsRequired bool    `gorm:"column:is_required"`
+	Position   int     `gorm:"column:position"`
+	CreatedAt  int64   `gorm:"column:created_at;autoCreateTime:milli"`
+}
+
+func (ci *ChecklistItem) TableName() string {
+	return "checklist_items"
+}
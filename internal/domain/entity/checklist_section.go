@@ -0,0 +1,17 @@
+package entity
+
+// ChecklistSection is a struct that represents a titled group of items in a checklist template
+type ChecklistSection struct {
+	ID         string `gorm:"column:id;primaryKey"`
+	TemplateID string `gorm:"column:template_id"`
+	Title      string `gorm:"column:title"`
+	Position   int    `gorm:"column:position"`
+	CreatedAt  int64  `gorm:"column:created_at;autoCreateTime:milli"`
+
+	// Relations
+	Items []ChecklistItem `gorm:"foreignKey:section_id;references:id"`
+}
+
+func (cs *ChecklistSection) TableName() string {
+	return "checklist_sections"
+}
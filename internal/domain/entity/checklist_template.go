@@ -0,0 +1,20 @@
+package entity
+
+// ChecklistTemplate is a struct that represents a version of the inspection checklist for a ship type
+type ChecklistTemplate struct {
+	ID          string  `gorm:"column:id;primaryKey"`
+	ShipType    string  `gorm:"column:ship_type"`
+	Version     int     `gorm:"column:version"`
+	Name        string  `gorm:"column:name"`
+	Description *string `gorm:"column:description"`
+	IsActive    bool    `gorm:"column:is_active;default:true"`
+	CreatedAt   int64   `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt   int64   `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+
+	// Relations
+	Sections []ChecklistSection `gorm:"foreignKey:template_id;references:id"`
+}
+
+func (ct *ChecklistTemplate) TableName() string {
+	return "checklist_templates"
+}
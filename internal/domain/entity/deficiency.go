@@ -0,0 +1,46 @@
+package entity
+
+// Deficiency follow-up: open -> rectified -> verified, a rectification the inspector does not accept sends the
+// deficiency back to open. Overdue is not stored, an open deficiency past its deadline is reported as overdue when it
+// is read and can still be rectified
+const (
+	DeficiencyStatusOpen      = "open"
+	DeficiencyStatusRectified = "rectified"
+	DeficiencyStatusVerified  = "verified"
+	DeficiencyStatusOverdue   = "overdue"
+)
+
+// DeficiencyLifecycle lists the statuses a deficiency may move to from each stored status, verified is final
+var DeficiencyLifecycle = Lifecycle{
+	Record: "deficiency",
+	Transitions: map[string][]string{
+		DeficiencyStatusOpen:      {DeficiencyStatusRectified},
+		DeficiencyStatusRectified: {DeficiencyStatusVerified, DeficiencyStatusOpen},
+	},
+}
+
+// Deficiency is a struct that represents a finding of a boarding and the follow-up of its corrective action
+type Deficiency struct {
+	ID                 string  `gorm:"column:id;primaryKey"`
+	BoardingID         string  `gorm:"column:boarding_id"`
+	ShipID             string  `gorm:"column:ship_id"`
+	Code               string  `gorm:"column:code"`
+	Severity           string  `gorm:"column:severity"`
+	Description        string  `gorm:"column:description"`
+	Deadline           *int64  `gorm:"column:deadline"`
+	IsDetainable       bool    `gorm:"column:is_detainable"`
+	Status             string  `gorm:"column:status;default:open"`
+	RectifiedAt        *int64  `gorm:"column:rectified_at"`
+	RectifiedBy        *string `gorm:"column:rectified_by"`
+	RectificationNotes *string `gorm:"column:rectification_notes"`
+	VerifiedAt         *int64  `gorm:"column:verified_at"`
+	VerifiedBy         *string `gorm:"column:verified_by"`
+	VerificationNotes  *string `gorm:"column:verification_notes"`
+	CreatedBy          string  `gorm:"column:created_by"`
+	CreatedAt          int64   `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt          int64   `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (d *Deficiency) TableName() string {
+	return "deficiencies"
+}
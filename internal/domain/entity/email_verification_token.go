@@ -0,0 +1,17 @@
+package entity
+
+// EmailVerificationToken is a struct that represents a pending email verification, identified by the hash of the token
+type EmailVerificationToken struct {
+	ID        string `gorm:"column:id;primaryKey"`
+	UserID    string `gorm:"column:user_id"`
+	TokenHash string `gorm:"column:token_hash;uniqueIndex"`
+	ExpiresAt int64  `gorm:"column:expires_at"`
+	CreatedAt int64  `gorm:"column:created_at;autoCreateTime:milli"`
+
+	// Relations
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
+func (evt *EmailVerificationToken) TableName() string {
+	return "email_verification_tokens"
+}
@@ -0,0 +1,21 @@
+package entity
+
+// ImpersonationAuditLog is a struct that represents the start of an impersonation or a write request made while
+// an admin impersonated a user
+type ImpersonationAuditLog struct {
+	ID         string  `gorm:"column:id;primaryKey"`
+	ActorID    string  `gorm:"column:actor_id"`
+	UserID     string  `gorm:"column:user_id"`
+	TokenID    string  `gorm:"column:token_id"`
+	Method     string  `gorm:"column:method"`
+	Path       string  `gorm:"column:path"`
+	Reason     *string `gorm:"column:reason"`
+	IPAddress  *string `gorm:"column:ip_address"`
+	StatusCode *int    `gorm:"column:status_code"`
+	CreatedAt  int64   `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt  int64   `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (ial *ImpersonationAuditLog) TableName() string {
+	return "impersonation_audit_logs"
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "fmt"
+
+// Lifecycle lists the statuses a record may move to from each status, a status without any is final
+type Lifecycle struct {
+	Record      string
+	Transitions map[string][]string
+}
+
+// Check returns an error when a record in status from may not move to status to
+func (l Lifecycle) Check(from string, to string) error {
+	for _, next := range l.Transitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("a %s %s can not become %s", from, l.Record, to)
+}
+
+// IsFinal reports whether a record in the status can no longer move
+func (l Lifecycle) IsFinal(status string) bool {
+	return len(l.Transitions[status]) == 0
+}
@@ -0,0 +1,14 @@
+package entity
+
+// LoginAttempt is a struct that represents the failed login counter of an account or a client address
+type LoginAttempt struct {
+	Key          string `gorm:"column:attempt_key;primaryKey"`
+	FailedCount  int    `gorm:"column:failed_count"`
+	LastFailedAt int64  `gorm:"column:last_failed_at"`
+	LockedUntil  int64  `gorm:"column:locked_until"`
+	UpdatedAt    int64  `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (la *LoginAttempt) TableName() string {
+	return "login_attempts"
+}
@@ -0,0 +1,15 @@
+package entity
+
+// OIDCLoginState is a struct that represents a started OpenID Connect login, identified by the hash of the state parameter
+type OIDCLoginState struct {
+	ID           string `gorm:"column:id;primaryKey"`
+	StateHash    string `gorm:"column:state_hash;uniqueIndex"`
+	Nonce        string `gorm:"column:nonce"`
+	CodeVerifier string `gorm:"column:code_verifier"`
+	ExpiresAt    int64  `gorm:"column:expires_at"`
+	CreatedAt    int64  `gorm:"column:created_at;autoCreateTime:milli"`
+}
+
+func (ols *OIDCLoginState) TableName() string {
+	return "oidc_login_states"
+}
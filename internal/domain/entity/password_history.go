@@ -0,0 +1,16 @@
+package entity
+
+// PasswordHistory is a struct that represents a password a user has set, stored as bcrypt hash
+type PasswordHistory struct {
+	ID           string `gorm:"column:id;primaryKey"`
+	UserID       string `gorm:"column:user_id"`
+	PasswordHash string `gorm:"column:password_hash"`
+	CreatedAt    int64  `gorm:"column:created_at;autoCreateTime:milli"`
+
+	// Relations
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
+func (ph *PasswordHistory) TableName() string {
+	return "password_history"
+}
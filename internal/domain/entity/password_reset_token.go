@@ -0,0 +1,17 @@
+package entity
+
+// PasswordResetToken is a struct that represents a pending password reset, identified by the hash of the token
+type PasswordResetToken struct {
+	ID        string `gorm:"column:id;primaryKey"`
+	UserID    string `gorm:"column:user_id"`
+	TokenHash string `gorm:"column:token_hash;uniqueIndex"`
+	ExpiresAt int64  `gorm:"column:expires_at"`
+	CreatedAt int64  `gorm:"column:created_at;autoCreateTime:milli"`
+
+	// Relations
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
+func (prt *PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
@@ -0,0 +1,45 @@
+package entity
+
+// Port call lifecycle: expected -> in_port -> departed, an expected call that is closed is cancelled
+const (
+	PortCallStatusExpected  = "expected"
+	PortCallStatusInPort    = "in_port"
+	PortCallStatusDeparted  = "departed"
+	PortCallStatusCancelled = "cancelled"
+)
+
+// PortCallLifecycle lists the statuses a port call may move to, departed and cancelled are final
+var PortCallLifecycle = Lifecycle{
+	Record: "port call",
+	Transitions: map[string][]string{
+		PortCallStatusExpected: {PortCallStatusInPort, PortCallStatusCancelled},
+		PortCallStatusInPort:   {PortCallStatusDeparted},
+	},
+}
+
+// PortCall is a struct that represents the visit of a ship to a harbor with its estimated and actual times
+type PortCall struct {
+	ID           string  `gorm:"column:id;primaryKey"`
+	ShipID       string  `gorm:"column:ship_id"`
+	HarborID     string  `gorm:"column:harbor_id"`
+	Status       string  `gorm:"column:status;default:expected"`
+	ETA          int64   `gorm:"column:eta"`
+	ETD          *int64  `gorm:"column:etd"`
+	ATA          *int64  `gorm:"column:ata"`
+	ATD          *int64  `gorm:"column:atd"`
+	PreviousPort *string `gorm:"column:previous_port"`
+	NextPort     *string `gorm:"column:next_port"`
+	Purpose      string  `gorm:"column:purpose"`
+	Remarks      *string `gorm:"column:remarks"`
+	DeclaredBy   string  `gorm:"column:declared_by"`
+	CreatedAt    int64   `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt    int64   `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+
+	// Relations
+	Ship   Ship   `gorm:"foreignKey:ship_id;references:id"`
+	Harbor Harbor `gorm:"foreignKey:harbor_id;references:id"`
+}
+
+func (pc *PortCall) TableName() string {
+	return "port_calls"
+}
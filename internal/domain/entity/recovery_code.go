@@ -0,0 +1,17 @@
+package entity
+
+// RecoveryCode is a struct that represents a single-use two-factor recovery code, identified by its hash
+type RecoveryCode struct {
+	ID        string `gorm:"column:id;primaryKey"`
+	UserID    string `gorm:"column:user_id"`
+	CodeHash  string `gorm:"column:code_hash"`
+	UsedAt    *int64 `gorm:"column:used_at"`
+	CreatedAt int64  `gorm:"column:created_at;autoCreateTime:milli"`
+
+	// Relations
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
+func (rc *RecoveryCode) TableName() string {
+	return "recovery_codes"
+}
@@ -0,0 +1,20 @@
+package entity
+
+// RefreshToken is a struct that represents an issued refresh token, identified by the hash of the token
+type RefreshToken struct {
+	ID         string  `gorm:"column:id;primaryKey"`
+	UserID     string  `gorm:"column:user_id"`
+	FamilyID   string  `gorm:"column:family_id"`
+	TokenHash  string  `gorm:"column:token_hash;uniqueIndex"`
+	ExpiresAt  int64   `gorm:"column:expires_at"`
+	RevokedAt  *int64  `gorm:"column:revoked_at"`
+	ReplacedBy *string `gorm:"column:replaced_by"`
+	CreatedAt  int64   `gorm:"column:created_at;autoCreateTime:milli"`
+
+	// Relations
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
+func (rt *RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
@@ -0,0 +1,13 @@
+package entity
+
+// RevokedToken is a struct that represents a revoked access token, the ID is the token_id claim of the JWT
+type RevokedToken struct {
+	ID        string `gorm:"column:id;primaryKey"`
+	UserID    string `gorm:"column:user_id"`
+	ExpiresAt int64  `gorm:"column:expires_at"`
+	CreatedAt int64  `gorm:"column:created_at;autoCreateTime:milli"`
+}
+
+func (rt *RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
@@ -6,6 +6,7 @@ type Role struct {
 	Name        string  `gorm:"column:name;uniqueIndex"`
 	DisplayName string  `gorm:"column:display_name"`
 	Description *string `gorm:"column:description"`
+	ParentID    *string `gorm:"column:parent_id"`
 	IsActive    bool    `gorm:"column:is_active;default:true"`
 	IsSystem    bool    `gorm:"column:is_system;default:false"`
 	CreatedAt   int64   `gorm:"column:created_at;autoCreateTime:milli"`
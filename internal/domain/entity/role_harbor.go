@@ -1,15 +1,16 @@
 package entity
 
-// RolePermission is a struct that represents a role_permission junction entity
+// RoleHarbor is a struct that represents a role_harbor junction entity, it grants the holders of the role access to the harbor
 type RoleHarbor struct {
-	RoleID   string `gorm:"column:role_id;primaryKey"`
-	HarborId string `gorm:"column:harbor_id;primaryKey"`
+	RoleID    string `gorm:"column:role_id;primaryKey"`
+	HarborID  string `gorm:"column:harbor_id;primaryKey"`
+	CreatedAt int64  `gorm:"column:created_at;autoCreateTime:milli"`
 
 	// Relations
 	Role   Role   `gorm:"foreignKey:role_id;references:id"`
 	Harbor Harbor `gorm:"foreignKey:harbor_id;references:id"`
 }
 
-func (rp *RoleHarbor) TableName() string {
+func (rh *RoleHarbor) TableName() string {
 	return "role_harbors"
 }
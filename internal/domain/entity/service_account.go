@@ -0,0 +1,21 @@
+package entity
+
+// ServiceAccount is a struct that represents a non-human principal that authenticates with API keys
+type ServiceAccount struct {
+	ID          string  `gorm:"column:id;primaryKey"`
+	Name        string  `gorm:"column:name"`
+	Description *string `gorm:"column:description"`
+	IsActive    bool    `gorm:"column:is_active;default:true"`
+	OperatorID  *string `gorm:"column:operator_id"`
+	CreatedBy   *string `gorm:"column:created_by"`
+	CreatedAt   int64   `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt   int64   `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt   *int64  `gorm:"column:deleted_at"`
+
+	// Relations
+	APIKeys []APIKey `gorm:"foreignKey:service_account_id;references:id"`
+}
+
+func (sa *ServiceAccount) TableName() string {
+	return "service_accounts"
+}
@@ -15,6 +15,7 @@ type User struct {
 	LastLoginAt       *int64  `gorm:"column:last_login_at"`
 	EmailVerifiedAt   *int64  `gorm:"column:email_verified_at"`
 	PasswordChangedAt *int64  `gorm:"column:password_changed_at"`
+	TwoFactorEnabled  bool    `gorm:"column:two_factor_enabled;default:false"`
 	Token             *string `gorm:"column:token"`
 	TokenExpiresAt    *int64  `gorm:"column:token_expires_at"`
 	RefreshToken      *string `gorm:"column:refresh_token"`
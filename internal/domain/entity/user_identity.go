@@ -0,0 +1,20 @@
+package entity
+
+// UserIdentity is a struct that represents the link between a user and the subject of an external identity provider
+type UserIdentity struct {
+	ID          string  `gorm:"column:id;primaryKey"`
+	UserID      string  `gorm:"column:user_id"`
+	Issuer      string  `gorm:"column:issuer"`
+	Subject     string  `gorm:"column:subject"`
+	Email       *string `gorm:"column:email"`
+	LastLoginAt *int64  `gorm:"column:last_login_at"`
+	CreatedAt   int64   `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt   int64   `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+
+	// Relations
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
+func (ui *UserIdentity) TableName() string {
+	return "user_identities"
+}
@@ -0,0 +1,21 @@
+package entity
+
+// UserSession is a struct that represents a login session of a user on one device
+type UserSession struct {
+	ID         string  `gorm:"column:id;primaryKey"`
+	UserID     string  `gorm:"column:user_id"`
+	FamilyID   string  `gorm:"column:family_id;uniqueIndex"`
+	UserAgent  *string `gorm:"column:user_agent"`
+	IPAddress  *string `gorm:"column:ip_address"`
+	LastSeenAt int64   `gorm:"column:last_seen_at"`
+	RevokedAt  *int64  `gorm:"column:revoked_at"`
+	CreatedAt  int64   `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt  int64   `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+
+	// Relations
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
+func (us *UserSession) TableName() string {
+	return "user_sessions"
+}
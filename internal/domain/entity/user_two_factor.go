@@ -0,0 +1,18 @@
+package entity
+
+// UserTwoFactor is a struct that represents the TOTP enrollment of a user, it is pending until ConfirmedAt is set
+type UserTwoFactor struct {
+	UserID       string `gorm:"column:user_id;primaryKey"`
+	Secret       string `gorm:"column:secret"`
+	ConfirmedAt  *int64 `gorm:"column:confirmed_at"`
+	LastUsedStep int64  `gorm:"column:last_used_step"`
+	CreatedAt    int64  `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt    int64  `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+
+	// Relations
+	User User `gorm:"foreignKey:user_id;references:id"`
+}
+
+func (utf *UserTwoFactor) TableName() string {
+	return "user_two_factors"
+}
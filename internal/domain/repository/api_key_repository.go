@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, apiKey *entity.APIKey) error
+	Update(db *gorm.DB, apiKey *entity.APIKey) error
+	Delete(db *gorm.DB, apiKey *entity.APIKey) error
+	FindById(db *gorm.DB, apiKey *entity.APIKey, id any) error
+	CountById(db *gorm.DB, id any) (int64, error)
+
+	// Custom operations
+	FindByPrefix(db *gorm.DB, apiKey *entity.APIKey, prefix string) error
+	FindByServiceAccountIDAndID(db *gorm.DB, apiKey *entity.APIKey, serviceAccountID string, id string) error
+	FindAllByServiceAccountID(db *gorm.DB, serviceAccountID string) ([]entity.APIKey, error)
+	AddPermissions(db *gorm.DB, apiKeyID string, permissionIDs []string) error
+	RevokeByServiceAccountID(db *gorm.DB, serviceAccountID string, revokedAt int64) error
+	TouchLastUsed(db *gorm.DB, id string, lastUsedAt int64, lastUsedIP string) error
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type BerthAllocationRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, allocation *entity.BerthAllocation) error
+	Update(db *gorm.DB, allocation *entity.BerthAllocation) error
+	Delete(db *gorm.DB, allocation *entity.BerthAllocation) error
+	FindById(db *gorm.DB, allocation *entity.BerthAllocation, id any) error
+
+	// Custom operations
+	FindAllByBerthIDInScope(db *gorm.DB, berthID string, from int64, to int64, principalID string) ([]entity.BerthAllocation, error)
+	FindAllByPortCallID(db *gorm.DB, portCallID string) ([]entity.BerthAllocation, error)
+	CountByBerthID(db *gorm.DB, berthID string) (int64, error)
+
+	// Conflict detection, only allocations that still hold their berth count: allocations of cancelled port calls are
+	// ignored and allocations of departed port calls end at the actual time of departure
+	CountOverlapping(db *gorm.DB, berthID string, startsAt int64, endsAt int64, excludeID string) (int64, error)
+	FindBusyBerthIDs(db *gorm.DB, berthIDs []string, startsAt int64, endsAt int64) ([]string, error)
+}
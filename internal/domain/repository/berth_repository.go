@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type BerthRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, berth *entity.Berth) error
+	Update(db *gorm.DB, berth *entity.Berth) error
+	Delete(db *gorm.DB, berth *entity.Berth) error
+	FindById(db *gorm.DB, berth *entity.Berth, id any) error
+
+	// Custom operations
+	FindByIdWithShipTypes(db *gorm.DB, berth *entity.Berth, id string) error
+	FindAllByHarborID(db *gorm.DB, harborID string) ([]entity.Berth, error)
+	CountByHarborIDAndCode(db *gorm.DB, harborID string, code string, excludeID string) (int64, error)
+	CountByHarborID(db *gorm.DB, harborID string) (int64, error)
+	ReplaceShipTypes(db *gorm.DB, berthID string, shipTypes []string) error
+}
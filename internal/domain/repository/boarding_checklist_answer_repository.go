@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type BoardingChecklistAnswerRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, answer *entity.BoardingChecklistAnswer) error
+	Update(db *gorm.DB, answer *entity.BoardingChecklistAnswer) error
+	Delete(db *gorm.DB, answer *entity.BoardingChecklistAnswer) error
+	FindById(db *gorm.DB, answer *entity.BoardingChecklistAnswer, id any) error
+
+	// Custom operations
+	FindByBoardingIDAndItemID(db *gorm.DB, answer *entity.BoardingChecklistAnswer, boardingID string, itemID string) error
+	FindAllByBoardingID(db *gorm.DB, boardingID string) ([]entity.BoardingChecklistAnswer, error)
+}
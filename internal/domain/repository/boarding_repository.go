@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type BoardingRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, boarding *entity.Boarding) error
+	Update(db *gorm.DB, boarding *entity.Boarding) error
+	Delete(db *gorm.DB, boarding *entity.Boarding) error
+	FindById(db *gorm.DB, boarding *entity.Boarding, id any) error
+
+	// Custom operations
+	CountInProgressByShipID(db *gorm.DB, shipID string, excludeID string) (int64, error)
+	CountByChecklistTemplateID(db *gorm.DB, templateID string) (int64, error)
+	CountByShipID(db *gorm.DB, shipID string) (int64, error)
+	CountByHarborID(db *gorm.DB, harborID string) (int64, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type ChecklistTemplateRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, template *entity.ChecklistTemplate) error
+	Update(db *gorm.DB, template *entity.ChecklistTemplate) error
+	Delete(db *gorm.DB, template *entity.ChecklistTemplate) error
+	FindById(db *gorm.DB, template *entity.ChecklistTemplate, id any) error
+
+	// Custom operations
+	FindByIdWithItems(db *gorm.DB, template *entity.ChecklistTemplate, id string) error
+	FindLatestActiveByShipType(db *gorm.DB, template *entity.ChecklistTemplate, shipType string) error
+	FindMaxVersionByShipType(db *gorm.DB, shipType string) (int, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type DeficiencyRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, deficiency *entity.Deficiency) error
+	Update(db *gorm.DB, deficiency *entity.Deficiency) error
+	Delete(db *gorm.DB, deficiency *entity.Deficiency) error
+	FindById(db *gorm.DB, deficiency *entity.Deficiency, id any) error
+
+	// Custom operations
+	FindAllByBoardingID(db *gorm.DB, boardingID string) ([]entity.Deficiency, error)
+	FindShipIDsWithOpenDetainable(db *gorm.DB, shipIDs []string) ([]string, error)
+}
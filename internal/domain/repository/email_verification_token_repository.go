@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type EmailVerificationTokenRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, verificationToken *entity.EmailVerificationToken) error
+	Update(db *gorm.DB, verificationToken *entity.EmailVerificationToken) error
+	Delete(db *gorm.DB, verificationToken *entity.EmailVerificationToken) error
+	FindById(db *gorm.DB, verificationToken *entity.EmailVerificationToken, id any) error
+	CountById(db *gorm.DB, id any) (int64, error)
+
+	// Custom operations
+	FindByTokenHash(db *gorm.DB, verificationToken *entity.EmailVerificationToken, tokenHash string) error
+	DeleteByUserID(db *gorm.DB, userID string) error
+}
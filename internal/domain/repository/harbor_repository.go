@@ -23,4 +23,8 @@ type HarborRepository interface {
 	FindAllActive(db *gorm.DB) ([]entity.Harbor, error)
 	CountByHarborCode(db *gorm.DB, harborCode string, excludeID string) (int64, error)
 	CountByUNLocode(db *gorm.DB, unLocode string, excludeID string) (int64, error)
+
+	// Harbor scope, a principal can only see and change the harbors granted to one of its roles through role_harbors
+	FindByIdInScope(db *gorm.DB, harbor *entity.Harbor, id string, principalID string) error
+	SelectIDsInScope(db *gorm.DB, principalID string) *gorm.DB
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type ImpersonationAuditLogRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, auditLog *entity.ImpersonationAuditLog) error
+	Update(db *gorm.DB, auditLog *entity.ImpersonationAuditLog) error
+	Delete(db *gorm.DB, auditLog *entity.ImpersonationAuditLog) error
+	FindById(db *gorm.DB, auditLog *entity.ImpersonationAuditLog, id any) error
+}
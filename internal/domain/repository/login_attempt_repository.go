@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type LoginAttemptRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, loginAttempt *entity.LoginAttempt) error
+	Update(db *gorm.DB, loginAttempt *entity.LoginAttempt) error
+	Delete(db *gorm.DB, loginAttempt *entity.LoginAttempt) error
+
+	// Custom operations
+	FindByKey(db *gorm.DB, loginAttempt *entity.LoginAttempt, key string) error
+	DeleteByKey(db *gorm.DB, key string) error
+}
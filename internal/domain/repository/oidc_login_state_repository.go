@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type OIDCLoginStateRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, state *entity.OIDCLoginState) error
+	Update(db *gorm.DB, state *entity.OIDCLoginState) error
+	Delete(db *gorm.DB, state *entity.OIDCLoginState) error
+	FindById(db *gorm.DB, state *entity.OIDCLoginState, id any) error
+	CountById(db *gorm.DB, id any) (int64, error)
+
+	// Custom operations
+	ConsumeByStateHash(db *gorm.DB, state *entity.OIDCLoginState, stateHash string) error
+	DeleteExpired(db *gorm.DB, now int64) error
+}
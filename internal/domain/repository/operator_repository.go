@@ -20,4 +20,7 @@ type OperatorRepository interface {
 	FindAllActive(db *gorm.DB) ([]entity.Operator, error)
 	CountByOperatorCode(db *gorm.DB, operatorCode string, excludeID string) (int64, error)
 	CountByLicenseNumber(db *gorm.DB, licenseNumber string, excludeID string) (int64, error)
+
+	// Tenant scope, a principal linked to an operator can only see that operator, staff principals see every operator
+	FindByIdInScope(db *gorm.DB, operator *entity.Operator, id string, principalID string) error
 }
\ No newline at end of file
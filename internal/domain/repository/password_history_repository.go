@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type PasswordHistoryRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, passwordHistory *entity.PasswordHistory) error
+	Update(db *gorm.DB, passwordHistory *entity.PasswordHistory) error
+	Delete(db *gorm.DB, passwordHistory *entity.PasswordHistory) error
+	FindById(db *gorm.DB, passwordHistory *entity.PasswordHistory, id any) error
+	CountById(db *gorm.DB, id any) (int64, error)
+
+	// Custom operations
+	FindLatestByUserID(db *gorm.DB, userID string, limit int) ([]entity.PasswordHistory, error)
+	PruneByUserID(db *gorm.DB, userID string, keep int) error
+}
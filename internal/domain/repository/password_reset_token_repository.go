@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type PasswordResetTokenRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, resetToken *entity.PasswordResetToken) error
+	Update(db *gorm.DB, resetToken *entity.PasswordResetToken) error
+	Delete(db *gorm.DB, resetToken *entity.PasswordResetToken) error
+	FindById(db *gorm.DB, resetToken *entity.PasswordResetToken, id any) error
+	CountById(db *gorm.DB, id any) (int64, error)
+
+	// Custom operations
+	FindByTokenHash(db *gorm.DB, resetToken *entity.PasswordResetToken, tokenHash string) error
+	DeleteByUserID(db *gorm.DB, userID string) error
+}
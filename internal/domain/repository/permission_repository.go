@@ -18,4 +18,8 @@ type PermissionRepository interface {
 	FindByResourceAndAction(db *gorm.DB, permission *entity.Permission, resource string, action string) error
 	FindAllActive(db *gorm.DB) ([]entity.Permission, error)
 	CountByName(db *gorm.DB, name string, excludeID string) (int64, error)
+	// FindAllByUserID and FindAllByRoleID include the permissions inherited through the parents of the roles
+	FindAllByUserID(db *gorm.DB, userID string) ([]entity.Permission, error)
+	FindAllByRoleID(db *gorm.DB, roleID string) ([]entity.Permission, error)
+	FindAllActiveByNames(db *gorm.DB, names []string) ([]entity.Permission, error)
 }
\ No newline at end of file
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type PortCallRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, portCall *entity.PortCall) error
+	Update(db *gorm.DB, portCall *entity.PortCall) error
+	Delete(db *gorm.DB, portCall *entity.PortCall) error
+	FindById(db *gorm.DB, portCall *entity.PortCall, id any) error
+
+	// Custom operations
+	CountInPortByShipID(db *gorm.DB, shipID string, excludeID string) (int64, error)
+	CountByShipID(db *gorm.DB, shipID string) (int64, error)
+	CountByHarborID(db *gorm.DB, harborID string) (int64, error)
+}
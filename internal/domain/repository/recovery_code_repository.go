@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type RecoveryCodeRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, recoveryCode *entity.RecoveryCode) error
+	Update(db *gorm.DB, recoveryCode *entity.RecoveryCode) error
+	Delete(db *gorm.DB, recoveryCode *entity.RecoveryCode) error
+	FindById(db *gorm.DB, recoveryCode *entity.RecoveryCode, id any) error
+	CountById(db *gorm.DB, id any) (int64, error)
+
+	// Custom operations
+	FindUnusedByUserIDAndCodeHash(db *gorm.DB, recoveryCode *entity.RecoveryCode, userID string, codeHash string) error
+	DeleteByUserID(db *gorm.DB, userID string) error
+}
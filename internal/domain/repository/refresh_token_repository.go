@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, refreshToken *entity.RefreshToken) error
+	Update(db *gorm.DB, refreshToken *entity.RefreshToken) error
+	Delete(db *gorm.DB, refreshToken *entity.RefreshToken) error
+	FindById(db *gorm.DB, refreshToken *entity.RefreshToken, id any) error
+	CountById(db *gorm.DB, id any) (int64, error)
+
+	// Custom operations
+	FindByTokenHash(db *gorm.DB, refreshToken *entity.RefreshToken, tokenHash string) error
+	RevokeByFamilyID(db *gorm.DB, familyID string, revokedAt int64) error
+	RevokeByUserID(db *gorm.DB, userID string, revokedAt int64) error
+}
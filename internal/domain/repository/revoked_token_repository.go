@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type RevokedTokenRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, revokedToken *entity.RevokedToken) error
+	Update(db *gorm.DB, revokedToken *entity.RevokedToken) error
+	Delete(db *gorm.DB, revokedToken *entity.RevokedToken) error
+	FindById(db *gorm.DB, revokedToken *entity.RevokedToken, id any) error
+	CountById(db *gorm.DB, id any) (int64, error)
+
+	// Custom operations
+	DeleteExpired(db *gorm.DB, now int64) error
+}
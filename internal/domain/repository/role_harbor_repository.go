@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type RoleHarborRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, roleHarbor *entity.RoleHarbor) error
+	Update(db *gorm.DB, roleHarbor *entity.RoleHarbor) error
+	Delete(db *gorm.DB, roleHarbor *entity.RoleHarbor) error
+
+	// Custom operations
+	FindByRoleIDAndHarborID(db *gorm.DB, roleHarbor *entity.RoleHarbor, roleID string, harborID string) error
+	FindAllByRoleID(db *gorm.DB, roleID string) ([]entity.RoleHarbor, error)
+	DeleteByRoleIDAndHarborID(db *gorm.DB, roleID string, harborID string) error
+}
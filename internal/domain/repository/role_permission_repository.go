@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type RolePermissionRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, rolePermission *entity.RolePermission) error
+	Update(db *gorm.DB, rolePermission *entity.RolePermission) error
+	Delete(db *gorm.DB, rolePermission *entity.RolePermission) error
+
+	// Custom operations
+	FindByRoleIDAndPermissionID(db *gorm.DB, rolePermission *entity.RolePermission, roleID string, permissionID string) error
+	FindAllByRoleID(db *gorm.DB, roleID string) ([]entity.RolePermission, error)
+	FindAllByPermissionID(db *gorm.DB, permissionID string) ([]entity.RolePermission, error)
+	DeleteByRoleIDAndPermissionID(db *gorm.DB, roleID string, permissionID string) error
+	DeleteAllByRoleID(db *gorm.DB, roleID string) error
+
+	// Bulk operations, assigning a permission twice or removing one the role does not have is a no-op
+	CreateAllByRoleID(db *gorm.DB, roleID string, permissionIDs []string) error
+	DeleteAllByRoleIDAndPermissionIDs(db *gorm.DB, roleID string, permissionIDs []string) error
+	DeleteAllByRoleIDExcept(db *gorm.DB, roleID string, permissionIDs []string) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type ServiceAccountRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, serviceAccount *entity.ServiceAccount) error
+	Update(db *gorm.DB, serviceAccount *entity.ServiceAccount) error
+	Delete(db *gorm.DB, serviceAccount *entity.ServiceAccount) error
+	FindById(db *gorm.DB, serviceAccount *entity.ServiceAccount, id any) error
+	CountById(db *gorm.DB, id any) (int64, error)
+
+	// Custom operations
+	FindActiveByID(db *gorm.DB, serviceAccount *entity.ServiceAccount, id string) error
+	CountByName(db *gorm.DB, name string, excludeID string) (int64, error)
+}
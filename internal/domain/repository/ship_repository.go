@@ -24,4 +24,8 @@ type ShipRepository interface {
 	CountByCallSign(db *gorm.DB, callSign string, excludeID string) (int64, error)
 	CountByMMSI(db *gorm.DB, mmsi string, excludeID string) (int64, error)
 	CountByShipNameAndOperatorID(db *gorm.DB, shipName string, operatorID string, excludeID string) (int64, error)
+
+	// Tenant scope, a principal linked to an operator can only see and change the ships of that operator
+	FindByIdInScope(db *gorm.DB, ship *entity.Ship, id string, principalID string) error
+	SelectIDsInScope(db *gorm.DB, principalID string) *gorm.DB
 }
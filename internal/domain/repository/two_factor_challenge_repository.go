@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type TwoFactorChallengeRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, challenge *entity.TwoFactorChallenge) error
+	Update(db *gorm.DB, challenge *entity.TwoFactorChallenge) error
+	Delete(db *gorm.DB, challenge *entity.TwoFactorChallenge) error
+	FindById(db *gorm.DB, challenge *entity.TwoFactorChallenge, id any) error
+	CountById(db *gorm.DB, id any) (int64, error)
+
+	// Custom operations
+	FindByTokenHash(db *gorm.DB, challenge *entity.TwoFactorChallenge, tokenHash string) error
+	DeleteByUserID(db *gorm.DB, userID string) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, identity *entity.UserIdentity) error
+	Update(db *gorm.DB, identity *entity.UserIdentity) error
+	Delete(db *gorm.DB, identity *entity.UserIdentity) error
+	FindById(db *gorm.DB, identity *entity.UserIdentity, id any) error
+	CountById(db *gorm.DB, id any) (int64, error)
+
+	// Custom operations
+	FindByIssuerAndSubject(db *gorm.DB, identity *entity.UserIdentity, issuer, subject string) error
+}
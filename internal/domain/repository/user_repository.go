@@ -18,6 +18,7 @@ type UserRepository interface {
 	FindByToken(db *gorm.DB, user *entity.User, token string) error
 	CountByUsernameAndEmail(db *gorm.DB, username, email, excludeID string) (int64, error)
 	FindByUsername(db *gorm.DB, user *entity.User, username string) error
+	FindByEmail(db *gorm.DB, user *entity.User, email string) error
 	CountByUsername(db *gorm.DB, username, excludeID string) (int64, error)
 
 	FindByRoleID(db *gorm.DB, roleID string) ([]*entity.User, error)
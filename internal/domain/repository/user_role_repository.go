@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type UserRoleRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, userRole *entity.UserRole) error
+	Update(db *gorm.DB, userRole *entity.UserRole) error
+	Delete(db *gorm.DB, userRole *entity.UserRole) error
+
+	// Custom operations
+	FindByUserIDAndRoleID(db *gorm.DB, userRole *entity.UserRole, userID string, roleID string) error
+	FindAllByUserID(db *gorm.DB, userID string) ([]entity.UserRole, error)
+	FindAllByRoleID(db *gorm.DB, roleID string) ([]entity.UserRole, error)
+	DeleteByUserIDAndRoleID(db *gorm.DB, userID string, roleID string) error
+	DeleteAllByUserID(db *gorm.DB, userID string) error
+
+	// Harbor scope, the roles of a user or, for a service account, the roles of the user that created it
+	FindAllInScope(db *gorm.DB, principalID string) ([]entity.UserRole, error)
+}
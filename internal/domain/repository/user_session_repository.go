@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type UserSessionRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, session *entity.UserSession) error
+	Update(db *gorm.DB, session *entity.UserSession) error
+	Delete(db *gorm.DB, session *entity.UserSession) error
+	FindById(db *gorm.DB, session *entity.UserSession, id any) error
+	CountById(db *gorm.DB, id any) (int64, error)
+
+	// Custom operations
+	FindByFamilyID(db *gorm.DB, session *entity.UserSession, familyID string) error
+	FindActiveByUserID(db *gorm.DB, userID string) ([]entity.UserSession, error)
+	RevokeByUserID(db *gorm.DB, userID string, revokedAt int64) error
+}
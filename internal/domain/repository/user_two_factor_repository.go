@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type UserTwoFactorRepository interface {
+	// Base CRUD operations
+	Create(db *gorm.DB, twoFactor *entity.UserTwoFactor) error
+	Update(db *gorm.DB, twoFactor *entity.UserTwoFactor) error
+	Delete(db *gorm.DB, twoFactor *entity.UserTwoFactor) error
+	FindById(db *gorm.DB, twoFactor *entity.UserTwoFactor, id any) error
+	CountById(db *gorm.DB, id any) (int64, error)
+
+	// Custom operations
+	FindByUserID(db *gorm.DB, twoFactor *entity.UserTwoFactor, userID string) error
+	DeleteByUserID(db *gorm.DB, userID string) error
+}
@@ -0,0 +1,11 @@
+package usecase
+
+import (
+	"context"
+	"mkp-boarding-test/internal/model"
+)
+
+type AuthzUseCase interface {
+	CurrentPermissions(ctx context.Context, request *model.CurrentPermissionsRequest) (*model.CurrentPermissionsResponse, error)
+	Check(ctx context.Context, request *model.AuthzCheckRequest) (*model.AuthzCheckResponse, error)
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+	"mkp-boarding-test/internal/model"
+)
+
+type BerthUseCase interface {
+	Create(ctx context.Context, request *model.CreateBerthRequest, userId string) (*model.BerthResponse, error)
+	Update(ctx context.Context, request *model.UpdateBerthRequest, userId string) (*model.BerthResponse, error)
+	Get(ctx context.Context, request *model.GetBerthRequest, userId string) (*model.BerthResponse, error)
+	Delete(ctx context.Context, request *model.DeleteBerthRequest, userId string) error
+	ListByHarbor(ctx context.Context, request *model.ListHarborBerthRequest, userId string) ([]model.BerthResponse, error)
+
+	Allocate(ctx context.Context, request *model.AllocateBerthRequest, userId string) (*model.BerthAllocationResponse, error)
+	Release(ctx context.Context, request *model.ReleaseBerthAllocationRequest, userId string) error
+	ListAllocationsByBerth(ctx context.Context, request *model.ListBerthAllocationRequest, userId string) ([]model.BerthAllocationResponse, error)
+	ListAllocationsByPortCall(ctx context.Context, request *model.ListPortCallBerthAllocationRequest, userId string) ([]model.BerthAllocationResponse, error)
+	Suggest(ctx context.Context, request *model.SuggestBerthRequest, userId string) ([]model.BerthResponse, error)
+}
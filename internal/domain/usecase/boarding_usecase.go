@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+	"mkp-boarding-test/internal/model"
+)
+
+type BoardingUseCase interface {
+	Create(ctx context.Context, request *model.CreateBoardingRequest, userId string) (*model.BoardingResponse, error)
+	Update(ctx context.Context, request *model.UpdateBoardingRequest, userId string) (*model.BoardingResponse, error)
+	Get(ctx context.Context, request *model.GetBoardingRequest, userId string) (*model.BoardingResponse, error)
+	Delete(ctx context.Context, request *model.DeleteBoardingRequest, userId string) error
+	List(ctx context.Context, request *model.ListBoardingRequest, userId string) (*model.WebResponse[[]model.BoardingResponse], error)
+
+	Start(ctx context.Context, request *model.StartBoardingRequest, userId string) (*model.BoardingResponse, error)
+	Complete(ctx context.Context, request *model.CompleteBoardingRequest, userId string) (*model.BoardingResponse, error)
+	Abort(ctx context.Context, request *model.AbortBoardingRequest, userId string) (*model.BoardingResponse, error)
+
+	GetChecklist(ctx context.Context, request *model.GetBoardingChecklistRequest, userId string) (*model.BoardingChecklistResponse, error)
+	AnswerChecklist(ctx context.Context, request *model.AnswerBoardingChecklistRequest, userId string) (*model.BoardingChecklistResponse, error)
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+	"mkp-boarding-test/internal/model"
+)
+
+type ChecklistTemplateUseCase interface {
+	Create(ctx context.Context, request *model.CreateChecklistTemplateRequest) (*model.ChecklistTemplateResponse, error)
+	Update(ctx context.Context, request *model.UpdateChecklistTemplateRequest) (*model.ChecklistTemplateResponse, error)
+	Get(ctx context.Context, request *model.GetChecklistTemplateRequest) (*model.ChecklistTemplateResponse, error)
+	Delete(ctx context.Context, request *model.DeleteChecklistTemplateRequest) error
+	List(ctx context.Context, request *model.ListChecklistTemplateRequest) (*model.WebResponse[[]model.ChecklistTemplateResponse], error)
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+	"mkp-boarding-test/internal/model"
+)
+
+type DeficiencyUseCase interface {
+	Create(ctx context.Context, request *model.CreateDeficiencyRequest, userId string) (*model.DeficiencyResponse, error)
+	Update(ctx context.Context, request *model.UpdateDeficiencyRequest, userId string) (*model.DeficiencyResponse, error)
+	Get(ctx context.Context, request *model.GetDeficiencyRequest, userId string) (*model.DeficiencyResponse, error)
+	ListByBoarding(ctx context.Context, request *model.ListBoardingDeficiencyRequest, userId string) ([]model.DeficiencyResponse, error)
+	ListByShip(ctx context.Context, request *model.ListShipDeficiencyRequest, userId string) (*model.WebResponse[[]model.DeficiencyResponse], error)
+
+	Rectify(ctx context.Context, request *model.RectifyDeficiencyRequest, userId string) (*model.DeficiencyResponse, error)
+	Verify(ctx context.Context, request *model.VerifyDeficiencyRequest, userId string) (*model.DeficiencyResponse, error)
+	Reopen(ctx context.Context, request *model.ReopenDeficiencyRequest, userId string) (*model.DeficiencyResponse, error)
+}
@@ -6,9 +6,9 @@ import (
 )
 
 type HarborUseCase interface {
-	Create(ctx context.Context, request *model.CreateHarborRequest) (*model.HarborResponse, error)
-	Update(ctx context.Context, request *model.UpdateHarborRequest) (*model.HarborResponse, error)
-	Get(ctx context.Context, request *model.GetHarborRequest) (*model.HarborResponse, error)
-	Delete(ctx context.Context, request *model.DeleteHarborRequest) error
+	Create(ctx context.Context, request *model.CreateHarborRequest, userId string) (*model.HarborResponse, error)
+	Update(ctx context.Context, request *model.UpdateHarborRequest, userId string) (*model.HarborResponse, error)
+	Get(ctx context.Context, request *model.GetHarborRequest, userId string) (*model.HarborResponse, error)
+	Delete(ctx context.Context, request *model.DeleteHarborRequest, userId string) error
 	List(ctx context.Context, request *model.ListHarborRequest, userId string) (*model.WebResponse[[]model.HarborResponse], error)
 }
@@ -11,4 +11,5 @@ type PermissionUseCase interface {
 	Get(ctx context.Context, request *model.GetPermissionRequest) (*model.PermissionResponse, error)
 	Delete(ctx context.Context, request *model.DeletePermissionRequest) error
 	List(ctx context.Context, request *model.ListPermissionRequest) (*model.WebResponse[[]model.PermissionResponse], error)
+	FindNamesByUserID(ctx context.Context, userID string) ([]string, error)
 }
\ No newline at end of file
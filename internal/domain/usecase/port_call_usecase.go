@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+	"mkp-boarding-test/internal/model"
+)
+
+type PortCallUseCase interface {
+	Create(ctx context.Context, request *model.CreatePortCallRequest, userId string) (*model.PortCallResponse, error)
+	Update(ctx context.Context, request *model.UpdatePortCallRequest, userId string) (*model.PortCallResponse, error)
+	Get(ctx context.Context, request *model.GetPortCallRequest, userId string) (*model.PortCallResponse, error)
+	ListByHarbor(ctx context.Context, request *model.ListHarborPortCallRequest, userId string) (*model.WebResponse[[]model.PortCallResponse], error)
+
+	Arrive(ctx context.Context, request *model.ArrivePortCallRequest, userId string) (*model.PortCallResponse, error)
+	Close(ctx context.Context, request *model.ClosePortCallRequest, userId string) (*model.PortCallResponse, error)
+}
@@ -13,4 +13,8 @@ type RoleUseCase interface {
 	List(ctx context.Context, request *model.ListRoleRequest) (*model.WebResponse[[]model.RoleResponse], error)
 	AssignPermissions(ctx context.Context, request *model.AssignPermissionsRequest) error
 	RemovePermissions(ctx context.Context, request *model.RemovePermissionsRequest) error
+	SetPermissions(ctx context.Context, request *model.SetPermissionsRequest) (*model.RoleResponse, error)
+	GetEffectivePermissions(ctx context.Context, request *model.GetRoleEffectivePermissionsRequest) (*model.RoleEffectivePermissionsResponse, error)
+	AssignHarbors(ctx context.Context, request *model.AssignHarborsRequest) error
+	RemoveHarbors(ctx context.Context, request *model.RemoveHarborsRequest) error
 }
\ No newline at end of file
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+	"mkp-boarding-test/internal/model"
+)
+
+type ServiceAccountUseCase interface {
+	Create(ctx context.Context, request *model.CreateServiceAccountRequest) (*model.ServiceAccountResponse, error)
+	Get(ctx context.Context, request *model.GetServiceAccountRequest) (*model.ServiceAccountResponse, error)
+	Delete(ctx context.Context, request *model.DeleteServiceAccountRequest) error
+	List(ctx context.Context, request *model.ListServiceAccountRequest) (*model.WebResponse[[]model.ServiceAccountResponse], error)
+	CreateAPIKey(ctx context.Context, request *model.CreateAPIKeyRequest) (*model.APIKeyResponse, error)
+	ListAPIKeys(ctx context.Context, request *model.ListAPIKeyRequest) ([]model.APIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, request *model.RevokeAPIKeyRequest) error
+	AuthenticateAPIKey(ctx context.Context, request *model.AuthenticateAPIKeyRequest) (*model.APIKeyPrincipal, error)
+}
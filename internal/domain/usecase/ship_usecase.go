@@ -6,9 +6,9 @@ import (
 )
 
 type ShipUseCase interface {
-	Create(ctx context.Context, request *model.CreateShipRequest) (*model.ShipResponse, error)
-	Update(ctx context.Context, request *model.UpdateShipRequest) (*model.ShipResponse, error)
-	Get(ctx context.Context, request *model.GetShipRequest) (*model.ShipResponse, error)
-	Delete(ctx context.Context, request *model.DeleteShipRequest) error
-	List(ctx context.Context, request *model.ListShipRequest) (*model.WebResponse[[]model.ShipResponse], error)
+	Create(ctx context.Context, request *model.CreateShipRequest, userId string) (*model.ShipResponse, error)
+	Update(ctx context.Context, request *model.UpdateShipRequest, userId string) (*model.ShipResponse, error)
+	Get(ctx context.Context, request *model.GetShipRequest, userId string) (*model.ShipResponse, error)
+	Delete(ctx context.Context, request *model.DeleteShipRequest, userId string) error
+	List(ctx context.Context, request *model.ListShipRequest, userId string) (*model.WebResponse[[]model.ShipResponse], error)
 }
\ No newline at end of file
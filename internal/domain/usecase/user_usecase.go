@@ -4,15 +4,46 @@ import (
 	"context"
 	"mkp-boarding-test/internal/delivery/http/dto/request"
 	"mkp-boarding-test/internal/delivery/http/dto/response"
+	"mkp-boarding-test/internal/model"
 )
 
 type UserUseCase interface {
 	Verify(ctx context.Context, request *request.VerifyUserRequest) (*response.UserResponse, error)
+	ResendVerification(ctx context.Context, request *request.ResendVerificationRequest) error
+	ForgotPassword(ctx context.Context, request *request.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, request *request.ResetPasswordRequest) error
+	ChangePassword(ctx context.Context, request *request.ChangePasswordRequest) error
 	Create(ctx context.Context, request *request.RegisterUserRequest) (*response.UserResponse, error)
 	Login(ctx context.Context, request *request.LoginUserRequest) (*response.UserResponse, error)
+	Refresh(ctx context.Context, request *request.RefreshTokenRequest) (*response.UserResponse, error)
+	Authenticate(ctx context.Context, request *request.AuthenticateUserRequest) (*response.UserResponse, error)
 	Current(ctx context.Context, request *request.GetUserRequest) (*response.UserResponse, error)
 	Logout(ctx context.Context, request *request.LogoutUserRequest) (bool, error)
 	Update(ctx context.Context, request *request.UpdateUserRequest) (*response.UserResponse, error)
 
+	LoginTwoFactor(ctx context.Context, request *request.LoginTwoFactorRequest) (*response.UserResponse, error)
+	EnrollTwoFactor(ctx context.Context, request *request.EnrollTwoFactorRequest) (*response.TwoFactorEnrollmentResponse, error)
+	ConfirmTwoFactor(ctx context.Context, request *request.ConfirmTwoFactorRequest) (*response.RecoveryCodesResponse, error)
+	DisableTwoFactor(ctx context.Context, request *request.DisableTwoFactorRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, request *request.RegenerateRecoveryCodesRequest) (*response.RecoveryCodesResponse, error)
+	ResetTwoFactor(ctx context.Context, request *request.ResetTwoFactorRequest) error
+	OIDCAuthorize(ctx context.Context) (*response.OIDCAuthorizationResponse, error)
+	OIDCCallback(ctx context.Context, request *request.OIDCCallbackRequest) (*response.UserResponse, error)
+
+	ListSessions(ctx context.Context, request *request.ListSessionRequest) ([]*response.SessionResponse, error)
+	RevokeSession(ctx context.Context, request *request.RevokeSessionRequest) error
+
+	List(ctx context.Context, request *request.ListUserRequest) (*model.WebResponse[[]*response.UserResponse], error)
+	Get(ctx context.Context, request *request.GetUserRequest) (*response.UserResponse, error)
+	AdminUpdate(ctx context.Context, request *request.AdminUpdateUserRequest) (*response.UserResponse, error)
+	Delete(ctx context.Context, request *request.DeleteUserRequest) error
+	Unlock(ctx context.Context, request *request.UnlockUserRequest) error
+
+	AssignRoles(ctx context.Context, request *request.AssignRolesRequest) (*response.UserResponse, error)
+	RemoveRoles(ctx context.Context, request *request.RemoveRolesRequest) (*response.UserResponse, error)
 	FindByRoleID(ctx context.Context, roleID string) ([]*response.UserResponse, error)
+
+	Impersonate(ctx context.Context, request *request.ImpersonateUserRequest) (*response.ImpersonationResponse, error)
+	StartImpersonatedWrite(ctx context.Context, request *request.StartImpersonatedWriteRequest) (string, error)
+	FinishImpersonatedWrite(ctx context.Context, request *request.FinishImpersonatedWriteRequest) error
 }
@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogMailer is meant for local development, messages are written to the log and appended to a file when a path is set
+type LogMailer struct {
+	Path string
+	Log  *logrus.Logger
+}
+
+var _ Mailer = (*LogMailer)(nil)
+
+func NewLogMailer(path string, log *logrus.Logger) *LogMailer {
+	return &LogMailer{
+		Path: path,
+		Log:  log,
+	}
+}
+
+func (m *LogMailer) Send(message *Message) error {
+	m.Log.Infof("Email %q to %s :\n%s", message.Subject, message.To, message.Body)
+
+	if m.Path == "" {
+		return nil
+	}
+
+	file, err := os.OpenFile(m.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		m.Log.WithError(err).Error("failed to open mail file")
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC1123Z), message.To, message.Subject, message.Body)
+	if err != nil {
+		m.Log.WithError(err).Error("failed to write mail file")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SMTPMailer sends messages through an SMTP server, authenticating with PLAIN auth when a username is configured
+type SMTPMailer struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	Log      *logrus.Logger
+}
+
+var _ Mailer = (*SMTPMailer)(nil)
+
+func NewSMTPMailer(host string, port int, username, password, from string, log *logrus.Logger) *SMTPMailer {
+	return &SMTPMailer{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+		Log:      log,
+	}
+}
+
+func (m *SMTPMailer) Send(message *Message) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", m.From)
+	fmt.Fprintf(&body, "To: %s\r\n", message.To)
+	fmt.Fprintf(&body, "Subject: %s\r\n", message.Subject)
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	body.WriteString(message.Body)
+
+	address := fmt.Sprintf("%s:%d", m.Host, m.Port)
+	if err := smtp.SendMail(address, auth, m.From, []string{message.To}, []byte(body.String())); err != nil {
+		m.Log.WithError(err).Error("failed to send email")
+		return err
+	}
+
+	m.Log.Debugf("Email %q sent to %s", message.Subject, message.To)
+	return nil
+}
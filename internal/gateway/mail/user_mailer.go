@@ -0,0 +1,52 @@
+package mail
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// UserMailer composes the account emails sent to users
+type UserMailer struct {
+	Mailer           Mailer
+	VerificationURL  string
+	PasswordResetURL string
+	Log              *logrus.Logger
+}
+
+func NewUserMailer(mailer Mailer, verificationURL, passwordResetURL string, log *logrus.Logger) *UserMailer {
+	return &UserMailer{
+		Mailer:           mailer,
+		VerificationURL:  verificationURL,
+		PasswordResetURL: passwordResetURL,
+		Log:              log,
+	}
+}
+
+func (m *UserMailer) SendEmailVerification(email, username, token string) error {
+	body := fmt.Sprintf("Hi %s,\n\nPlease confirm your email address with the following verification token:\n\n%s\n", username, token)
+	if m.VerificationURL != "" {
+		body += fmt.Sprintf("\nOr open %s?token=%s\n", m.VerificationURL, url.QueryEscape(token))
+	}
+
+	return m.Mailer.Send(&Message{
+		To:      email,
+		Subject: "Verify your email address",
+		Body:    body,
+	})
+}
+
+func (m *UserMailer) SendPasswordReset(email, username, token string) error {
+	body := fmt.Sprintf("Hi %s,\n\nA password reset was requested for your account. Use the following token to choose a new password:\n\n%s\n", username, token)
+	if m.PasswordResetURL != "" {
+		body += fmt.Sprintf("\nOr open %s?token=%s\n", m.PasswordResetURL, url.QueryEscape(token))
+	}
+	body += "\nIf you did not request a password reset, you can ignore this email.\n"
+
+	return m.Mailer.Send(&Message{
+		To:      email,
+		Subject: "Reset your password",
+		Body:    body,
+	})
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepositoryImpl struct {
+	baseRepo.Repository[entity.APIKey]
+	Log *logrus.Logger
+}
+
+var _ domain.APIKeyRepository = (*APIKeyRepositoryImpl)(nil)
+
+func NewAPIKeyRepository(log *logrus.Logger) *APIKeyRepositoryImpl {
+	return &APIKeyRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *APIKeyRepositoryImpl) FindByPrefix(db *gorm.DB, apiKey *entity.APIKey, prefix string) error {
+	return db.Preload("ServiceAccount").
+		Preload("APIKeyPermissions.Permission").
+		Where("prefix = ?", prefix).
+		First(apiKey).Error
+}
+
+func (r *APIKeyRepositoryImpl) FindByServiceAccountIDAndID(db *gorm.DB, apiKey *entity.APIKey, serviceAccountID string, id string) error {
+	return db.Where("service_account_id = ? AND id = ?", serviceAccountID, id).First(apiKey).Error
+}
+
+func (r *APIKeyRepositoryImpl) FindAllByServiceAccountID(db *gorm.DB, serviceAccountID string) ([]entity.APIKey, error) {
+	var apiKeys []entity.APIKey
+	err := db.Preload("APIKeyPermissions.Permission").
+		Where("service_account_id = ?", serviceAccountID).
+		Order("created_at DESC").
+		Find(&apiKeys).Error
+	if err != nil {
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+func (r *APIKeyRepositoryImpl) AddPermissions(db *gorm.DB, apiKeyID string, permissionIDs []string) error {
+	if len(permissionIDs) == 0 {
+		return nil
+	}
+
+	apiKeyPermissions := make([]entity.APIKeyPermission, len(permissionIDs))
+	for i, permissionID := range permissionIDs {
+		apiKeyPermissions[i] = entity.APIKeyPermission{
+			APIKeyID:     apiKeyID,
+			PermissionID: permissionID,
+		}
+	}
+	return db.Omit("APIKey", "Permission").Create(&apiKeyPermissions).Error
+}
+
+func (r *APIKeyRepositoryImpl) RevokeByServiceAccountID(db *gorm.DB, serviceAccountID string, revokedAt int64) error {
+	return db.Model(&entity.APIKey{}).
+		Where("service_account_id = ? AND revoked_at IS NULL", serviceAccountID).
+		Update("revoked_at", revokedAt).Error
+}
+
+func (r *APIKeyRepositoryImpl) TouchLastUsed(db *gorm.DB, id string, lastUsedAt int64, lastUsedIP string) error {
+	return db.Model(&entity.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{
+			"last_used_at": lastUsedAt,
+			"last_used_ip": lastUsedIP,
+		}).Error
+}
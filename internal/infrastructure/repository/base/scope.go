@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+// OperatorIDsInScope returns a subquery selecting the operators in the tenant scope of the principal, a user linked to
+// an operator only sees that operator and staff users without an operator see every operator. A service account only
+// sees the operator stored on it, without one it sees no operator and never falls back to the staff scope
+func OperatorIDsInScope(db *gorm.DB, principalID string) *gorm.DB {
+	serviceAccounts := db.Session(&gorm.Session{NewDB: true}).Model(&entity.ServiceAccount{}).Select("operator_id").Where("id = ?", principalID)
+	linked := db.Session(&gorm.Session{NewDB: true}).Model(&entity.Operator{}).Select("id").
+		Where("deleted_at IS NULL AND (user_id = ? OR id IN (?))", principalID, serviceAccounts)
+	return db.Session(&gorm.Session{NewDB: true}).Model(&entity.Operator{}).Select("id").
+		Where("id IN (?) OR (NOT EXISTS (?) AND NOT EXISTS (?))", linked, linked, serviceAccounts)
+}
+
+// ShipIDsInScope returns a subquery selecting the ships of the operators in the tenant scope of the principal
+func ShipIDsInScope(db *gorm.DB, principalID string) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&entity.Ship{}).Select("id").
+		Where("deleted_at IS NULL AND operator_id IN (?)", OperatorIDsInScope(db, principalID))
+}
+
+// RoleHolderIDsInScope returns a subquery selecting the users whose roles make up the harbor scope of the principal, a
+// user holds its own roles and a service account, which holds no roles, borrows the roles of the user that created it
+func RoleHolderIDsInScope(db *gorm.DB, principalID string) *gorm.DB {
+	creators := db.Session(&gorm.Session{NewDB: true}).Model(&entity.ServiceAccount{}).Select("created_by").Where("id = ? AND deleted_at IS NULL", principalID)
+	return db.Session(&gorm.Session{NewDB: true}).Model(&entity.User{}).Select("id").
+		Where("id = ? OR (id IN (?) AND deleted_at IS NULL)", principalID, creators)
+}
+
+// HarborIDsInScope returns a subquery selecting the ids of the harbors granted to any role of the principal
+func HarborIDsInScope(db *gorm.DB, principalID string) *gorm.DB {
+	roleIDs := db.Session(&gorm.Session{NewDB: true}).Model(&entity.UserRole{}).Select("role_id").Where("user_id IN (?)", RoleHolderIDsInScope(db, principalID))
+	return db.Session(&gorm.Session{NewDB: true}).Model(&entity.RoleHarbor{}).Select("harbor_id").Where("role_id IN (?)", roleIDs)
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BerthRepositoryImpl struct {
+	baseRepo.Repository[entity.Berth]
+	Log *logrus.Logger
+}
+
+var _ domain.BerthRepository = (*BerthRepositoryImpl)(nil)
+
+func NewBerthRepository(log *logrus.Logger) *BerthRepositoryImpl {
+	return &BerthRepositoryImpl{
+		Log: log,
+	}
+}
+
+// Create writes the berth row only, its ship types are written with ReplaceShipTypes
+func (r *BerthRepositoryImpl) Create(db *gorm.DB, berth *entity.Berth) error {
+	return db.Omit(clause.Associations).Create(berth).Error
+}
+
+// Update writes the berth row only, its ship types are written with ReplaceShipTypes
+func (r *BerthRepositoryImpl) Update(db *gorm.DB, berth *entity.Berth) error {
+	return db.Omit(clause.Associations).Save(berth).Error
+}
+
+func (r *BerthRepositoryImpl) FindByIdWithShipTypes(db *gorm.DB, berth *entity.Berth, id string) error {
+	return db.Preload("ShipTypes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("ship_type ASC")
+	}).
+		Where("id = ?", id).
+		Take(berth).Error
+}
+
+func (r *BerthRepositoryImpl) FindAllByHarborID(db *gorm.DB, harborID string) ([]entity.Berth, error) {
+	var berths []entity.Berth
+	err := db.Preload("ShipTypes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("ship_type ASC")
+	}).
+		Where("harbor_id = ?", harborID).
+		Order("code ASC").
+		Find(&berths).Error
+	return berths, err
+}
+
+func (r *BerthRepositoryImpl) CountByHarborIDAndCode(db *gorm.DB, harborID string, code string, excludeID string) (int64, error) {
+	var total int64
+	query := db.Model(&entity.Berth{}).Where("harbor_id = ? AND LOWER(code) = LOWER(?)", harborID, code)
+	if excludeID != "" {
+		query = query.Where("id != ?", excludeID)
+	}
+	err := query.Count(&total).Error
+	return total, err
+}
+
+func (r *BerthRepositoryImpl) CountByHarborID(db *gorm.DB, harborID string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.Berth{}).Where("harbor_id = ?", harborID).Count(&total).Error
+	return total, err
+}
+
+// ReplaceShipTypes replaces the ship types a berth takes, an empty list lets the berth take any ship
+func (r *BerthRepositoryImpl) ReplaceShipTypes(db *gorm.DB, berthID string, shipTypes []string) error {
+	if err := db.Where("berth_id = ?", berthID).Delete(&entity.BerthShipType{}).Error; err != nil {
+		return err
+	}
+
+	if len(shipTypes) == 0 {
+		return nil
+	}
+
+	berthShipTypes := make([]entity.BerthShipType, len(shipTypes))
+	for i, shipType := range shipTypes {
+		berthShipTypes[i] = entity.BerthShipType{
+			BerthID:  berthID,
+			ShipType: shipType,
+		}
+	}
+	return db.Create(&berthShipTypes).Error
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BerthAllocationRepositoryImpl struct {
+	baseRepo.Repository[entity.BerthAllocation]
+	Log *logrus.Logger
+}
+
+var _ domain.BerthAllocationRepository = (*BerthAllocationRepositoryImpl)(nil)
+
+func NewBerthAllocationRepository(log *logrus.Logger) *BerthAllocationRepositoryImpl {
+	return &BerthAllocationRepositoryImpl{
+		Log: log,
+	}
+}
+
+// Create writes the allocation row only, the berth and port call of an allocation are never saved through it
+func (r *BerthAllocationRepositoryImpl) Create(db *gorm.DB, allocation *entity.BerthAllocation) error {
+	return db.Omit(clause.Associations).Create(allocation).Error
+}
+
+// Update writes the allocation row only, the berth and port call loaded with the allocation are left untouched
+func (r *BerthAllocationRepositoryImpl) Update(db *gorm.DB, allocation *entity.BerthAllocation) error {
+	return db.Omit(clause.Associations).Save(allocation).Error
+}
+
+// FindAllByBerthIDInScope finds the allocations of a berth that overlap the window from..to with their port calls and
+// ships, only port calls of ships in the tenant scope of the principal are included, a bound of 0 leaves the window
+// open on that side
+func (r *BerthAllocationRepositoryImpl) FindAllByBerthIDInScope(db *gorm.DB, berthID string, from int64, to int64, principalID string) ([]entity.BerthAllocation, error) {
+	var allocations []entity.BerthAllocation
+	portCallIDs := db.Session(&gorm.Session{NewDB: true}).Model(&entity.PortCall{}).Select("id").
+		Where("ship_id IN (?)", baseRepo.ShipIDsInScope(db, principalID))
+	query := db.Preload("PortCall.Ship").Where("berth_id = ? AND port_call_id IN (?)", berthID, portCallIDs)
+	if from > 0 {
+		query = query.Where("ends_at > ?", from)
+	}
+	if to > 0 {
+		query = query.Where("starts_at < ?", to)
+	}
+	err := query.Order("starts_at ASC").Find(&allocations).Error
+	return allocations, err
+}
+
+func (r *BerthAllocationRepositoryImpl) FindAllByPortCallID(db *gorm.DB, portCallID string) ([]entity.BerthAllocation, error) {
+	var allocations []entity.BerthAllocation
+	err := db.Preload("Berth").
+		Where("port_call_id = ?", portCallID).
+		Order("starts_at ASC").
+		Find(&allocations).Error
+	return allocations, err
+}
+
+func (r *BerthAllocationRepositoryImpl) CountByBerthID(db *gorm.DB, berthID string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.BerthAllocation{}).Where("berth_id = ?", berthID).Count(&total).Error
+	return total, err
+}
+
+func (r *BerthAllocationRepositoryImpl) CountOverlapping(db *gorm.DB, berthID string, startsAt int64, endsAt int64, excludeID string) (int64, error) {
+	var total int64
+	query := r.holding(db, startsAt, endsAt).Where("berth_allocations.berth_id = ?", berthID)
+	if excludeID != "" {
+		query = query.Where("berth_allocations.id != ?", excludeID)
+	}
+	err := query.Count(&total).Error
+	return total, err
+}
+
+func (r *BerthAllocationRepositoryImpl) FindBusyBerthIDs(db *gorm.DB, berthIDs []string, startsAt int64, endsAt int64) ([]string, error) {
+	var ids []string
+	if len(berthIDs) == 0 {
+		return ids, nil
+	}
+	err := r.holding(db, startsAt, endsAt).
+		Where("berth_allocations.berth_id IN ?", berthIDs).
+		Distinct("berth_allocations.berth_id").
+		Pluck("berth_allocations.berth_id", &ids).Error
+	return ids, err
+}
+
+// holding selects the allocations that hold their berth during part of the window startsAt..endsAt
+func (r *BerthAllocationRepositoryImpl) holding(db *gorm.DB, startsAt int64, endsAt int64) *gorm.DB {
+	return db.Model(&entity.BerthAllocation{}).
+		Joins("JOIN port_calls ON port_calls.id = berth_allocations.port_call_id").
+		Where("port_calls.status != ?", entity.PortCallStatusCancelled).
+		Where("berth_allocations.starts_at < ?", endsAt).
+		Where("LEAST(berth_allocations.ends_at, COALESCE(port_calls.atd, berth_allocations.ends_at)) > ?", startsAt)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func dryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("open gorm: %v", err)
+	}
+	return db
+}
+
+// TestCountOverlappingBounds checks the windows are compared as half-open intervals, an allocation ending when the
+// window starts or starting when it ends does not overlap it, and a departed ship frees the berth at its atd
+func TestCountOverlappingBounds(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	repository := NewBerthAllocationRepository(log)
+
+	tests := []struct {
+		name      string
+		excludeID string
+		want      []string
+		vars      []interface{}
+	}{
+		{
+			name: "overlap with the window",
+			want: []string{
+				`port_calls.status != $1`,
+				`berth_allocations.starts_at < $2`,
+				`LEAST(berth_allocations.ends_at, COALESCE(port_calls.atd, berth_allocations.ends_at)) > $3`,
+				`berth_allocations.berth_id = $4`,
+			},
+			vars: []interface{}{"cancelled", int64(2000), int64(1000), "berth-id"},
+		},
+		{
+			name:      "overlap with the window besides the allocation moved",
+			excludeID: "allocation-id",
+			want:      []string{`berth_allocations.id != $5`},
+			vars:      []interface{}{"cancelled", int64(2000), int64(1000), "berth-id", "allocation-id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := dryRun(t).Session(&gorm.Session{})
+			var statement *gorm.Statement
+			if err := db.Callback().Query().After("gorm:query").Register("test:statement", func(db *gorm.DB) {
+				statement = db.Statement
+			}); err != nil {
+				t.Fatalf("register callback: %v", err)
+			}
+
+			if _, err := repository.CountOverlapping(db, "berth-id", 1000, 2000, tt.excludeID); err != nil {
+				t.Fatalf("count overlapping: %v", err)
+			}
+
+			sql := statement.SQL.String()
+			for _, want := range tt.want {
+				if !strings.Contains(sql, want) {
+					t.Fatalf("expected %q in %s", want, sql)
+				}
+			}
+			if len(statement.Vars) != len(tt.vars) {
+				t.Fatalf("expected vars %v, got %v", tt.vars, statement.Vars)
+			}
+			for i := range tt.vars {
+				if statement.Vars[i] != tt.vars[i] {
+					t.Fatalf("expected vars %v, got %v", tt.vars, statement.Vars)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BoardingRepositoryImpl struct {
+	baseRepo.Repository[entity.Boarding]
+	Log *logrus.Logger
+}
+
+var _ domain.BoardingRepository = (*BoardingRepositoryImpl)(nil)
+
+func NewBoardingRepository(log *logrus.Logger) *BoardingRepositoryImpl {
+	return &BoardingRepositoryImpl{
+		Log: log,
+	}
+}
+
+// Create writes the boarding row only, the ship and harbor of a boarding are never saved through it
+func (r *BoardingRepositoryImpl) Create(db *gorm.DB, boarding *entity.Boarding) error {
+	return db.Omit(clause.Associations).Create(boarding).Error
+}
+
+// Update writes the boarding row only, the ship and harbor loaded with the boarding are left untouched
+func (r *BoardingRepositoryImpl) Update(db *gorm.DB, boarding *entity.Boarding) error {
+	return db.Omit(clause.Associations).Save(boarding).Error
+}
+
+func (r *BoardingRepositoryImpl) CountInProgressByShipID(db *gorm.DB, shipID string, excludeID string) (int64, error) {
+	var total int64
+	query := db.Model(&entity.Boarding{}).Where("ship_id = ? AND status = ?", shipID, entity.BoardingStatusInProgress)
+	if excludeID != "" {
+		query = query.Where("id != ?", excludeID)
+	}
+	err := query.Count(&total).Error
+	return total, err
+}
+
+func (r *BoardingRepositoryImpl) CountByChecklistTemplateID(db *gorm.DB, templateID string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.Boarding{}).Where("checklist_template_id = ?", templateID).Count(&total).Error
+	return total, err
+}
+
+func (r *BoardingRepositoryImpl) CountByShipID(db *gorm.DB, shipID string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.Boarding{}).Where("ship_id = ?", shipID).Count(&total).Error
+	return total, err
+}
+
+func (r *BoardingRepositoryImpl) CountByHarborID(db *gorm.DB, harborID string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.Boarding{}).Where("harbor_id = ?", harborID).Count(&total).Error
+	return total, err
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type BoardingChecklistAnswerRepositoryImpl struct {
+	baseRepo.Repository[entity.BoardingChecklistAnswer]
+	Log *logrus.Logger
+}
+
+var _ domain.BoardingChecklistAnswerRepository = (*BoardingChecklistAnswerRepositoryImpl)(nil)
+
+func NewBoardingChecklistAnswerRepository(log *logrus.Logger) *BoardingChecklistAnswerRepositoryImpl {
+	return &BoardingChecklistAnswerRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *BoardingChecklistAnswerRepositoryImpl) FindByBoardingIDAndItemID(db *gorm.DB, answer *entity.BoardingChecklistAnswer, boardingID string, itemID string) error {
+	return db.Where("boarding_id = ? AND item_id = ?", boardingID, itemID).Take(answer).Error
+}
+
+func (r *BoardingChecklistAnswerRepositoryImpl) FindAllByBoardingID(db *gorm.DB, boardingID string) ([]entity.BoardingChecklistAnswer, error) {
+	var answers []entity.BoardingChecklistAnswer
+	if err := db.Where("boarding_id = ?", boardingID).Order("created_at ASC").Find(&answers).Error; err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
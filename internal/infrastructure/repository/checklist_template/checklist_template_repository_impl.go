@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ChecklistTemplateRepositoryImpl struct {
+	baseRepo.Repository[entity.ChecklistTemplate]
+	Log *logrus.Logger
+}
+
+var _ domain.ChecklistTemplateRepository = (*ChecklistTemplateRepositoryImpl)(nil)
+
+func NewChecklistTemplateRepository(log *logrus.Logger) *ChecklistTemplateRepositoryImpl {
+	return &ChecklistTemplateRepositoryImpl{
+		Log: log,
+	}
+}
+
+// Update writes the template row only, sections and items of a published version never change
+func (r *ChecklistTemplateRepositoryImpl) Update(db *gorm.DB, template *entity.ChecklistTemplate) error {
+	return db.Omit(clause.Associations).Save(template).Error
+}
+
+func (r *ChecklistTemplateRepositoryImpl) FindByIdWithItems(db *gorm.DB, template *entity.ChecklistTemplate, id string) error {
+	return db.Preload("Sections", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).
+		Preload("Sections.Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Where("id = ?", id).
+		Take(template).Error
+}
+
+func (r *ChecklistTemplateRepositoryImpl) FindLatestActiveByShipType(db *gorm.DB, template *entity.ChecklistTemplate, shipType string) error {
+	return db.Where("LOWER(ship_type) = LOWER(?) AND is_active = ?", shipType, true).
+		Order("version DESC").
+		First(template).Error
+}
+
+func (r *ChecklistTemplateRepositoryImpl) FindMaxVersionByShipType(db *gorm.DB, shipType string) (int, error) {
+	var version int
+	err := db.Model(&entity.ChecklistTemplate{}).
+		Select("COALESCE(MAX(version), 0)").
+		Where("LOWER(ship_type) = LOWER(?)", shipType).
+		Scan(&version).Error
+	return version, err
+}
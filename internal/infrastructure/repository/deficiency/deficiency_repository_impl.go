@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type DeficiencyRepositoryImpl struct {
+	baseRepo.Repository[entity.Deficiency]
+	Log *logrus.Logger
+}
+
+var _ domain.DeficiencyRepository = (*DeficiencyRepositoryImpl)(nil)
+
+func NewDeficiencyRepository(log *logrus.Logger) *DeficiencyRepositoryImpl {
+	return &DeficiencyRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *DeficiencyRepositoryImpl) FindAllByBoardingID(db *gorm.DB, boardingID string) ([]entity.Deficiency, error) {
+	var deficiencies []entity.Deficiency
+	if err := db.Where("boarding_id = ?", boardingID).Order("created_at ASC").Find(&deficiencies).Error; err != nil {
+		return nil, err
+	}
+	return deficiencies, nil
+}
+
+// FindShipIDsWithOpenDetainable returns the ships among shipIDs with a detainable deficiency that is not verified yet
+func (r *DeficiencyRepositoryImpl) FindShipIDsWithOpenDetainable(db *gorm.DB, shipIDs []string) ([]string, error) {
+	var ids []string
+	if len(shipIDs) == 0 {
+		return ids, nil
+	}
+	err := db.Model(&entity.Deficiency{}).
+		Distinct("ship_id").
+		Where("ship_id IN ? AND is_detainable = ? AND status != ?", shipIDs, true, entity.DeficiencyStatusVerified).
+		Pluck("ship_id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type EmailVerificationTokenRepositoryImpl struct {
+	baseRepo.Repository[entity.EmailVerificationToken]
+	Log *logrus.Logger
+}
+
+var _ domain.EmailVerificationTokenRepository = (*EmailVerificationTokenRepositoryImpl)(nil)
+
+func NewEmailVerificationTokenRepository(log *logrus.Logger) *EmailVerificationTokenRepositoryImpl {
+	return &EmailVerificationTokenRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *EmailVerificationTokenRepositoryImpl) FindByTokenHash(db *gorm.DB, verificationToken *entity.EmailVerificationToken, tokenHash string) error {
+	return db.Where("token_hash = ?", tokenHash).First(verificationToken).Error
+}
+
+func (r *EmailVerificationTokenRepositoryImpl) DeleteByUserID(db *gorm.DB, userID string) error {
+	return db.Where("user_id = ?", userID).Delete(&entity.EmailVerificationToken{}).Error
+}
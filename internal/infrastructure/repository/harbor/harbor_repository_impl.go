@@ -81,3 +81,11 @@ func (r *HarborRepositoryImpl) CountByUNLocode(db *gorm.DB, unLocode string, exc
 	err := query.Count(&total).Error
 	return total, err
 }
+
+func (r *HarborRepositoryImpl) FindByIdInScope(db *gorm.DB, harbor *entity.Harbor, id string, principalID string) error {
+	return db.Where("id = ? AND deleted_at IS NULL AND id IN (?)", id, r.SelectIDsInScope(db, principalID)).Take(harbor).Error
+}
+
+func (r *HarborRepositoryImpl) SelectIDsInScope(db *gorm.DB, principalID string) *gorm.DB {
+	return baseRepo.HarborIDsInScope(db, principalID)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ImpersonationAuditLogRepositoryImpl struct {
+	baseRepo.Repository[entity.ImpersonationAuditLog]
+	Log *logrus.Logger
+}
+
+var _ domain.ImpersonationAuditLogRepository = (*ImpersonationAuditLogRepositoryImpl)(nil)
+
+func NewImpersonationAuditLogRepository(log *logrus.Logger) *ImpersonationAuditLogRepositoryImpl {
+	return &ImpersonationAuditLogRepositoryImpl{
+		Log: log,
+	}
+}
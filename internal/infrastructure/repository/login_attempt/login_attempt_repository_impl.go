@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type LoginAttemptRepositoryImpl struct {
+	baseRepo.Repository[entity.LoginAttempt]
+	Log *logrus.Logger
+}
+
+var _ domain.LoginAttemptRepository = (*LoginAttemptRepositoryImpl)(nil)
+
+func NewLoginAttemptRepository(log *logrus.Logger) *LoginAttemptRepositoryImpl {
+	return &LoginAttemptRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *LoginAttemptRepositoryImpl) FindByKey(db *gorm.DB, loginAttempt *entity.LoginAttempt, key string) error {
+	return db.Where("attempt_key = ?", key).Take(loginAttempt).Error
+}
+
+func (r *LoginAttemptRepositoryImpl) DeleteByKey(db *gorm.DB, key string) error {
+	return db.Where("attempt_key = ?", key).Delete(&entity.LoginAttempt{}).Error
+}
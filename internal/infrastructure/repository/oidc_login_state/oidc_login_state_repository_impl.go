@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OIDCLoginStateRepositoryImpl struct {
+	baseRepo.Repository[entity.OIDCLoginState]
+	Log *logrus.Logger
+}
+
+var _ domain.OIDCLoginStateRepository = (*OIDCLoginStateRepositoryImpl)(nil)
+
+func NewOIDCLoginStateRepository(log *logrus.Logger) *OIDCLoginStateRepositoryImpl {
+	return &OIDCLoginStateRepositoryImpl{
+		Log: log,
+	}
+}
+
+// ConsumeByStateHash deletes the login state and loads what was deleted, of two concurrent callbacks only one gets it
+func (r *OIDCLoginStateRepositoryImpl) ConsumeByStateHash(db *gorm.DB, state *entity.OIDCLoginState, stateHash string) error {
+	result := db.Clauses(clause.Returning{}).Where("state_hash = ?", stateHash).Delete(state)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *OIDCLoginStateRepositoryImpl) DeleteExpired(db *gorm.DB, now int64) error {
+	return db.Where("expires_at < ?", now).Delete(&entity.OIDCLoginState{}).Error
+}
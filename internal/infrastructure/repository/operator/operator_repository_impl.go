@@ -26,6 +26,10 @@ func (r *OperatorRepositoryImpl) FindByUserID(db *gorm.DB, operator *entity.Oper
 	return db.Where("user_id = ? AND deleted_at IS NULL", userID).First(operator).Error
 }
 
+func (r *OperatorRepositoryImpl) FindByIdInScope(db *gorm.DB, operator *entity.Operator, id string, principalID string) error {
+	return db.Where("id = ? AND deleted_at IS NULL AND id IN (?)", id, baseRepo.OperatorIDsInScope(db, principalID)).Take(operator).Error
+}
+
 func (r *OperatorRepositoryImpl) FindByOperatorCode(db *gorm.DB, operator *entity.Operator, operatorCode string) error {
 	return db.Where("operator_code = ? AND deleted_at IS NULL", operatorCode).First(operator).Error
 }
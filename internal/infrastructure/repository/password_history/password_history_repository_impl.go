@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PasswordHistoryRepositoryImpl struct {
+	baseRepo.Repository[entity.PasswordHistory]
+	Log *logrus.Logger
+}
+
+var _ domain.PasswordHistoryRepository = (*PasswordHistoryRepositoryImpl)(nil)
+
+func NewPasswordHistoryRepository(log *logrus.Logger) *PasswordHistoryRepositoryImpl {
+	return &PasswordHistoryRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *PasswordHistoryRepositoryImpl) FindLatestByUserID(db *gorm.DB, userID string, limit int) ([]entity.PasswordHistory, error) {
+	var history []entity.PasswordHistory
+	err := db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&history).Error
+	return history, err
+}
+
+// PruneByUserID removes everything but the `keep` most recent entries of the user
+func (r *PasswordHistoryRepositoryImpl) PruneByUserID(db *gorm.DB, userID string, keep int) error {
+	latest := db.Model(&entity.PasswordHistory{}).
+		Select("id").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(keep)
+
+	return db.Where("user_id = ? AND id NOT IN (?)", userID, latest).
+		Delete(&entity.PasswordHistory{}).Error
+}
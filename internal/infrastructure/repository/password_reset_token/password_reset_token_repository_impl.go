@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PasswordResetTokenRepositoryImpl struct {
+	baseRepo.Repository[entity.PasswordResetToken]
+	Log *logrus.Logger
+}
+
+var _ domain.PasswordResetTokenRepository = (*PasswordResetTokenRepositoryImpl)(nil)
+
+func NewPasswordResetTokenRepository(log *logrus.Logger) *PasswordResetTokenRepositoryImpl {
+	return &PasswordResetTokenRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *PasswordResetTokenRepositoryImpl) FindByTokenHash(db *gorm.DB, resetToken *entity.PasswordResetToken, tokenHash string) error {
+	return db.Where("token_hash = ?", tokenHash).First(resetToken).Error
+}
+
+func (r *PasswordResetTokenRepositoryImpl) DeleteByUserID(db *gorm.DB, userID string) error {
+	return db.Where("user_id = ?", userID).Delete(&entity.PasswordResetToken{}).Error
+}
@@ -45,3 +45,45 @@ func (r *PermissionRepositoryImpl) CountByName(db *gorm.DB, name string, exclude
 	err := query.Count(&total).Error
 	return total, err
 }
+
+func (r *PermissionRepositoryImpl) FindAllByUserID(db *gorm.DB, userID string) ([]entity.Permission, error) {
+	userRoleIDs := db.Session(&gorm.Session{NewDB: true}).Model(&entity.UserRole{}).Select("role_id").Where("user_id = ?", userID)
+	return r.findAllByInheritedRoles(db, userRoleIDs)
+}
+
+func (r *PermissionRepositoryImpl) FindAllByRoleID(db *gorm.DB, roleID string) ([]entity.Permission, error) {
+	return r.findAllByInheritedRoles(db, []string{roleID})
+}
+
+// findAllByInheritedRoles returns the active permissions granted to the given roles or to any active role they inherit
+// from. The role tree is walked with a recursive query, UNION drops rows already visited so a cycle in the data can not
+// make it recurse forever, and the walk stops at an inactive role.
+func (r *PermissionRepositoryImpl) findAllByInheritedRoles(db *gorm.DB, roleIDs any) ([]entity.Permission, error) {
+	roleTree := db.Session(&gorm.Session{NewDB: true}).Raw(`WITH RECURSIVE role_tree AS (
+		SELECT roles.id, roles.parent_id FROM roles
+		WHERE roles.id IN (?) AND roles.is_active = ? AND roles.deleted_at IS NULL
+		UNION
+		SELECT roles.id, roles.parent_id FROM roles
+		JOIN role_tree ON roles.id = role_tree.parent_id
+		WHERE roles.is_active = ? AND roles.deleted_at IS NULL
+	) SELECT id FROM role_tree`, roleIDs, true, true)
+
+	var permissions []entity.Permission
+	err := db.Distinct("permissions.*").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Where("role_permissions.role_id IN (?)", roleTree).
+		Where("permissions.is_active = ? AND permissions.deleted_at IS NULL", true).
+		Find(&permissions).Error
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (r *PermissionRepositoryImpl) FindAllActiveByNames(db *gorm.DB, names []string) ([]entity.Permission, error) {
+	var permissions []entity.Permission
+	if err := db.Where("name IN ? AND is_active = ? AND deleted_at IS NULL", names, true).Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PortCallRepositoryImpl struct {
+	baseRepo.Repository[entity.PortCall]
+	Log *logrus.Logger
+}
+
+var _ domain.PortCallRepository = (*PortCallRepositoryImpl)(nil)
+
+func NewPortCallRepository(log *logrus.Logger) *PortCallRepositoryImpl {
+	return &PortCallRepositoryImpl{
+		Log: log,
+	}
+}
+
+// Create writes the port call row only, the ship and harbor of a port call are never saved through it
+func (r *PortCallRepositoryImpl) Create(db *gorm.DB, portCall *entity.PortCall) error {
+	return db.Omit(clause.Associations).Create(portCall).Error
+}
+
+// Update writes the port call row only, the ship and harbor loaded with the port call are left untouched
+func (r *PortCallRepositoryImpl) Update(db *gorm.DB, portCall *entity.PortCall) error {
+	return db.Omit(clause.Associations).Save(portCall).Error
+}
+
+func (r *PortCallRepositoryImpl) CountInPortByShipID(db *gorm.DB, shipID string, excludeID string) (int64, error) {
+	var total int64
+	query := db.Model(&entity.PortCall{}).Where("ship_id = ? AND status = ?", shipID, entity.PortCallStatusInPort)
+	if excludeID != "" {
+		query = query.Where("id != ?", excludeID)
+	}
+	err := query.Count(&total).Error
+	return total, err
+}
+
+func (r *PortCallRepositoryImpl) CountByShipID(db *gorm.DB, shipID string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.PortCall{}).Where("ship_id = ?", shipID).Count(&total).Error
+	return total, err
+}
+
+func (r *PortCallRepositoryImpl) CountByHarborID(db *gorm.DB, harborID string) (int64, error) {
+	var total int64
+	err := db.Model(&entity.PortCall{}).Where("harbor_id = ?", harborID).Count(&total).Error
+	return total, err
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RecoveryCodeRepositoryImpl struct {
+	baseRepo.Repository[entity.RecoveryCode]
+	Log *logrus.Logger
+}
+
+var _ domain.RecoveryCodeRepository = (*RecoveryCodeRepositoryImpl)(nil)
+
+func NewRecoveryCodeRepository(log *logrus.Logger) *RecoveryCodeRepositoryImpl {
+	return &RecoveryCodeRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *RecoveryCodeRepositoryImpl) FindUnusedByUserIDAndCodeHash(db *gorm.DB, recoveryCode *entity.RecoveryCode, userID string, codeHash string) error {
+	return db.Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).First(recoveryCode).Error
+}
+
+func (r *RecoveryCodeRepositoryImpl) DeleteByUserID(db *gorm.DB, userID string) error {
+	return db.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error
+}
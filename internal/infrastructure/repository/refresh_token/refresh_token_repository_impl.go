@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepositoryImpl struct {
+	baseRepo.Repository[entity.RefreshToken]
+	Log *logrus.Logger
+}
+
+var _ domain.RefreshTokenRepository = (*RefreshTokenRepositoryImpl)(nil)
+
+func NewRefreshTokenRepository(log *logrus.Logger) *RefreshTokenRepositoryImpl {
+	return &RefreshTokenRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *RefreshTokenRepositoryImpl) FindByTokenHash(db *gorm.DB, refreshToken *entity.RefreshToken, tokenHash string) error {
+	return db.Where("token_hash = ?", tokenHash).First(refreshToken).Error
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeByFamilyID(db *gorm.DB, familyID string, revokedAt int64) error {
+	return db.Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt).Error
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeByUserID(db *gorm.DB, userID string, revokedAt int64) error {
+	return db.Model(&entity.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
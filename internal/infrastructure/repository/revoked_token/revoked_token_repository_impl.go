@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RevokedTokenRepositoryImpl struct {
+	baseRepo.Repository[entity.RevokedToken]
+	Log *logrus.Logger
+}
+
+var _ domain.RevokedTokenRepository = (*RevokedTokenRepositoryImpl)(nil)
+
+func NewRevokedTokenRepository(log *logrus.Logger) *RevokedTokenRepositoryImpl {
+	return &RevokedTokenRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *RevokedTokenRepositoryImpl) DeleteExpired(db *gorm.DB, now int64) error {
+	return db.Where("expires_at < ?", now).Delete(&entity.RevokedToken{}).Error
+}
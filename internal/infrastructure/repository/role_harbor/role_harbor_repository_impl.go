@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type RoleHarborRepositoryImpl struct {
+	baseRepo.Repository[entity.RoleHarbor]
+	Log *logrus.Logger
+}
+
+var _ domain.RoleHarborRepository = (*RoleHarborRepositoryImpl)(nil)
+
+func NewRoleHarborRepository(log *logrus.Logger) *RoleHarborRepositoryImpl {
+	return &RoleHarborRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *RoleHarborRepositoryImpl) FindByRoleIDAndHarborID(db *gorm.DB, roleHarbor *entity.RoleHarbor, roleID string, harborID string) error {
+	return db.Where("role_id = ? AND harbor_id = ?", roleID, harborID).First(roleHarbor).Error
+}
+
+func (r *RoleHarborRepositoryImpl) FindAllByRoleID(db *gorm.DB, roleID string) ([]entity.RoleHarbor, error) {
+	var roleHarbors []entity.RoleHarbor
+	if err := db.Where("role_id = ?", roleID).Find(&roleHarbors).Error; err != nil {
+		return nil, err
+	}
+	return roleHarbors, nil
+}
+
+func (r *RoleHarborRepositoryImpl) DeleteByRoleIDAndHarborID(db *gorm.DB, roleID string, harborID string) error {
+	return db.Where("role_id = ? AND harbor_id = ?", roleID, harborID).Delete(&entity.RoleHarbor{}).Error
+}
@@ -2,10 +2,12 @@ package repository
 
 import (
 	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
 	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type RolePermissionRepositoryImpl struct {
@@ -13,7 +15,7 @@ type RolePermissionRepositoryImpl struct {
 	Log *logrus.Logger
 }
 
-
+var _ domain.RolePermissionRepository = (*RolePermissionRepositoryImpl)(nil)
 
 func NewRolePermissionRepository(log *logrus.Logger) *RolePermissionRepositoryImpl {
 	return &RolePermissionRepositoryImpl{
@@ -48,3 +50,33 @@ func (r *RolePermissionRepositoryImpl) DeleteByRoleIDAndPermissionID(db *gorm.DB
 func (r *RolePermissionRepositoryImpl) DeleteAllByRoleID(db *gorm.DB, roleID string) error {
 	return db.Where("role_id = ?", roleID).Delete(&entity.RolePermission{}).Error
 }
+
+func (r *RolePermissionRepositoryImpl) CreateAllByRoleID(db *gorm.DB, roleID string, permissionIDs []string) error {
+	if len(permissionIDs) == 0 {
+		return nil
+	}
+
+	rolePermissions := make([]entity.RolePermission, len(permissionIDs))
+	for i, permissionID := range permissionIDs {
+		rolePermissions[i] = entity.RolePermission{
+			RoleID:       roleID,
+			PermissionID: permissionID,
+		}
+	}
+	return db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&rolePermissions).Error
+}
+
+func (r *RolePermissionRepositoryImpl) DeleteAllByRoleIDAndPermissionIDs(db *gorm.DB, roleID string, permissionIDs []string) error {
+	if len(permissionIDs) == 0 {
+		return nil
+	}
+	return db.Where("role_id = ? AND permission_id IN ?", roleID, permissionIDs).Delete(&entity.RolePermission{}).Error
+}
+
+// DeleteAllByRoleIDExcept removes every permission of the role that is not in the given list
+func (r *RolePermissionRepositoryImpl) DeleteAllByRoleIDExcept(db *gorm.DB, roleID string, permissionIDs []string) error {
+	if len(permissionIDs) == 0 {
+		return r.DeleteAllByRoleID(db, roleID)
+	}
+	return db.Where("role_id = ? AND permission_id NOT IN ?", roleID, permissionIDs).Delete(&entity.RolePermission{}).Error
+}
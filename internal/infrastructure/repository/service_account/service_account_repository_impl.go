@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ServiceAccountRepositoryImpl struct {
+	baseRepo.Repository[entity.ServiceAccount]
+	Log *logrus.Logger
+}
+
+var _ domain.ServiceAccountRepository = (*ServiceAccountRepositoryImpl)(nil)
+
+func NewServiceAccountRepository(log *logrus.Logger) *ServiceAccountRepositoryImpl {
+	return &ServiceAccountRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *ServiceAccountRepositoryImpl) FindActiveByID(db *gorm.DB, serviceAccount *entity.ServiceAccount, id string) error {
+	return db.Where("id = ? AND deleted_at IS NULL", id).First(serviceAccount).Error
+}
+
+func (r *ServiceAccountRepositoryImpl) CountByName(db *gorm.DB, name string, excludeID string) (int64, error) {
+	var total int64
+	query := db.Model(&entity.ServiceAccount{}).Where("name = ? AND deleted_at IS NULL", name)
+	if excludeID != "" {
+		query = query.Where("id != ?", excludeID)
+	}
+	err := query.Count(&total).Error
+	return total, err
+}
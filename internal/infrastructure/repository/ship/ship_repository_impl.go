@@ -89,3 +89,11 @@ func (r *ShipRepositoryImpl) CountByShipNameAndOperatorID(db *gorm.DB, shipName
 	err := query.Count(&total).Error
 	return total, err
 }
+
+func (r *ShipRepositoryImpl) FindByIdInScope(db *gorm.DB, ship *entity.Ship, id string, principalID string) error {
+	return db.Where("id = ? AND deleted_at IS NULL AND operator_id IN (?)", id, baseRepo.OperatorIDsInScope(db, principalID)).Take(ship).Error
+}
+
+func (r *ShipRepositoryImpl) SelectIDsInScope(db *gorm.DB, principalID string) *gorm.DB {
+	return baseRepo.ShipIDsInScope(db, principalID)
+}
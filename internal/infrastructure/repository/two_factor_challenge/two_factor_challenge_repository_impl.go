@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TwoFactorChallengeRepositoryImpl struct {
+	baseRepo.Repository[entity.TwoFactorChallenge]
+	Log *logrus.Logger
+}
+
+var _ domain.TwoFactorChallengeRepository = (*TwoFactorChallengeRepositoryImpl)(nil)
+
+func NewTwoFactorChallengeRepository(log *logrus.Logger) *TwoFactorChallengeRepositoryImpl {
+	return &TwoFactorChallengeRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *TwoFactorChallengeRepositoryImpl) FindByTokenHash(db *gorm.DB, challenge *entity.TwoFactorChallenge, tokenHash string) error {
+	return db.Where("token_hash = ?", tokenHash).First(challenge).Error
+}
+
+func (r *TwoFactorChallengeRepositoryImpl) DeleteByUserID(db *gorm.DB, userID string) error {
+	return db.Where("user_id = ?", userID).Delete(&entity.TwoFactorChallenge{}).Error
+}
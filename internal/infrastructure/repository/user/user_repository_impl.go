@@ -37,6 +37,10 @@ func (r *UserRepositoryImpl) FindByUsername(db *gorm.DB, user *entity.User, user
 	return db.Where("username = ?", username).First(user).Error
 }
 
+func (r *UserRepositoryImpl) FindByEmail(db *gorm.DB, user *entity.User, email string) error {
+	return db.Where("email = ?", email).First(user).Error
+}
+
 func (r *UserRepositoryImpl) CountByUsername(db *gorm.DB, username, excludeID string) (int64, error) {
 	var count int64
 	query := db.Model(&entity.User{}).Where("username = ?", username)
@@ -49,7 +53,8 @@ func (r *UserRepositoryImpl) CountByUsername(db *gorm.DB, username, excludeID st
 func (r *UserRepositoryImpl) FindByRoleID(db *gorm.DB, roleID string) ([]*entity.User, error) {
 	var users []*entity.User
 
-	err := db.Joins("JOIN user_roles ON users.id = user_roles.user_id").
+	err := db.Preload("UserRoles.Role").
+		Joins("JOIN user_roles ON users.id = user_roles.user_id").
 		Where("user_roles.role_id = ?", roleID).
 		Find(&users).Error
 
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepositoryImpl struct {
+	baseRepo.Repository[entity.UserIdentity]
+	Log *logrus.Logger
+}
+
+var _ domain.UserIdentityRepository = (*UserIdentityRepositoryImpl)(nil)
+
+func NewUserIdentityRepository(log *logrus.Logger) *UserIdentityRepositoryImpl {
+	return &UserIdentityRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *UserIdentityRepositoryImpl) FindByIssuerAndSubject(db *gorm.DB, identity *entity.UserIdentity, issuer, subject string) error {
+	return db.Where("issuer = ? AND subject = ?", issuer, subject).First(identity).Error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
 	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
 
 	"github.com/sirupsen/logrus"
@@ -13,6 +14,8 @@ type UserRoleRepositoryImpl struct {
 	Log *logrus.Logger
 }
 
+var _ domain.UserRoleRepository = (*UserRoleRepositoryImpl)(nil)
+
 func NewUserRoleRepository(log *logrus.Logger) *UserRoleRepositoryImpl {
 	return &UserRoleRepositoryImpl{
 		Log: log,
@@ -31,6 +34,14 @@ func (r *UserRoleRepositoryImpl) FindAllByUserID(db *gorm.DB, userID string) ([]
 	return userRoles, nil
 }
 
+func (r *UserRoleRepositoryImpl) FindAllInScope(db *gorm.DB, principalID string) ([]entity.UserRole, error) {
+	var userRoles []entity.UserRole
+	if err := db.Preload("Role").Where("user_id IN (?)", baseRepo.RoleHolderIDsInScope(db, principalID)).Find(&userRoles).Error; err != nil {
+		return nil, err
+	}
+	return userRoles, nil
+}
+
 func (r *UserRoleRepositoryImpl) FindAllByRoleID(db *gorm.DB, roleID string) ([]entity.UserRole, error) {
 	var userRoles []entity.UserRole
 	if err := db.Preload("User").Where("role_id = ?", roleID).Find(&userRoles).Error; err != nil {
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type UserSessionRepositoryImpl struct {
+	baseRepo.Repository[entity.UserSession]
+	Log *logrus.Logger
+}
+
+var _ domain.UserSessionRepository = (*UserSessionRepositoryImpl)(nil)
+
+func NewUserSessionRepository(log *logrus.Logger) *UserSessionRepositoryImpl {
+	return &UserSessionRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *UserSessionRepositoryImpl) FindByFamilyID(db *gorm.DB, session *entity.UserSession, familyID string) error {
+	return db.Where("family_id = ?", familyID).First(session).Error
+}
+
+func (r *UserSessionRepositoryImpl) FindActiveByUserID(db *gorm.DB, userID string) ([]entity.UserSession, error) {
+	var sessions []entity.UserSession
+	if err := db.Where("user_id = ? AND revoked_at IS NULL", userID).Order("last_seen_at DESC").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *UserSessionRepositoryImpl) RevokeByUserID(db *gorm.DB, userID string, revokedAt int64) error {
+	return db.Model(&entity.UserSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	domain "mkp-boarding-test/internal/domain/repository"
+	baseRepo "mkp-boarding-test/internal/infrastructure/repository/base"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type UserTwoFactorRepositoryImpl struct {
+	baseRepo.Repository[entity.UserTwoFactor]
+	Log *logrus.Logger
+}
+
+var _ domain.UserTwoFactorRepository = (*UserTwoFactorRepositoryImpl)(nil)
+
+func NewUserTwoFactorRepository(log *logrus.Logger) *UserTwoFactorRepositoryImpl {
+	return &UserTwoFactorRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *UserTwoFactorRepositoryImpl) FindByUserID(db *gorm.DB, twoFactor *entity.UserTwoFactor, userID string) error {
+	return db.Where("user_id = ?", userID).First(twoFactor).Error
+}
+
+func (r *UserTwoFactorRepositoryImpl) DeleteByUserID(db *gorm.DB, userID string) error {
+	return db.Where("user_id = ?", userID).Delete(&entity.UserTwoFactor{}).Error
+}
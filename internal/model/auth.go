@@ -5,4 +5,25 @@ type Auth struct {
 	ID       string `json:"id"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
+	// Access token identity, used to revoke the token on logout
+	TokenID        string `json:"token_id"`
+	TokenExpiresAt int64  `json:"token_expires_at"`
+	// Login session the token belongs to
+	SessionID string `json:"session_id"`
+	// Whether the user has two-factor authentication enabled
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
+	// Set when the request was authenticated with an API key, ID is then the service account id
+	ServiceAccountID string `json:"service_account_id,omitempty"`
+	APIKeyID         string `json:"api_key_id,omitempty"`
+	// Set when the token was issued to impersonate the user, the actor is the admin acting as ID
+	ActorID       string `json:"actor_id,omitempty"`
+	ActorUsername string `json:"actor_username,omitempty"`
+}
+
+func (a *Auth) IsServiceAccount() bool {
+	return a.ServiceAccountID != ""
+}
+
+func (a *Auth) IsImpersonated() bool {
+	return a.ActorID != ""
 }
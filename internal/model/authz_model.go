@@ -0,0 +1,47 @@
+package model
+
+type CurrentPermissionsResponse struct {
+	// Roles are the active roles of the user, without the inherited ones
+	Roles []string `json:"roles"`
+	// Grants are the granted permission names, including wildcards and permissions inherited through parent roles
+	Grants []string `json:"grants"`
+	// Permissions are the existing permissions the grants allow, with the wildcards expanded
+	Permissions []string `json:"permissions"`
+	// HarborIDs are the harbors granted to any role of the user through role_harbors
+	HarborIDs []string `json:"harbor_ids"`
+	// OperatorID is set for operator users, they only see the ships of that operator
+	OperatorID *string `json:"operator_id"`
+	// TwoFactorEnrollmentRequired is set while the policy denies every permission until two-factor authentication is enabled
+	TwoFactorEnrollmentRequired bool `json:"two_factor_enrollment_required"`
+}
+
+type AuthzGrantResponse struct {
+	Role  string `json:"role"`
+	Grant string `json:"grant"`
+}
+
+type AuthzCheckResponse struct {
+	UserID     string  `json:"user_id"`
+	Action     string  `json:"action"`
+	ResourceID *string `json:"resource_id"`
+	Allowed    bool    `json:"allowed"`
+	// Reason is the step that decided
+	Reason string `json:"reason"`
+	// GrantedBy lists the roles of the user that grant the action and the grant that matched
+	GrantedBy []AuthzGrantResponse `json:"granted_by"`
+	// Steps explains every check in the order the API applies them
+	Steps []string `json:"steps"`
+}
+
+type CurrentPermissionsRequest struct {
+	UserID string `json:"-" validate:"required,max=100"`
+}
+
+type AuthzCheckRequest struct {
+	UserID string `json:"user_id" validate:"required,max=100,uuid"`
+	// Action is the `resource.action` permission, for example `ship.destroy`
+	Action string `json:"action" validate:"required,max=100"`
+	// ResourceID is the record the action targets, ships, harbors, boardings, deficiencies, port calls and berths are
+	// checked against the scope of the user
+	ResourceID *string `json:"resource_id" validate:"omitempty,max=100,uuid"`
+}
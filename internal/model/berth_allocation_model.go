@@ -0,0 +1,50 @@
+package model
+
+type BerthAllocationResponse struct {
+	ID          string  `json:"id"`
+	BerthID     string  `json:"berth_id"`
+	PortCallID  string  `json:"port_call_id"`
+	StartsAt    int64   `json:"starts_at"`
+	EndsAt      int64   `json:"ends_at"`
+	Remarks     *string `json:"remarks"`
+	AllocatedBy string  `json:"allocated_by"`
+	CreatedAt   int64   `json:"created_at"`
+	UpdatedAt   int64   `json:"updated_at"`
+
+	Berth    *BerthResponse    `json:"berth,omitempty"`
+	PortCall *PortCallResponse `json:"port_call,omitempty"`
+}
+
+// AllocateBerthRequest allocates a berth to a port call, times are Unix milliseconds. The window starts at the ATA of
+// a ship in port or the ETA of an expected ship and ends at the ETD unless given.
+type AllocateBerthRequest struct {
+	PortCallID string  `json:"-" validate:"required,max=100,uuid"`
+	BerthID    string  `json:"berth_id" validate:"required,uuid"`
+	StartsAt   *int64  `json:"starts_at" validate:"omitempty,min=0"`
+	EndsAt     *int64  `json:"ends_at" validate:"omitempty,min=0"`
+	Remarks    *string `json:"remarks" validate:"omitempty,max=2000"`
+}
+
+type ReleaseBerthAllocationRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+// ListBerthAllocationRequest lists the allocations of a berth overlapping the window from..to, a bound of 0 leaves
+// the window open on that side
+type ListBerthAllocationRequest struct {
+	BerthID string `json:"-" validate:"required,max=100,uuid"`
+	From    int64  `json:"from" validate:"min=0"`
+	To      int64  `json:"to" validate:"min=0"`
+}
+
+type ListPortCallBerthAllocationRequest struct {
+	PortCallID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+// SuggestBerthRequest looks for the berths that are free and take the ship of a port call, the window defaults the
+// same way as AllocateBerthRequest
+type SuggestBerthRequest struct {
+	PortCallID string `json:"-" validate:"required,max=100,uuid"`
+	StartsAt   *int64 `json:"starts_at" validate:"omitempty,min=0"`
+	EndsAt     *int64 `json:"ends_at" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,52 @@
+package model
+
+type BerthResponse struct {
+	ID        string   `json:"id"`
+	HarborID  string   `json:"harbor_id"`
+	Code      string   `json:"code"`
+	Name      string   `json:"name"`
+	Length    float64  `json:"length"`
+	Depth     float64  `json:"depth"`
+	ShipTypes []string `json:"ship_types"`
+	IsActive  bool     `json:"is_active"`
+	Notes     *string  `json:"notes"`
+	CreatedAt int64    `json:"created_at"`
+	UpdatedAt int64    `json:"updated_at"`
+}
+
+// CreateBerthRequest registers an active berth of a harbor, length and depth are in meters. A berth without ship
+// types takes any ship.
+type CreateBerthRequest struct {
+	HarborID  string   `json:"-" validate:"required,max=100,uuid"`
+	Code      string   `json:"code" validate:"required,max=50"`
+	Name      string   `json:"name" validate:"required,max=255"`
+	Length    float64  `json:"length" validate:"required,gt=0"`
+	Depth     float64  `json:"depth" validate:"required,gt=0"`
+	ShipTypes []string `json:"ship_types" validate:"omitempty,max=50,dive,required,max=100"`
+	Notes     *string  `json:"notes" validate:"omitempty,max=2000"`
+}
+
+// UpdateBerthRequest changes a berth, ship types replace the ones of the berth when given and an empty list lets the
+// berth take any ship
+type UpdateBerthRequest struct {
+	ID        string    `json:"-" validate:"required,max=100,uuid"`
+	Code      *string   `json:"code" validate:"omitempty,max=50"`
+	Name      *string   `json:"name" validate:"omitempty,max=255"`
+	Length    *float64  `json:"length" validate:"omitempty,gt=0"`
+	Depth     *float64  `json:"depth" validate:"omitempty,gt=0"`
+	ShipTypes *[]string `json:"ship_types" validate:"omitempty,max=50,dive,required,max=100"`
+	IsActive  *bool     `json:"is_active"`
+	Notes     *string   `json:"notes" validate:"omitempty,max=2000"`
+}
+
+type GetBerthRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type DeleteBerthRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type ListHarborBerthRequest struct {
+	HarborID string `json:"-" validate:"required,max=100,uuid"`
+}
@@ -0,0 +1,116 @@
+package model
+
+type BoardingResponse struct {
+	ID                  string   `json:"id"`
+	ShipID              string   `json:"ship_id"`
+	HarborID            string   `json:"harbor_id"`
+	InspectorID         string   `json:"inspector_id"`
+	ChecklistTemplateID *string  `json:"checklist_template_id"`
+	Status              string   `json:"status"`
+	PlannedAt           int64    `json:"planned_at"`
+	StartedAt           *int64   `json:"started_at"`
+	CompletedAt         *int64   `json:"completed_at"`
+	AbortedAt           *int64   `json:"aborted_at"`
+	Latitude            *float64 `json:"latitude"`
+	Longitude           *float64 `json:"longitude"`
+	Outcome             *string  `json:"outcome"`
+	Remarks             *string  `json:"remarks"`
+	CreatedAt           int64    `json:"created_at"`
+	UpdatedAt           int64    `json:"updated_at"`
+
+	Ship   *ShipResponse   `json:"ship,omitempty"`
+	Harbor *HarborResponse `json:"harbor,omitempty"`
+}
+
+type CreateBoardingRequest struct {
+	ShipID   string `json:"ship_id" validate:"required,uuid"`
+	HarborID string `json:"harbor_id" validate:"required,uuid"`
+	// InspectorID defaults to the current user
+	InspectorID *string `json:"inspector_id" validate:"omitempty,uuid"`
+	// PlannedAt is the planned start in Unix milliseconds
+	PlannedAt int64   `json:"planned_at" validate:"required,min=0"`
+	Remarks   *string `json:"remarks" validate:"omitempty,max=2000"`
+}
+
+type UpdateBoardingRequest struct {
+	ID          string  `json:"-" validate:"required,max=100,uuid"`
+	HarborID    *string `json:"harbor_id" validate:"omitempty,uuid"`
+	InspectorID *string `json:"inspector_id" validate:"omitempty,uuid"`
+	PlannedAt   *int64  `json:"planned_at" validate:"omitempty,min=0"`
+	Remarks     *string `json:"remarks" validate:"omitempty,max=2000"`
+}
+
+type StartBoardingRequest struct {
+	ID        string   `json:"-" validate:"required,max=100,uuid"`
+	Latitude  *float64 `json:"latitude" validate:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" validate:"omitempty,min=-180,max=180"`
+}
+
+type CompleteBoardingRequest struct {
+	ID        string   `json:"-" validate:"required,max=100,uuid"`
+	Outcome   string   `json:"outcome" validate:"required,oneof=satisfactory deficiencies detained"`
+	Remarks   *string  `json:"remarks" validate:"omitempty,max=2000"`
+	Latitude  *float64 `json:"latitude" validate:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" validate:"omitempty,min=-180,max=180"`
+}
+
+type AbortBoardingRequest struct {
+	ID      string `json:"-" validate:"required,max=100,uuid"`
+	Remarks string `json:"remarks" validate:"required,max=2000"`
+}
+
+type GetBoardingRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type DeleteBoardingRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type ListBoardingRequest struct {
+	Page        int     `json:"page" validate:"min=1"`
+	Size        int     `json:"size" validate:"min=1,max=100"`
+	ShipID      *string `json:"ship_id"`
+	HarborID    *string `json:"harbor_id"`
+	InspectorID *string `json:"inspector_id"`
+	Status      *string `json:"status"`
+}
+
+// BoardingChecklistResponse is the checklist version of a boarding with the answers given so far
+type BoardingChecklistResponse struct {
+	BoardingID string                     `json:"boarding_id"`
+	Status     string                     `json:"status"`
+	Template   *ChecklistTemplateResponse `json:"template"`
+	Answers    []ChecklistAnswerResponse  `json:"answers"`
+	// RequiredUnanswered counts the required items without an answer, the boarding can be completed at zero
+	RequiredUnanswered int `json:"required_unanswered"`
+}
+
+type ChecklistAnswerResponse struct {
+	ID         string  `json:"id"`
+	ItemID     string  `json:"item_id"`
+	Value      *string `json:"value"`
+	PhotoURL   *string `json:"photo_url"`
+	Remarks    *string `json:"remarks"`
+	AnsweredBy string  `json:"answered_by"`
+	CreatedAt  int64   `json:"created_at"`
+	UpdatedAt  int64   `json:"updated_at"`
+}
+
+type GetBoardingChecklistRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type AnswerBoardingChecklistRequest struct {
+	ID      string                   `json:"-" validate:"required,max=100,uuid"`
+	Answers []ChecklistAnswerRequest `json:"answers" validate:"required,min=1,max=500,dive"`
+}
+
+// ChecklistAnswerRequest answers one item, value is yes, no or na for the yes/no types and a number for numeric items.
+// A photo_url must be an http or https link
+type ChecklistAnswerRequest struct {
+	ItemID   string  `json:"item_id" validate:"required,uuid"`
+	Value    *string `json:"value" validate:"omitempty,max=255"`
+	PhotoURL *string `json:"photo_url" validate:"omitempty,http_url,max=2048"`
+	Remarks  *string `json:"remarks" validate:"omitempty,max=2000"`
+}
@@ -0,0 +1,74 @@
+package model
+
+type ChecklistTemplateResponse struct {
+	ID          string                     `json:"id"`
+	ShipType    string                     `json:"ship_type"`
+	Version     int                        `json:"version"`
+	Name        string                     `json:"name"`
+	Description *string                    `json:"description"`
+	IsActive    bool                       `json:"is_active"`
+	CreatedAt   int64                      `json:"created_at"`
+	UpdatedAt   int64                      `json:"updated_at"`
+	Sections    []ChecklistSectionResponse `json:"sections,omitempty"`
+}
+
+type ChecklistSectionResponse struct {
+	ID       string                  `json:"id"`
+	Title    string                  `json:"title"`
+	Position int                     `json:"position"`
+	Items    []ChecklistItemResponse `json:"items"`
+}
+
+type ChecklistItemResponse struct {
+	ID         string  `json:"id"`
+	Prompt     string  `json:"prompt"`
+	Guidance   *string `json:"guidance"`
+	AnswerType string  `json:"answer_type"`
+	IsRequired bool    `json:"is_required"`
+	Position   int     `json:"position"`
+}
+
+type CreateChecklistTemplateRequest struct {
+	ShipType    string                          `json:"ship_type" validate:"required,max=100"`
+	Name        string                          `json:"name" validate:"required,max=255"`
+	Description *string                         `json:"description" validate:"omitempty,max=2000"`
+	Sections    []CreateChecklistSectionRequest `json:"sections" validate:"required,min=1,max=50,dive"`
+}
+
+type CreateChecklistSectionRequest struct {
+	Title string                       `json:"title" validate:"required,max=255"`
+	Items []CreateChecklistItemRequest `json:"items" validate:"required,min=1,max=200,dive"`
+}
+
+type CreateChecklistItemRequest struct {
+	Prompt   string  `json:"prompt" validate:"required,max=1000"`
+	Guidance *string `json:"guidance" validate:"omitempty,max=2000"`
+	// AnswerType is yes_no, yes_no_na, numeric or photo, a photo answer needs a photo_url
+	AnswerType string `json:"answer_type" validate:"required,oneof=yes_no yes_no_na numeric photo"`
+	// IsRequired defaults to true, a boarding can only be completed once every required item is answered
+	IsRequired *bool `json:"is_required"`
+}
+
+// UpdateChecklistTemplateRequest only changes the label and the availability of a version, a changed checklist is
+// created as the next version
+type UpdateChecklistTemplateRequest struct {
+	ID          string  `json:"-" validate:"required,max=100,uuid"`
+	Name        *string `json:"name" validate:"omitempty,max=255"`
+	Description *string `json:"description" validate:"omitempty,max=2000"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+type GetChecklistTemplateRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type DeleteChecklistTemplateRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type ListChecklistTemplateRequest struct {
+	Page     int     `json:"page" validate:"min=1"`
+	Size     int     `json:"size" validate:"min=1,max=100"`
+	ShipType *string `json:"ship_type"`
+	IsActive *bool   `json:"is_active"`
+}
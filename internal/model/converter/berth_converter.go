@@ -0,0 +1,51 @@
+package converter
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model"
+)
+
+func BerthToResponse(berth *entity.Berth) *model.BerthResponse {
+	shipTypes := make([]string, len(berth.ShipTypes))
+	for i, shipType := range berth.ShipTypes {
+		shipTypes[i] = shipType.ShipType
+	}
+
+	return &model.BerthResponse{
+		ID:        berth.ID,
+		HarborID:  berth.HarborID,
+		Code:      berth.Code,
+		Name:      berth.Name,
+		Length:    berth.Length,
+		Depth:     berth.Depth,
+		ShipTypes: shipTypes,
+		IsActive:  berth.IsActive,
+		Notes:     berth.Notes,
+		CreatedAt: berth.CreatedAt,
+		UpdatedAt: berth.UpdatedAt,
+	}
+}
+
+func BerthAllocationToResponse(allocation *entity.BerthAllocation) *model.BerthAllocationResponse {
+	response := &model.BerthAllocationResponse{
+		ID:          allocation.ID,
+		BerthID:     allocation.BerthID,
+		PortCallID:  allocation.PortCallID,
+		StartsAt:    allocation.StartsAt,
+		EndsAt:      allocation.EndsAt,
+		Remarks:     allocation.Remarks,
+		AllocatedBy: allocation.AllocatedBy,
+		CreatedAt:   allocation.CreatedAt,
+		UpdatedAt:   allocation.UpdatedAt,
+	}
+
+	// Relations are only part of the response when they were loaded
+	if allocation.Berth.ID != "" {
+		response.Berth = BerthToResponse(&allocation.Berth)
+	}
+	if allocation.PortCall.ID != "" {
+		response.PortCall = PortCallToResponse(&allocation.PortCall)
+	}
+
+	return response
+}
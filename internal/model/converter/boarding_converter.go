@@ -0,0 +1,37 @@
+package converter
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model"
+)
+
+func BoardingToResponse(boarding *entity.Boarding) *model.BoardingResponse {
+	response := &model.BoardingResponse{
+		ID:                  boarding.ID,
+		ShipID:              boarding.ShipID,
+		HarborID:            boarding.HarborID,
+		InspectorID:         boarding.InspectorID,
+		ChecklistTemplateID: boarding.ChecklistTemplateID,
+		Status:              boarding.Status,
+		PlannedAt:           boarding.PlannedAt,
+		StartedAt:           boarding.StartedAt,
+		CompletedAt:         boarding.CompletedAt,
+		AbortedAt:           boarding.AbortedAt,
+		Latitude:            boarding.Latitude,
+		Longitude:           boarding.Longitude,
+		Outcome:             boarding.Outcome,
+		Remarks:             boarding.Remarks,
+		CreatedAt:           boarding.CreatedAt,
+		UpdatedAt:           boarding.UpdatedAt,
+	}
+
+	// Relations are only part of the response when they were loaded
+	if boarding.Ship.ID != "" {
+		response.Ship = ShipToResponse(&boarding.Ship)
+	}
+	if boarding.Harbor.ID != "" {
+		response.Harbor = HarborToResponse(&boarding.Harbor)
+	}
+
+	return response
+}
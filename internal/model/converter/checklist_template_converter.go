@@ -0,0 +1,59 @@
+package converter
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model"
+)
+
+func ChecklistTemplateToResponse(template *entity.ChecklistTemplate) *model.ChecklistTemplateResponse {
+	response := &model.ChecklistTemplateResponse{
+		ID:          template.ID,
+		ShipType:    template.ShipType,
+		Version:     template.Version,
+		Name:        template.Name,
+		Description: template.Description,
+		IsActive:    template.IsActive,
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+	}
+
+	// Sections are only part of the response when they were loaded
+	if len(template.Sections) > 0 {
+		response.Sections = make([]model.ChecklistSectionResponse, len(template.Sections))
+		for i, section := range template.Sections {
+			items := make([]model.ChecklistItemResponse, len(section.Items))
+			for j, item := range section.Items {
+				items[j] = model.ChecklistItemResponse{
+					ID:         item.ID,
+					Prompt:     item.Prompt,
+					Guidance:   item.Guidance,
+					AnswerType: item.AnswerType,
+					IsRequired: item.IsRequired,
+					Position:   item.Position,
+				}
+			}
+
+			response.Sections[i] = model.ChecklistSectionResponse{
+				ID:       section.ID,
+				Title:    section.Title,
+				Position: section.Position,
+				Items:    items,
+			}
+		}
+	}
+
+	return response
+}
+
+func ChecklistAnswerToResponse(answer *entity.BoardingChecklistAnswer) *model.ChecklistAnswerResponse {
+	return &model.ChecklistAnswerResponse{
+		ID:         answer.ID,
+		ItemID:     answer.ItemID,
+		Value:      answer.Value,
+		PhotoURL:   answer.PhotoURL,
+		Remarks:    answer.Remarks,
+		AnsweredBy: answer.AnsweredBy,
+		CreatedAt:  answer.CreatedAt,
+		UpdatedAt:  answer.UpdatedAt,
+	}
+}
@@ -0,0 +1,39 @@
+package converter
+
+import (
+	"time"
+
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model"
+)
+
+func DeficiencyToResponse(deficiency *entity.Deficiency) *model.DeficiencyResponse {
+	return &model.DeficiencyResponse{
+		ID:                 deficiency.ID,
+		BoardingID:         deficiency.BoardingID,
+		ShipID:             deficiency.ShipID,
+		Code:               deficiency.Code,
+		Severity:           deficiency.Severity,
+		Description:        deficiency.Description,
+		Deadline:           deficiency.Deadline,
+		IsDetainable:       deficiency.IsDetainable,
+		Status:             deficiencyStatus(deficiency, time.Now().UnixMilli()),
+		RectifiedAt:        deficiency.RectifiedAt,
+		RectifiedBy:        deficiency.RectifiedBy,
+		RectificationNotes: deficiency.RectificationNotes,
+		VerifiedAt:         deficiency.VerifiedAt,
+		VerifiedBy:         deficiency.VerifiedBy,
+		VerificationNotes:  deficiency.VerificationNotes,
+		CreatedBy:          deficiency.CreatedBy,
+		CreatedAt:          deficiency.CreatedAt,
+		UpdatedAt:          deficiency.UpdatedAt,
+	}
+}
+
+// deficiencyStatus reports an open deficiency past its deadline as overdue, overdue is derived and never stored
+func deficiencyStatus(deficiency *entity.Deficiency, now int64) string {
+	if deficiency.Status == entity.DeficiencyStatusOpen && deficiency.Deadline != nil && *deficiency.Deadline < now {
+		return entity.DeficiencyStatusOverdue
+	}
+	return deficiency.Status
+}
@@ -0,0 +1,33 @@
+package converter
+
+import (
+	"testing"
+
+	"mkp-boarding-test/internal/domain/entity"
+)
+
+func TestDeficiencyStatus(t *testing.T) {
+	const now = int64(10_000)
+	deadline := func(value int64) *int64 { return &value }
+
+	tests := []struct {
+		name       string
+		deficiency entity.Deficiency
+		want       string
+	}{
+		{name: "open without a deadline", deficiency: entity.Deficiency{Status: "open"}, want: "open"},
+		{name: "open before the deadline", deficiency: entity.Deficiency{Status: "open", Deadline: deadline(now + 1)}, want: "open"},
+		{name: "open at the deadline", deficiency: entity.Deficiency{Status: "open", Deadline: deadline(now)}, want: "open"},
+		{name: "open past the deadline", deficiency: entity.Deficiency{Status: "open", Deadline: deadline(now - 1)}, want: "overdue"},
+		{name: "rectified past the deadline", deficiency: entity.Deficiency{Status: "rectified", Deadline: deadline(now - 1)}, want: "rectified"},
+		{name: "verified past the deadline", deficiency: entity.Deficiency{Status: "verified", Deadline: deadline(now - 1)}, want: "verified"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deficiencyStatus(&tt.deficiency, now); got != tt.want {
+				t.Fatalf("deficiencyStatus = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
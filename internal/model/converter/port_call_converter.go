@@ -0,0 +1,36 @@
+package converter
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model"
+)
+
+func PortCallToResponse(portCall *entity.PortCall) *model.PortCallResponse {
+	response := &model.PortCallResponse{
+		ID:           portCall.ID,
+		ShipID:       portCall.ShipID,
+		HarborID:     portCall.HarborID,
+		Status:       portCall.Status,
+		ETA:          portCall.ETA,
+		ETD:          portCall.ETD,
+		ATA:          portCall.ATA,
+		ATD:          portCall.ATD,
+		PreviousPort: portCall.PreviousPort,
+		NextPort:     portCall.NextPort,
+		Purpose:      portCall.Purpose,
+		Remarks:      portCall.Remarks,
+		DeclaredBy:   portCall.DeclaredBy,
+		CreatedAt:    portCall.CreatedAt,
+		UpdatedAt:    portCall.UpdatedAt,
+	}
+
+	// Relations are only part of the response when they were loaded
+	if portCall.Ship.ID != "" {
+		response.Ship = ShipToResponse(&portCall.Ship)
+	}
+	if portCall.Harbor.ID != "" {
+		response.Harbor = HarborToResponse(&portCall.Harbor)
+	}
+
+	return response
+}
@@ -6,14 +6,21 @@ import (
 )
 
 func RoleToResponse(role *entity.Role) *model.RoleResponse {
+	permissions := make([]model.PermissionResponse, len(role.RolePermissions))
+	for i, rolePermission := range role.RolePermissions {
+		permissions[i] = *PermissionToResponse(&rolePermission.Permission)
+	}
+
 	return &model.RoleResponse{
 		ID:          role.ID,
 		Name:        role.Name,
 		DisplayName: role.DisplayName,
 		Description: role.Description,
+		ParentID:    role.ParentID,
 		IsActive:    role.IsActive,
 		IsSystem:    role.IsSystem,
 		CreatedAt:   role.CreatedAt,
 		UpdatedAt:   role.UpdatedAt,
+		Permissions: permissions,
 	}
 }
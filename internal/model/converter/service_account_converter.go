@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"mkp-boarding-test/internal/domain/entity"
+	"mkp-boarding-test/internal/model"
+)
+
+func ServiceAccountToResponse(serviceAccount *entity.ServiceAccount) *model.ServiceAccountResponse {
+	return &model.ServiceAccountResponse{
+		ID:          serviceAccount.ID,
+		Name:        serviceAccount.Name,
+		Description: serviceAccount.Description,
+		IsActive:    serviceAccount.IsActive,
+		OperatorID:  serviceAccount.OperatorID,
+		CreatedBy:   serviceAccount.CreatedBy,
+		CreatedAt:   serviceAccount.CreatedAt,
+		UpdatedAt:   serviceAccount.UpdatedAt,
+	}
+}
+
+func APIKeyToResponse(apiKey *entity.APIKey) *model.APIKeyResponse {
+	permissions := make([]string, 0, len(apiKey.APIKeyPermissions))
+	for _, apiKeyPermission := range apiKey.APIKeyPermissions {
+		if apiKeyPermission.Permission.ID != "" {
+			permissions = append(permissions, apiKeyPermission.Permission.Name)
+		}
+	}
+
+	return &model.APIKeyResponse{
+		ID:               apiKey.ID,
+		ServiceAccountID: apiKey.ServiceAccountID,
+		Name:             apiKey.Name,
+		Prefix:           apiKey.Prefix,
+		Permissions:      permissions,
+		ExpiresAt:        apiKey.ExpiresAt,
+		LastUsedAt:       apiKey.LastUsedAt,
+		LastUsedIP:       apiKey.LastUsedIP,
+		RevokedAt:        apiKey.RevokedAt,
+		CreatedBy:        apiKey.CreatedBy,
+		CreatedAt:        apiKey.CreatedAt,
+	}
+}
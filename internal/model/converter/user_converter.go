@@ -6,7 +6,7 @@ import (
 )
 
 func UserToResponse(user *entity.User) *response.UserResponse {
-	return &response.UserResponse{
+	userResponse := &response.UserResponse{
 		ID:                user.ID,
 		Username:          user.Username,
 		Email:             user.Email,
@@ -19,9 +19,29 @@ func UserToResponse(user *entity.User) *response.UserResponse {
 		LastLoginAt:       user.LastLoginAt,
 		EmailVerifiedAt:   user.EmailVerifiedAt,
 		PasswordChangedAt: user.PasswordChangedAt,
+		TwoFactorEnabled:  user.TwoFactorEnabled,
 		CreatedAt:         user.CreatedAt,
 		UpdatedAt:         user.UpdatedAt,
 	}
+
+	// Roles are only present when the user was loaded with its roles
+	for _, userRole := range user.UserRoles {
+		if userRole.Role.ID == "" || userRole.Role.DeletedAt != nil {
+			continue
+		}
+		userResponse.Roles = append(userResponse.Roles, &response.RoleResponse{
+			ID:          userRole.Role.ID,
+			Name:        userRole.Role.Name,
+			DisplayName: userRole.Role.DisplayName,
+			Description: userRole.Role.Description,
+			IsActive:    userRole.Role.IsActive,
+			IsSystem:    userRole.Role.IsSystem,
+			CreatedAt:   userRole.Role.CreatedAt,
+			UpdatedAt:   userRole.Role.UpdatedAt,
+		})
+	}
+
+	return userResponse
 }
 
 func UserToResponseList(users []*entity.User) []*response.UserResponse {
@@ -31,3 +51,13 @@ func UserToResponseList(users []*entity.User) []*response.UserResponse {
 	}
 	return responseUsers
 }
+
+func SessionToResponse(session *entity.UserSession) *response.SessionResponse {
+	return &response.SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		LastSeenAt: session.LastSeenAt,
+		CreatedAt:  session.CreatedAt,
+	}
+}
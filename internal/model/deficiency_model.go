@@ -0,0 +1,73 @@
+package model
+
+type DeficiencyResponse struct {
+	ID                 string  `json:"id"`
+	BoardingID         string  `json:"boarding_id"`
+	ShipID             string  `json:"ship_id"`
+	Code               string  `json:"code"`
+	Severity           string  `json:"severity"`
+	Description        string  `json:"description"`
+	Deadline           *int64  `json:"deadline"`
+	IsDetainable       bool    `json:"is_detainable"`
+	Status             string  `json:"status"`
+	RectifiedAt        *int64  `json:"rectified_at"`
+	RectifiedBy        *string `json:"rectified_by"`
+	RectificationNotes *string `json:"rectification_notes"`
+	VerifiedAt         *int64  `json:"verified_at"`
+	VerifiedBy         *string `json:"verified_by"`
+	VerificationNotes  *string `json:"verification_notes"`
+	CreatedBy          string  `json:"created_by"`
+	CreatedAt          int64   `json:"created_at"`
+	UpdatedAt          int64   `json:"updated_at"`
+}
+
+type CreateDeficiencyRequest struct {
+	BoardingID  string `json:"-" validate:"required,max=100,uuid"`
+	Code        string `json:"code" validate:"required,max=20"`
+	Severity    string `json:"severity" validate:"required,oneof=minor major critical"`
+	Description string `json:"description" validate:"required,max=2000"`
+	// Deadline is the date the corrective action is due in Unix milliseconds
+	Deadline     *int64 `json:"deadline" validate:"omitempty,min=0"`
+	IsDetainable bool   `json:"is_detainable"`
+}
+
+type UpdateDeficiencyRequest struct {
+	ID           string  `json:"-" validate:"required,max=100,uuid"`
+	Code         *string `json:"code" validate:"omitempty,max=20"`
+	Severity     *string `json:"severity" validate:"omitempty,oneof=minor major critical"`
+	Description  *string `json:"description" validate:"omitempty,max=2000"`
+	Deadline     *int64  `json:"deadline" validate:"omitempty,min=0"`
+	IsDetainable *bool   `json:"is_detainable"`
+}
+
+type RectifyDeficiencyRequest struct {
+	ID    string `json:"-" validate:"required,max=100,uuid"`
+	Notes string `json:"notes" validate:"required,max=2000"`
+}
+
+type VerifyDeficiencyRequest struct {
+	ID    string  `json:"-" validate:"required,max=100,uuid"`
+	Notes *string `json:"notes" validate:"omitempty,max=2000"`
+}
+
+type ReopenDeficiencyRequest struct {
+	ID    string `json:"-" validate:"required,max=100,uuid"`
+	Notes string `json:"notes" validate:"required,max=2000"`
+}
+
+type GetDeficiencyRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type ListBoardingDeficiencyRequest struct {
+	BoardingID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+// ListShipDeficiencyRequest lists the deficiencies of a ship, without a status only the deficiencies that are not
+// verified yet
+type ListShipDeficiencyRequest struct {
+	ShipID string  `json:"-" validate:"required,max=100,uuid"`
+	Page   int     `json:"page" validate:"min=1"`
+	Size   int     `json:"size" validate:"min=1,max=100"`
+	Status *string `json:"status" validate:"omitempty,oneof=open rectified verified overdue"`
+}
@@ -25,6 +25,33 @@ type PageMetadata struct {
 	To          int   `json:"to"`
 }
 
+// NewPageMetadata describes the page of a list with size records per page and total records in all
+func NewPageMetadata(page int, size int, total int64) *PageMetadata {
+	lastPage := (total + int64(size) - 1) / int64(size)
+	if lastPage == 0 {
+		lastPage = 1
+	}
+
+	from := (page-1)*size + 1
+	to := page * size
+	if int64(to) > total {
+		to = int(total)
+	}
+	if total == 0 {
+		from = 0
+		to = 0
+	}
+
+	return &PageMetadata{
+		CurrentPage: page,
+		PerPage:     size,
+		Total:       total,
+		LastPage:    lastPage,
+		From:        from,
+		To:          to,
+	}
+}
+
 // SwaggerWebResponse is used for Swagger documentation only
 type SwaggerWebResponse struct {
 	Success bool          `json:"success"`
@@ -0,0 +1,70 @@
+package model
+
+type PortCallResponse struct {
+	ID           string  `json:"id"`
+	ShipID       string  `json:"ship_id"`
+	HarborID     string  `json:"harbor_id"`
+	Status       string  `json:"status"`
+	ETA          int64   `json:"eta"`
+	ETD          *int64  `json:"etd"`
+	ATA          *int64  `json:"ata"`
+	ATD          *int64  `json:"atd"`
+	PreviousPort *string `json:"previous_port"`
+	NextPort     *string `json:"next_port"`
+	Purpose      string  `json:"purpose"`
+	Remarks      *string `json:"remarks"`
+	DeclaredBy   string  `json:"declared_by"`
+	CreatedAt    int64   `json:"created_at"`
+	UpdatedAt    int64   `json:"updated_at"`
+
+	Ship   *ShipResponse   `json:"ship,omitempty"`
+	Harbor *HarborResponse `json:"harbor,omitempty"`
+}
+
+// CreatePortCallRequest declares an expected port call, times are Unix milliseconds
+type CreatePortCallRequest struct {
+	ShipID       string  `json:"ship_id" validate:"required,uuid"`
+	HarborID     string  `json:"harbor_id" validate:"required,uuid"`
+	ETA          int64   `json:"eta" validate:"required,min=0"`
+	ETD          *int64  `json:"etd" validate:"omitempty,min=0"`
+	PreviousPort *string `json:"previous_port" validate:"omitempty,max=255"`
+	NextPort     *string `json:"next_port" validate:"omitempty,max=255"`
+	Purpose      string  `json:"purpose" validate:"required,oneof=cargo passengers bunkering crew_change repairs supplies other"`
+	Remarks      *string `json:"remarks" validate:"omitempty,max=2000"`
+}
+
+type UpdatePortCallRequest struct {
+	ID           string  `json:"-" validate:"required,max=100,uuid"`
+	ETA          *int64  `json:"eta" validate:"omitempty,min=0"`
+	ETD          *int64  `json:"etd" validate:"omitempty,min=0"`
+	PreviousPort *string `json:"previous_port" validate:"omitempty,max=255"`
+	NextPort     *string `json:"next_port" validate:"omitempty,max=255"`
+	Purpose      *string `json:"purpose" validate:"omitempty,oneof=cargo passengers bunkering crew_change repairs supplies other"`
+	Remarks      *string `json:"remarks" validate:"omitempty,max=2000"`
+}
+
+// ArrivePortCallRequest records the arrival of the ship, ATA defaults to now
+type ArrivePortCallRequest struct {
+	ID  string `json:"-" validate:"required,max=100,uuid"`
+	ATA *int64 `json:"ata" validate:"omitempty,min=0"`
+}
+
+// ClosePortCallRequest ends a port call, a ship in port departs at ATD which defaults to now and an expected call is
+// cancelled
+type ClosePortCallRequest struct {
+	ID      string  `json:"-" validate:"required,max=100,uuid"`
+	ATD     *int64  `json:"atd" validate:"omitempty,min=0"`
+	Remarks *string `json:"remarks" validate:"omitempty,max=2000"`
+}
+
+type GetPortCallRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type ListHarborPortCallRequest struct {
+	HarborID string  `json:"-" validate:"required,max=100,uuid"`
+	Page     int     `json:"page" validate:"min=1"`
+	Size     int     `json:"size" validate:"min=1,max=100"`
+	Status   *string `json:"status" validate:"omitempty,oneof=expected in_port departed cancelled"`
+	ShipID   *string `json:"ship_id"`
+}
@@ -5,16 +5,20 @@ type RoleResponse struct {
 	Name        string  `json:"name"`
 	DisplayName string  `json:"display_name"`
 	Description *string `json:"description"`
+	ParentID    *string `json:"parent_id"`
 	IsActive    bool    `json:"is_active"`
 	IsSystem    bool    `json:"is_system"`
 	CreatedAt   int64   `json:"created_at"`
 	UpdatedAt   int64   `json:"updated_at"`
+	// Permissions granted to the role itself, without the inherited ones
+	Permissions []PermissionResponse `json:"permissions"`
 }
 
 type CreateRoleRequest struct {
 	Name        string  `json:"name" validate:"required,max=100"`
 	DisplayName string  `json:"display_name" validate:"required,max=255"`
 	Description *string `json:"description" validate:"omitempty,max=500"`
+	ParentID    *string `json:"parent_id" validate:"omitempty,uuid"`
 	IsActive    *bool   `json:"is_active"`
 }
 
@@ -23,13 +27,28 @@ type UpdateRoleRequest struct {
 	Name        *string `json:"name" validate:"omitempty,max=100"`
 	DisplayName *string `json:"display_name" validate:"omitempty,max=255"`
 	Description *string `json:"description" validate:"omitempty,max=500"`
+	ParentID    *string `json:"parent_id" validate:"omitempty,uuid"`
 	IsActive    *bool   `json:"is_active"`
 }
 
+type RoleEffectivePermissionsResponse struct {
+	RoleID string `json:"role_id"`
+	// InheritedRoles lists the ancestors of the role, nearest parent first
+	InheritedRoles []string `json:"inherited_roles"`
+	// Grants are the granted permission names of the role and its ancestors, including wildcards
+	Grants []string `json:"grants"`
+	// Permissions are the existing permissions the grants allow, with the wildcards expanded
+	Permissions []string `json:"permissions"`
+}
+
 type GetRoleRequest struct {
 	ID string `json:"-" validate:"required,max=100,uuid"`
 }
 
+type GetRoleEffectivePermissionsRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
 type DeleteRoleRequest struct {
 	ID string `json:"-" validate:"required,max=100,uuid"`
 }
@@ -50,3 +69,20 @@ type RemovePermissionsRequest struct {
 	RoleID        string   `json:"-" validate:"required,max=100,uuid"`
 	PermissionIDs []string `json:"permission_ids" validate:"required,dive,uuid"`
 }
+
+type SetPermissionsRequest struct {
+	RoleID        string   `json:"-" validate:"required,max=100,uuid"`
+	PermissionIDs []string `json:"permission_ids" validate:"required,dive,uuid"`
+}
+
+type AssignHarborsRequest struct {
+	RoleID    string   `json:"-" validate:"required,max=100,uuid"`
+	UserID    string   `json:"-" validate:"required,max=100"`
+	HarborIDs []string `json:"harbor_ids" validate:"required,min=1,dive,uuid"`
+}
+
+type RemoveHarborsRequest struct {
+	RoleID    string   `json:"-" validate:"required,max=100,uuid"`
+	UserID    string   `json:"-" validate:"required,max=100"`
+	HarborIDs []string `json:"harbor_ids" validate:"required,min=1,dive,uuid"`
+}
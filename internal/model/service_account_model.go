@@ -0,0 +1,84 @@
+package model
+
+import "mkp-boarding-test/pkg/service"
+
+type ServiceAccountResponse struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	IsActive    bool    `json:"is_active"`
+	OperatorID  *string `json:"operator_id"`
+	CreatedBy   *string `json:"created_by"`
+	CreatedAt   int64   `json:"created_at"`
+	UpdatedAt   int64   `json:"updated_at"`
+}
+
+type APIKeyResponse struct {
+	ID               string   `json:"id"`
+	ServiceAccountID string   `json:"service_account_id"`
+	Name             string   `json:"name"`
+	Prefix           string   `json:"prefix"`
+	Permissions      []string `json:"permissions"`
+	ExpiresAt        *int64   `json:"expires_at"`
+	LastUsedAt       *int64   `json:"last_used_at"`
+	LastUsedIP       *string  `json:"last_used_ip"`
+	RevokedAt        *int64   `json:"revoked_at"`
+	CreatedBy        *string  `json:"created_by"`
+	CreatedAt        int64    `json:"created_at"`
+	// Plain API key, only returned once when the key is created
+	Key string `json:"key,omitempty"`
+}
+
+// APIKeyPrincipal is the service account behind an authenticated API key
+type APIKeyPrincipal struct {
+	ServiceAccountID   string
+	ServiceAccountName string
+	APIKeyID           string
+	Permissions        []string
+}
+
+type CreateServiceAccountRequest struct {
+	Name        string  `json:"name" validate:"required,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+	CreatedBy   string  `json:"-" validate:"required,max=100,uuid"`
+}
+
+type GetServiceAccountRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type DeleteServiceAccountRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type ListServiceAccountRequest struct {
+	Page     int     `json:"page" validate:"min=1"`
+	Size     int     `json:"size" validate:"min=1,max=100"`
+	Name     *string `json:"name"`
+	IsActive *bool   `json:"is_active"`
+}
+
+type CreateAPIKeyRequest struct {
+	ServiceAccountID string   `json:"-" validate:"required,max=100,uuid"`
+	Name             string   `json:"name" validate:"required,max=100"`
+	Permissions      []string `json:"permissions" validate:"required,min=1,dive,required,max=100"`
+	// Unix timestamp in seconds, keys without expiry stay valid until revoked
+	ExpiresAt *int64 `json:"expires_at"`
+	CreatedBy string `json:"-" validate:"required,max=100,uuid"`
+	// Permissions of the caller, a key can never be scoped wider than the user issuing it
+	GrantorPermissions service.PermissionSet `json:"-"`
+}
+
+type ListAPIKeyRequest struct {
+	ServiceAccountID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type RevokeAPIKeyRequest struct {
+	ServiceAccountID string `json:"-" validate:"required,max=100,uuid"`
+	ID               string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type AuthenticateAPIKeyRequest struct {
+	Key       string `json:"-" validate:"required,max=200"`
+	IPAddress string `json:"-" validate:"max=45"`
+}
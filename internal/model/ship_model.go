@@ -34,6 +34,9 @@ type ShipResponse struct {
 	Notes                 *string  `json:"notes"`
 	CreatedAt             int64    `json:"created_at"`
 	UpdatedAt             int64    `json:"updated_at"`
+	// HasOpenDetainableDeficiencies flags a ship with a detainable deficiency that is not verified yet, it is set by
+	// the ship endpoints only
+	HasOpenDetainableDeficiencies *bool `json:"has_open_detainable_deficiencies,omitempty"`
 
 	Operator OperatorResponse `json:"operator"`
 }
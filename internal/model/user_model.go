@@ -13,9 +13,40 @@ type UserResponse struct {
 	LastLoginAt       *int64  `json:"last_login_at"`
 	EmailVerifiedAt   *int64  `json:"email_verified_at"`
 	PasswordChangedAt *int64  `json:"password_changed_at"`
+	TwoFactorEnabled  bool    `json:"two_factor_enabled"`
 	Token             string  `json:"token,omitempty"`
 	CreatedAt         int64   `json:"created_at"`
 	UpdatedAt         int64   `json:"updated_at"`
+
+	// Set by login when the password was accepted but a second factor still has to be submitted to /login/2fa
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	TwoFactorToken    string `json:"two_factor_token,omitempty"`
+	// Set by login when the account must enroll in two-factor authentication before it can use its permissions
+	TwoFactorEnrollmentRequired bool `json:"two_factor_enrollment_required,omitempty"`
+
+	Roles []*RoleResponse `json:"roles,omitempty"`
+}
+
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+type TwoFactorEnrollmentResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type SessionResponse struct {
+	ID         string  `json:"id"`
+	UserAgent  *string `json:"user_agent"`
+	IPAddress  *string `json:"ip_address"`
+	Current    bool    `json:"current"`
+	LastSeenAt int64   `json:"last_seen_at"`
+	CreatedAt  int64   `json:"created_at"`
 }
 
 type VerifyUserRequest struct {
@@ -35,7 +66,6 @@ type UpdateUserRequest struct {
 	ID        string  `json:"-" validate:"required,max=100,uuid"`
 	Username  *string `json:"username" validate:"omitempty,max=100"`
 	Email     *string `json:"email" validate:"omitempty,email,max=255"`
-	Password  *string `json:"password" validate:"omitempty,min=8,max=100"`
 	FirstName *string `json:"first_name" validate:"omitempty,max=100"`
 	LastName  *string `json:"last_name" validate:"omitempty,max=100"`
 	Phone     *string `json:"phone" validate:"omitempty,max=20"`
@@ -44,12 +74,39 @@ type UpdateUserRequest struct {
 }
 
 type LoginUserRequest struct {
-	Username string `json:"username" validate:"required,max=100"`
-	Password string `json:"password" validate:"required,max=100"`
+	Username  string `json:"username" validate:"required,max=100"`
+	Password  string `json:"password" validate:"required,max=100"`
+	UserAgent string `json:"-" validate:"max=500"`
+	IPAddress string `json:"-" validate:"max=45"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,max=500"`
+	UserAgent    string `json:"-" validate:"max=500"`
+	IPAddress    string `json:"-" validate:"max=45"`
+}
+
+type AuthenticateUserRequest struct {
+	UserID    string `json:"-" validate:"required,max=100,uuid"`
+	TokenID   string `json:"-" validate:"required,max=100"`
+	SessionID string `json:"-" validate:"omitempty,max=100,uuid"`
 }
 
 type LogoutUserRequest struct {
-	ID string `json:"-" validate:"required,max=100,uuid"`
+	ID             string `json:"-" validate:"required,max=100,uuid"`
+	SessionID      string `json:"-" validate:"omitempty,max=100,uuid"`
+	TokenID        string `json:"-" validate:"omitempty,max=100"`
+	TokenExpiresAt int64  `json:"-"`
+}
+
+type ListSessionRequest struct {
+	UserID           string `json:"-" validate:"required,max=100,uuid"`
+	CurrentSessionID string `json:"-" validate:"omitempty,max=100,uuid"`
+}
+
+type RevokeSessionRequest struct {
+	UserID string `json:"-" validate:"required,max=100,uuid"`
+	ID     string `json:"-" validate:"required,max=100,uuid"`
 }
 
 type GetUserRequest struct {
@@ -60,6 +117,16 @@ type DeleteUserRequest struct {
 	ID string `json:"-" validate:"required,max=100,uuid"`
 }
 
+type UnlockUserRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type AdminUpdateUserRequest struct {
+	ID         string `json:"-" validate:"required,max=100,uuid"`
+	IsActive   *bool  `json:"is_active"`
+	IsVerified *bool  `json:"is_verified"`
+}
+
 type ListUserRequest struct {
 	Page       int     `json:"page" validate:"min=1"`
 	Size       int     `json:"size" validate:"min=1,max=100"`
@@ -92,6 +159,44 @@ type ResetPasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=8,max=100"`
 }
 
+type LoginTwoFactorRequest struct {
+	TwoFactorToken string `json:"two_factor_token" validate:"required,max=255"`
+	Code           string `json:"code" validate:"required,max=20"`
+	UserAgent      string `json:"-" validate:"max=500"`
+	IPAddress      string `json:"-" validate:"max=45"`
+}
+
+type OIDCCallbackRequest struct {
+	Code      string `json:"code" validate:"required,max=2048"`
+	State     string `json:"state" validate:"required,max=255"`
+	UserAgent string `json:"-" validate:"max=500"`
+	IPAddress string `json:"-" validate:"max=45"`
+}
+
+type EnrollTwoFactorRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
+type ConfirmTwoFactorRequest struct {
+	ID   string `json:"-" validate:"required,max=100,uuid"`
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type DisableTwoFactorRequest struct {
+	ID       string `json:"-" validate:"required,max=100,uuid"`
+	Password string `json:"password" validate:"required,max=100"`
+	Code     string `json:"code" validate:"required,max=20"`
+}
+
+type RegenerateRecoveryCodesRequest struct {
+	ID   string `json:"-" validate:"required,max=100,uuid"`
+	Code string `json:"code" validate:"required,max=20"`
+}
+
+type ResetTwoFactorRequest struct {
+	ID string `json:"-" validate:"required,max=100,uuid"`
+}
+
 type AssignRolesRequest struct {
 	UserID  string   `json:"-" validate:"required,max=100,uuid"`
 	RoleIDs []string `json:"role_ids" validate:"required,dive,uuid"`
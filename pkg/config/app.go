@@ -1,25 +1,56 @@
 package config
 
 import (
-	"time"
-
 	"mkp-boarding-test/internal/delivery/http/handler"
 	"mkp-boarding-test/internal/delivery/http/middleware"
 	route "mkp-boarding-test/internal/delivery/http/router"
+	"mkp-boarding-test/internal/gateway/mail"
 	"mkp-boarding-test/internal/gateway/messaging"
+	apiKeyRepo "mkp-boarding-test/internal/infrastructure/repository/api_key"
+	berthRepo "mkp-boarding-test/internal/infrastructure/repository/berth"
+	berthAllocationRepo "mkp-boarding-test/internal/infrastructure/repository/berth_allocation"
+	boardingRepo "mkp-boarding-test/internal/infrastructure/repository/boarding"
+	boardingChecklistAnswerRepo "mkp-boarding-test/internal/infrastructure/repository/boarding_checklist_answer"
+	checklistTemplateRepo "mkp-boarding-test/internal/infrastructure/repository/checklist_template"
+	deficiencyRepo "mkp-boarding-test/internal/infrastructure/repository/deficiency"
+	verificationRepo "mkp-boarding-test/internal/infrastructure/repository/email_verification_token"
 	harborRepo "mkp-boarding-test/internal/infrastructure/repository/harbor"
+	impersonationAuditLogRepo "mkp-boarding-test/internal/infrastructure/repository/impersonation_audit_log"
+	loginAttemptRepo "mkp-boarding-test/internal/infrastructure/repository/login_attempt"
+	oidcLoginStateRepo "mkp-boarding-test/internal/infrastructure/repository/oidc_login_state"
 	operatorRepo "mkp-boarding-test/internal/infrastructure/repository/operator"
+	passwordHistoryRepo "mkp-boarding-test/internal/infrastructure/repository/password_history"
+	passwordResetRepo "mkp-boarding-test/internal/infrastructure/repository/password_reset_token"
 	permissionRepo "mkp-boarding-test/internal/infrastructure/repository/permission"
+	portCallRepo "mkp-boarding-test/internal/infrastructure/repository/port_call"
+	recoveryCodeRepo "mkp-boarding-test/internal/infrastructure/repository/recovery_code"
+	refreshTokenRepo "mkp-boarding-test/internal/infrastructure/repository/refresh_token"
+	revokedTokenRepo "mkp-boarding-test/internal/infrastructure/repository/revoked_token"
 	roleRepo "mkp-boarding-test/internal/infrastructure/repository/role"
+	roleHarborRepo "mkp-boarding-test/internal/infrastructure/repository/role_harbor"
+	rolePermissionRepo "mkp-boarding-test/internal/infrastructure/repository/role_permission"
+	serviceAccountRepo "mkp-boarding-test/internal/infrastructure/repository/service_account"
+	twoFactorChallengeRepo "mkp-boarding-test/internal/infrastructure/repository/two_factor_challenge"
+	userSessionRepo "mkp-boarding-test/internal/infrastructure/repository/user_session"
+	userTwoFactorRepo "mkp-boarding-test/internal/infrastructure/repository/user_two_factor"
 
+	authzUsecase "mkp-boarding-test/internal/application/usecase/authz"
+	berthUsecase "mkp-boarding-test/internal/application/usecase/berth"
+	boardingUsecase "mkp-boarding-test/internal/application/usecase/boarding"
+	checklistTemplateUsecase "mkp-boarding-test/internal/application/usecase/checklist_template"
+	deficiencyUsecase "mkp-boarding-test/internal/application/usecase/deficiency"
 	harborUsecase "mkp-boarding-test/internal/application/usecase/harbor"
 	operatorUsecase "mkp-boarding-test/internal/application/usecase/operator"
 	permissionUsecase "mkp-boarding-test/internal/application/usecase/permission"
+	portCallUsecase "mkp-boarding-test/internal/application/usecase/port_call"
 	roleUsecase "mkp-boarding-test/internal/application/usecase/role"
+	serviceAccountUsecase "mkp-boarding-test/internal/application/usecase/service_account"
 	shipUsecase "mkp-boarding-test/internal/application/usecase/ship"
 	userUsecase "mkp-boarding-test/internal/application/usecase/user"
 	shipRepo "mkp-boarding-test/internal/infrastructure/repository/ship"
 	userRepo "mkp-boarding-test/internal/infrastructure/repository/user"
+	userIdentityRepo "mkp-boarding-test/internal/infrastructure/repository/user_identity"
+	userRoleRepo "mkp-boarding-test/internal/infrastructure/repository/user_role"
 	"mkp-boarding-test/pkg/service"
 
 	"github.com/IBM/sarama"
@@ -37,25 +68,85 @@ type BootstrapConfig struct {
 	Validate *validator.Validate
 	Config   *viper.Viper
 	Producer sarama.SyncProducer
+	Mailer   mail.Mailer
 }
 
 func Bootstrap(config *BootstrapConfig) {
 	// setup repositories
 	userRepository := userRepo.NewUserRepository(config.Log)
 	roleRepository := roleRepo.NewRoleRepository(config.Log)
+	userRoleRepository := userRoleRepo.NewUserRoleRepository(config.Log)
 	permissionRepository := permissionRepo.NewPermissionRepository(config.Log)
+	refreshTokenRepository := refreshTokenRepo.NewRefreshTokenRepository(config.Log)
+	revokedTokenRepository := revokedTokenRepo.NewRevokedTokenRepository(config.Log)
+	userSessionRepository := userSessionRepo.NewUserSessionRepository(config.Log)
+	verificationRepository := verificationRepo.NewEmailVerificationTokenRepository(config.Log)
+	passwordResetRepository := passwordResetRepo.NewPasswordResetTokenRepository(config.Log)
+	passwordHistoryRepository := passwordHistoryRepo.NewPasswordHistoryRepository(config.Log)
+	loginAttemptRepository := loginAttemptRepo.NewLoginAttemptRepository(config.Log)
+	twoFactorRepository := userTwoFactorRepo.NewUserTwoFactorRepository(config.Log)
+	recoveryCodeRepository := recoveryCodeRepo.NewRecoveryCodeRepository(config.Log)
+	twoFactorChallengeRepository := twoFactorChallengeRepo.NewTwoFactorChallengeRepository(config.Log)
+	userIdentityRepository := userIdentityRepo.NewUserIdentityRepository(config.Log)
+	oidcLoginStateRepository := oidcLoginStateRepo.NewOIDCLoginStateRepository(config.Log)
+	impersonationAuditLogRepository := impersonationAuditLogRepo.NewImpersonationAuditLogRepository(config.Log)
 
 	operatorRepository := operatorRepo.NewOperatorRepository(config.Log)
 	shipRepository := shipRepo.NewShipRepository(config.Log)
 	harborRepository := harborRepo.NewHarborRepository(config.Log)
+	boardingRepository := boardingRepo.NewBoardingRepository(config.Log)
+	checklistTemplateRepository := checklistTemplateRepo.NewChecklistTemplateRepository(config.Log)
+	boardingChecklistAnswerRepository := boardingChecklistAnswerRepo.NewBoardingChecklistAnswerRepository(config.Log)
+	deficiencyRepository := deficiencyRepo.NewDeficiencyRepository(config.Log)
+	portCallRepository := portCallRepo.NewPortCallRepository(config.Log)
+	berthRepository := berthRepo.NewBerthRepository(config.Log)
+	berthAllocationRepository := berthAllocationRepo.NewBerthAllocationRepository(config.Log)
+	roleHarborRepository := roleHarborRepo.NewRoleHarborRepository(config.Log)
+	rolePermissionRepository := rolePermissionRepo.NewRolePermissionRepository(config.Log)
+	serviceAccountRepository := serviceAccountRepo.NewServiceAccountRepository(config.Log)
+	apiKeyRepository := apiKeyRepo.NewAPIKeyRepository(config.Log)
 
 	// setup JWT service
-	jwtService := service.NewJWTService(
-		"your-secret-key",
-		"your-refresh-key",
-		24*time.Hour,   // token expiry
-		7*24*time.Hour, // refresh token expiry
-	)
+	jwtService := NewJWTService(config.Config, config.Log)
+
+	// setup password policy
+	passwordPolicy := &service.PasswordPolicy{
+		MinLength:     config.Config.GetInt("auth.password.min_length"),
+		RequireUpper:  config.Config.GetBool("auth.password.require_upper"),
+		RequireLower:  config.Config.GetBool("auth.password.require_lower"),
+		RequireDigit:  config.Config.GetBool("auth.password.require_digit"),
+		RequireSymbol: config.Config.GetBool("auth.password.require_symbol"),
+		HistorySize:   config.Config.GetInt("auth.password.history"),
+	}
+
+	// setup login lockout policy
+	lockoutPolicy := &service.LockoutPolicy{
+		FreeAttempts:    config.Config.GetInt("auth.lockout.free_attempts"),
+		MaxAttempts:     config.Config.GetInt("auth.lockout.max_attempts"),
+		IPMaxAttempts:   config.Config.GetInt("auth.lockout.ip_max_attempts"),
+		BaseDelay:       config.Config.GetDuration("auth.lockout.base_delay"),
+		LockoutDuration: config.Config.GetDuration("auth.lockout.duration"),
+	}
+
+	// setup two-factor authentication
+	totpService, err := service.NewTOTPService(config.Config.GetString("auth.two_factor.issuer"), config.Config.GetString("auth.two_factor.secret_key"))
+	if err != nil {
+		config.Log.Fatalf("Failed to setup two-factor authentication: %v", err)
+	}
+
+	twoFactorPolicy := &service.TwoFactorPolicy{
+		MandatoryForPrivileged: config.Config.GetBool("auth.two_factor.mandatory_for_privileged"),
+		ChallengeTTL:           config.Config.GetDuration("auth.two_factor.challenge_ttl"),
+	}
+
+	// setup impersonation
+	config.Config.SetDefault("auth.impersonation.token_ttl", "15m")
+	impersonationPolicy := &service.ImpersonationPolicy{
+		TokenTTL: config.Config.GetDuration("auth.impersonation.token_ttl"),
+	}
+
+	// setup identity provider login
+	oidcService, oidcPolicy := NewOIDC(config.Config, config.Log)
 
 	// setup producer
 	var userProducer *messaging.UserProducer
@@ -64,13 +155,27 @@ func Bootstrap(config *BootstrapConfig) {
 		userProducer = messaging.NewUserProducer(config.Producer, config.Log)
 	}
 
+	// setup mailer
+	var userMailer *mail.UserMailer
+
+	if config.Mailer != nil {
+		userMailer = mail.NewUserMailer(config.Mailer, config.Config.GetString("mail.verification_url"), config.Config.GetString("mail.password_reset_url"), config.Log)
+	}
+
 	// setup use cases
-	userUseCase := userUsecase.NewUserUseCase(config.DB, config.Log, config.Validate, userRepository, userProducer, jwtService)
-	roleUseCase := roleUsecase.NewRoleUseCase(config.DB, config.Log, config.Validate, roleRepository, permissionRepository)
+	userUseCase := userUsecase.NewUserUseCase(config.DB, config.Log, config.Validate, userRepository, roleRepository, userRoleRepository, refreshTokenRepository, revokedTokenRepository, userSessionRepository, verificationRepository, passwordResetRepository, passwordHistoryRepository, loginAttemptRepository, permissionRepository, twoFactorRepository, recoveryCodeRepository, twoFactorChallengeRepository, userIdentityRepository, oidcLoginStateRepository, impersonationAuditLogRepository, userProducer, userMailer, jwtService, passwordPolicy, lockoutPolicy, totpService, twoFactorPolicy, oidcService, oidcPolicy, impersonationPolicy)
+	roleUseCase := roleUsecase.NewRoleUseCase(config.DB, config.Log, config.Validate, roleRepository, permissionRepository, harborRepository, roleHarborRepository, rolePermissionRepository)
 	permissionUseCase := permissionUsecase.NewPermissionUseCase(config.DB, config.Log, config.Validate, permissionRepository)
 	operatorUseCase := operatorUsecase.NewOperatorUseCase(config.DB, config.Log, config.Validate, operatorRepository)
-	shipUseCase := shipUsecase.NewShipUseCase(config.DB, config.Log, config.Validate, shipRepository)
-	harborUseCase := harborUsecase.NewHarborUseCase(config.DB, config.Log, config.Validate, harborRepository)
+	shipUseCase := shipUsecase.NewShipUseCase(config.DB, config.Log, config.Validate, shipRepository, operatorRepository, deficiencyRepository, boardingRepository, portCallRepository)
+	harborUseCase := harborUsecase.NewHarborUseCase(config.DB, config.Log, config.Validate, harborRepository, roleHarborRepository, userRoleRepository, boardingRepository, portCallRepository, berthRepository)
+	boardingUseCase := boardingUsecase.NewBoardingUseCase(config.DB, config.Log, config.Validate, boardingRepository, shipRepository, harborRepository, userRepository, permissionRepository, checklistTemplateRepository, boardingChecklistAnswerRepository)
+	checklistTemplateUseCase := checklistTemplateUsecase.NewChecklistTemplateUseCase(config.DB, config.Log, config.Validate, checklistTemplateRepository, boardingRepository)
+	deficiencyUseCase := deficiencyUsecase.NewDeficiencyUseCase(config.DB, config.Log, config.Validate, deficiencyRepository, boardingRepository, shipRepository, harborRepository)
+	portCallUseCase := portCallUsecase.NewPortCallUseCase(config.DB, config.Log, config.Validate, portCallRepository, shipRepository, harborRepository)
+	berthUseCase := berthUsecase.NewBerthUseCase(config.DB, config.Log, config.Validate, berthRepository, berthAllocationRepository, portCallRepository, shipRepository, harborRepository)
+	serviceAccountUseCase := serviceAccountUsecase.NewServiceAccountUseCase(config.DB, config.Log, config.Validate, serviceAccountRepository, apiKeyRepository, permissionRepository, operatorRepository)
+	authzUseCase := authzUsecase.NewAuthzUseCase(config.DB, config.Log, config.Validate, userRepository, userRoleRepository, permissionRepository, harborRepository, operatorRepository, shipRepository, boardingRepository, deficiencyRepository, portCallRepository, berthRepository, twoFactorPolicy)
 
 	// setup controller
 	userController := handler.NewUserController(userUseCase, config.Log)
@@ -79,19 +184,39 @@ func Bootstrap(config *BootstrapConfig) {
 	operatorController := handler.NewOperatorController(operatorUseCase, config.Log)
 	shipController := handler.NewShipController(shipUseCase, config.Log)
 	harborController := handler.NewHarborController(harborUseCase, config.Log)
+	boardingController := handler.NewBoardingController(boardingUseCase, config.Log)
+	checklistTemplateController := handler.NewChecklistTemplateController(checklistTemplateUseCase, config.Log)
+	deficiencyController := handler.NewDeficiencyController(deficiencyUseCase, config.Log)
+	portCallController := handler.NewPortCallController(portCallUseCase, config.Log)
+	berthController := handler.NewBerthController(berthUseCase, config.Log)
+	serviceAccountController := handler.NewServiceAccountController(serviceAccountUseCase, config.Log)
+	jwksController := handler.NewJWKSController(jwtService, config.Log)
+	authzController := handler.NewAuthzController(authzUseCase, config.Log)
 
 	// setup middleware
-	authMiddleware := middleware.NewAuth(userUseCase, jwtService, config.Log)
+	authMiddleware := middleware.NewAuth(userUseCase, serviceAccountUseCase, jwtService, config.Config.GetBool("auth.require_verified_email"), config.Log)
+	permissionMiddleware := middleware.NewPermission(permissionUseCase, twoFactorPolicy, config.Log)
+	impersonationMiddleware := middleware.NewImpersonationAudit(userUseCase, config.Log)
 
 	routeConfig := route.RouteConfig{
-		App:                  config.App,
-		UserController:       userController,
-		RoleController:       roleController,
-		PermissionController: permissionController,
-		OperatorController:   operatorController,
-		ShipController:       shipController,
-		HarborController:     harborController,
-		AuthMiddleware:       authMiddleware,
+		App:                         config.App,
+		UserController:              userController,
+		RoleController:              roleController,
+		PermissionController:        permissionController,
+		OperatorController:          operatorController,
+		ShipController:              shipController,
+		HarborController:            harborController,
+		BoardingController:          boardingController,
+		ChecklistTemplateController: checklistTemplateController,
+		DeficiencyController:        deficiencyController,
+		PortCallController:          portCallController,
+		BerthController:             berthController,
+		ServiceAccountController:    serviceAccountController,
+		JWKSController:              jwksController,
+		AuthzController:             authzController,
+		AuthMiddleware:              authMiddleware,
+		ImpersonationMiddleware:     impersonationMiddleware,
+		PermissionMiddleware:        permissionMiddleware,
 	}
 	routeConfig.Setup()
 	routeConfig.SetupSwaggerRoute()
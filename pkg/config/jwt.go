@@ -0,0 +1,73 @@
+package config
+
+import (
+	"mkp-boarding-test/pkg/service"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type jwtKeyConfig struct {
+	Kid  string `mapstructure:"kid"`
+	Path string `mapstructure:"path"`
+}
+
+// NewJWTService builds the JWT service from the `jwt` config section. HS256 signs access tokens with a shared
+// secret, RS256 and EdDSA sign them with the PEM key in `jwt.signing_key` and publish it on the JWKS endpoint.
+func NewJWTService(config *viper.Viper, log *logrus.Logger) service.JWTService {
+	config.SetDefault("jwt.algorithm", "HS256")
+	config.SetDefault("jwt.token_expiry", "24h")
+	config.SetDefault("jwt.refresh_expiry", "168h")
+
+	algorithm := config.GetString("jwt.algorithm")
+	refreshSecret := config.GetString("jwt.refresh_secret")
+	tokenExpiry := config.GetDuration("jwt.token_expiry")
+	refreshExpiry := config.GetDuration("jwt.refresh_expiry")
+
+	if refreshSecret == "" {
+		log.Fatal("jwt.refresh_secret must be configured")
+	}
+
+	switch algorithm {
+	case "HS256":
+		secret := config.GetString("jwt.secret")
+		if secret == "" {
+			log.Fatal("jwt.secret must be configured for HS256")
+		}
+		return service.NewJWTService(secret, refreshSecret, tokenExpiry, refreshExpiry)
+	case "RS256", "EdDSA":
+		var signingKeyConfig jwtKeyConfig
+		if err := config.UnmarshalKey("jwt.signing_key", &signingKeyConfig); err != nil {
+			log.Fatalf("Failed to read jwt.signing_key: %v", err)
+		}
+		signingKey := loadJWTKey(signingKeyConfig, algorithm, log)
+		if signingKey.PrivateKey == nil {
+			log.Fatalf("jwt.signing_key %s must be a private key", signingKeyConfig.Path)
+		}
+
+		var verificationKeyConfigs []jwtKeyConfig
+		if err := config.UnmarshalKey("jwt.verification_keys", &verificationKeyConfigs); err != nil {
+			log.Fatalf("Failed to read jwt.verification_keys: %v", err)
+		}
+		verificationKeys := make([]*service.JWTKey, 0, len(verificationKeyConfigs))
+		for _, keyConfig := range verificationKeyConfigs {
+			verificationKeys = append(verificationKeys, loadJWTKey(keyConfig, "", log))
+		}
+
+		return service.NewAsymmetricJWTService(signingKey, verificationKeys, refreshSecret, tokenExpiry, refreshExpiry)
+	default:
+		log.Fatalf("Unknown JWT algorithm: %s", algorithm)
+		return nil
+	}
+}
+
+func loadJWTKey(keyConfig jwtKeyConfig, algorithm string, log *logrus.Logger) *service.JWTKey {
+	key, err := service.LoadJWTKey(keyConfig.Kid, keyConfig.Path)
+	if err != nil {
+		log.Fatalf("Failed to load JWT key %s: %v", keyConfig.Path, err)
+	}
+	if algorithm != "" && key.Method.Alg() != algorithm {
+		log.Fatalf("JWT key %s is a %s key, expected %s", keyConfig.Path, key.Method.Alg(), algorithm)
+	}
+	return key
+}
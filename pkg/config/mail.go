@@ -0,0 +1,27 @@
+package config
+
+import (
+	"mkp-boarding-test/internal/gateway/mail"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+func NewMailer(config *viper.Viper, log *logrus.Logger) mail.Mailer {
+	switch driver := config.GetString("mail.driver"); driver {
+	case "smtp":
+		return mail.NewSMTPMailer(
+			config.GetString("mail.smtp.host"),
+			config.GetInt("mail.smtp.port"),
+			config.GetString("mail.smtp.username"),
+			config.GetString("mail.smtp.password"),
+			config.GetString("mail.from"),
+			log,
+		)
+	case "", "log":
+		return mail.NewLogMailer(config.GetString("mail.file.path"), log)
+	default:
+		log.Fatalf("Unknown mail driver: %s", driver)
+		return nil
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"mkp-boarding-test/pkg/service"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewOIDC builds the OpenID Connect client and provisioning policy from the `auth.oidc` config section,
+// the client is nil while `auth.oidc.enabled` is false
+func NewOIDC(config *viper.Viper, log *logrus.Logger) (service.OIDCService, *service.OIDCPolicy) {
+	config.SetDefault("auth.oidc.state_ttl", "10m")
+	config.SetDefault("auth.oidc.groups_claim", "groups")
+
+	policy := &service.OIDCPolicy{
+		AutoProvision: config.GetBool("auth.oidc.auto_provision"),
+		StateTTL:      config.GetDuration("auth.oidc.state_ttl"),
+	}
+
+	// Group mappings are a list rather than a map because viper lower-cases map keys and group names are case-sensitive
+	if err := config.UnmarshalKey("auth.oidc.group_roles", &policy.GroupRoles); err != nil {
+		log.Fatalf("Failed to read auth.oidc.group_roles: %v", err)
+	}
+
+	if !config.GetBool("auth.oidc.enabled") {
+		return nil, policy
+	}
+
+	oidcConfig := service.OIDCConfig{
+		Issuer:       config.GetString("auth.oidc.issuer"),
+		ClientID:     config.GetString("auth.oidc.client_id"),
+		ClientSecret: config.GetString("auth.oidc.client_secret"),
+		RedirectURL:  config.GetString("auth.oidc.redirect_url"),
+		Scopes:       config.GetStringSlice("auth.oidc.scopes"),
+		GroupsClaim:  config.GetString("auth.oidc.groups_claim"),
+	}
+
+	if oidcConfig.Issuer == "" || oidcConfig.ClientID == "" || oidcConfig.RedirectURL == "" {
+		log.Fatal("auth.oidc.issuer, client_id and redirect_url must be configured when OIDC login is enabled")
+	}
+
+	return service.NewOIDCService(oidcConfig), policy
+}
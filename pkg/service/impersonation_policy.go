@@ -0,0 +1,12 @@
+package service
+
+import "time"
+
+// ImpersonatePermission allows a user to act as another user
+const ImpersonatePermission = "user.impersonate"
+
+// ImpersonationPolicy describes the tokens admins get to act as another user
+type ImpersonationPolicy struct {
+	// TokenTTL is how long an impersonation token stays valid, it can not be refreshed
+	TokenTTL time.Duration
+}
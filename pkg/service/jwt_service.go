@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -10,11 +11,17 @@ import (
 )
 
 type JWTService interface {
-	GenerateToken(user *entity.User) (string, error)
+	GenerateToken(user *entity.User, sessionID string) (string, error)
+	GenerateImpersonationToken(user *entity.User, actor *entity.User, expiry time.Duration) (string, error)
 	GenerateRefreshToken(user *entity.User) (string, error)
 	ValidateToken(tokenString string) (*jwt.Token, error)
 	ExtractUserIDFromToken(tokenString string) (string, error)
 	ExtractClaimsFromToken(tokenString string) (jwt.MapClaims, error)
+	ValidateRefreshToken(tokenString string) (*jwt.Token, error)
+	ExtractClaimsFromRefreshToken(tokenString string) (jwt.MapClaims, error)
+	JWKS() *JSONWebKeySet
+	TokenExpiry() time.Duration
+	RefreshExpiry() time.Duration
 }
 
 type jwtService struct {
@@ -22,14 +29,27 @@ type jwtService struct {
 	refreshKey   string
 	tokenExpiry  time.Duration
 	refreshExpiry time.Duration
+
+	// Asymmetric mode, access tokens are signed with signingKey and verified with any of verificationKeys by kid
+	signingKey       *JWTKey
+	verificationKeys map[string]*JWTKey
 }
 
 type JWTClaims struct {
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	TokenID   string `json:"token_id"`
+	SessionID string `json:"session_id,omitempty"`
+	// Actor is set on impersonation tokens, it is the user acting as UserID
+	Actor *JWTActor `json:"act,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// JWTActor is the `act` claim of a token issued to one user on behalf of another
+type JWTActor struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
-	Email    string `json:"email"`
-	TokenID  string `json:"token_id"`
-	jwt.RegisteredClaims
 }
 
 func NewJWTService(secretKey, refreshKey string, tokenExpiry, refreshExpiry time.Duration) JWTService {
@@ -41,19 +61,70 @@ func NewJWTService(secretKey, refreshKey string, tokenExpiry, refreshExpiry time
 	}
 }
 
-func (j *jwtService) GenerateToken(user *entity.User) (string, error) {
+// NewAsymmetricJWTService signs access tokens with an RSA or Ed25519 key. Tokens are verified against the signing key
+// and the extra verification keys, which keeps tokens signed with a retired key valid during a rotation.
+// Refresh tokens are only ever read by this service and stay HMAC signed with refreshKey.
+func NewAsymmetricJWTService(signingKey *JWTKey, verificationKeys []*JWTKey, refreshKey string, tokenExpiry, refreshExpiry time.Duration) JWTService {
+	keys := map[string]*JWTKey{signingKey.ID: signingKey}
+	for _, key := range verificationKeys {
+		keys[key.ID] = key
+	}
+
+	return &jwtService{
+		refreshKey:       refreshKey,
+		tokenExpiry:      tokenExpiry,
+		refreshExpiry:    refreshExpiry,
+		signingKey:       signingKey,
+		verificationKeys: keys,
+	}
+}
+
+func (j *jwtService) GenerateToken(user *entity.User, sessionID string) (string, error) {
+	claims := JWTClaims{
+		UserID:    user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		TokenID:   uuid.New().String(),
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.tokenExpiry)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return j.signAccessToken(claims)
+}
+
+// GenerateImpersonationToken issues an access token of the user that carries the actor in the `act` claim.
+// It belongs to no session and can not be refreshed, it expires after expiry instead of the usual token expiry.
+func (j *jwtService) GenerateImpersonationToken(user *entity.User, actor *entity.User, expiry time.Duration) (string, error) {
 	claims := JWTClaims{
 		UserID:   user.ID,
 		Username: user.Username,
 		Email:    user.Email,
 		TokenID:  uuid.New().String(),
+		Actor: &JWTActor{
+			UserID:   actor.ID,
+			Username: actor.Username,
+		},
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.tokenExpiry)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
 	}
 
+	return j.signAccessToken(claims)
+}
+
+func (j *jwtService) signAccessToken(claims JWTClaims) (string, error) {
+	if j.signingKey != nil {
+		token := jwt.NewWithClaims(j.signingKey.Method, claims)
+		token.Header["kid"] = j.signingKey.ID
+		return token.SignedString(j.signingKey.PrivateKey)
+	}
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(j.secretKey))
 	if err != nil {
@@ -86,6 +157,20 @@ func (j *jwtService) GenerateRefreshToken(user *entity.User) (string, error) {
 }
 
 func (j *jwtService) ValidateToken(tokenString string) (*jwt.Token, error) {
+	if j.signingKey != nil {
+		return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			key, ok := j.verificationKeys[kid]
+			if !ok {
+				return nil, errors.New("unknown signing key")
+			}
+			if token.Method.Alg() != key.Method.Alg() {
+				return nil, errors.New("unexpected signing method")
+			}
+			return key.PublicKey, nil
+		})
+	}
+
 	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
@@ -123,4 +208,48 @@ func (j *jwtService) ExtractClaimsFromToken(tokenString string) (jwt.MapClaims,
 	}
 
 	return nil, errors.New("invalid token")
-}
\ No newline at end of file
+}
+
+func (j *jwtService) ValidateRefreshToken(tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(j.refreshKey), nil
+	})
+}
+
+func (j *jwtService) ExtractClaimsFromRefreshToken(tokenString string) (jwt.MapClaims, error) {
+	token, err := j.ValidateRefreshToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		return claims, nil
+	}
+
+	return nil, errors.New("invalid token")
+}
+
+// TokenExpiry returns how long an access token is valid
+func (j *jwtService) TokenExpiry() time.Duration {
+	return j.tokenExpiry
+}
+
+// RefreshExpiry returns how long a refresh token is valid
+func (j *jwtService) RefreshExpiry() time.Duration {
+	return j.refreshExpiry
+}
+
+// JWKS returns the public verification keys, it is empty when tokens are signed with a shared secret
+func (j *jwtService) JWKS() *JSONWebKeySet {
+	set := &JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range j.verificationKeys {
+		set.Keys = append(set.Keys, key.JWK())
+	}
+	sort.Slice(set.Keys, func(a, b int) bool {
+		return set.Keys[a].Kid < set.Keys[b].Kid
+	})
+	return set
+}
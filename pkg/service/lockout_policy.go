@@ -0,0 +1,38 @@
+package service
+
+import "time"
+
+// LockoutPolicy describes how failed logins are throttled
+type LockoutPolicy struct {
+	// FreeAttempts is the number of failures tolerated before backoff starts
+	FreeAttempts int
+	// MaxAttempts and IPMaxAttempts are the number of failures after which an account or a client address is locked
+	MaxAttempts   int
+	IPMaxAttempts int
+	// BaseDelay is the first backoff delay, it doubles with every further failure
+	BaseDelay time.Duration
+	// LockoutDuration is both the length of a lockout and the upper bound of the backoff delay,
+	// counters of keys without failures for that long start over
+	LockoutDuration time.Duration
+}
+
+// Delay returns how long a key has to wait after its failedCount-th failure
+func (p *LockoutPolicy) Delay(failedCount, maxAttempts int) time.Duration {
+	if maxAttempts > 0 && failedCount >= maxAttempts {
+		return p.LockoutDuration
+	}
+
+	if failedCount <= p.FreeAttempts {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failedCount && delay < p.LockoutDuration; i++ {
+		delay *= 2
+	}
+
+	if delay > p.LockoutDuration {
+		return p.LockoutDuration
+	}
+	return delay
+}
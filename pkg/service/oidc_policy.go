@@ -0,0 +1,48 @@
+package service
+
+import "time"
+
+// OIDCGroupRole grants a role to every member of an identity provider group
+type OIDCGroupRole struct {
+	Group string `mapstructure:"group"`
+	Role  string `mapstructure:"role"`
+}
+
+// OIDCPolicy describes how identities of the identity provider become users
+type OIDCPolicy struct {
+	// AutoProvision creates a user for a verified identity that matches no existing user
+	AutoProvision bool
+	// StateTTL is how long the user may take at the identity provider before the login has to be started again
+	StateTTL time.Duration
+	// GroupRoles maps provider groups to role names, the mapped roles are kept in sync with the groups on every login
+	GroupRoles []OIDCGroupRole
+}
+
+// ManagedRoles returns the role names whose assignment follows the provider groups
+func (p *OIDCPolicy) ManagedRoles() []string {
+	seen := make(map[string]bool, len(p.GroupRoles))
+	roles := make([]string, 0, len(p.GroupRoles))
+	for _, mapping := range p.GroupRoles {
+		if !seen[mapping.Role] {
+			seen[mapping.Role] = true
+			roles = append(roles, mapping.Role)
+		}
+	}
+	return roles
+}
+
+// RolesForGroups returns the set of role names granted by the given groups
+func (p *OIDCPolicy) RolesForGroups(groups []string) map[string]bool {
+	member := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		member[group] = true
+	}
+
+	roles := make(map[string]bool)
+	for _, mapping := range p.GroupRoles {
+		if member[mapping.Group] {
+			roles[mapping.Role] = true
+		}
+	}
+	return roles
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// oidcKeyRefreshInterval limits how often an unknown kid makes the provider keys be fetched again
+const oidcKeyRefreshInterval = time.Minute
+
+// OIDCConfig describes the relying party registration at the identity provider
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// GroupsClaim is the ID token claim holding the group names of the user
+	GroupsClaim string
+}
+
+// OIDCIdentity is the verified content of an ID token
+type OIDCIdentity struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+	GivenName         string
+	FamilyName        string
+	Groups            []string
+}
+
+// OIDCService implements the OpenID Connect authorization code flow with PKCE (RFC 7636) against a single provider
+type OIDCService interface {
+	Issuer() string
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	// Exchange redeems an authorization code and returns the verified identity of the ID token
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*OIDCIdentity, error)
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcService struct {
+	config     OIDCConfig
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	discovery   *oidcDiscovery
+	keys        map[string]JSONWebKey
+	keysFetched time.Time
+}
+
+func NewOIDCService(config OIDCConfig) OIDCService {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = "groups"
+	}
+
+	return &oidcService{
+		config:     config,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (o *oidcService) Issuer() string {
+	return o.config.Issuer
+}
+
+func (o *oidcService) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	discovery, err := o.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	query := endpoint.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", o.config.ClientID)
+	query.Set("redirect_uri", o.config.RedirectURL)
+	query.Set("scope", strings.Join(o.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", PKCEChallenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+	endpoint.RawQuery = query.Encode()
+
+	return endpoint.String(), nil
+}
+
+func (o *oidcService) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*OIDCIdentity, error) {
+	discovery, err := o.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", o.config.RedirectURL)
+	form.Set("client_id", o.config.ClientID)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if o.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(o.config.ClientID), url.QueryEscape(o.config.ClientSecret))
+	}
+
+	var token struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := o.do(req, &token); err != nil {
+		if token.Error != "" {
+			return nil, fmt.Errorf("token endpoint: %s %s", token.Error, token.ErrorDescription)
+		}
+		return nil, err
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	return o.verifyIDToken(ctx, discovery, token.IDToken, nonce)
+}
+
+func (o *oidcService) verifyIDToken(ctx context.Context, discovery *oidcDiscovery, rawIDToken, nonce string) (*OIDCIdentity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := o.key(ctx, discovery, kid)
+		if err != nil {
+			return nil, err
+		}
+		return key.PublicKey()
+	},
+		jwt.WithValidMethods([]string{"RS256", "ES256", "EdDSA"}),
+		jwt.WithIssuer(o.config.Issuer),
+		jwt.WithAudience(o.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if claimNonce, _ := claims["nonce"].(string); claimNonce == "" || claimNonce != nonce {
+		return nil, errors.New("id token nonce does not match")
+	}
+
+	// With several audiences the token must have been issued to this client
+	if azp, ok := claims["azp"].(string); ok && azp != o.config.ClientID {
+		return nil, errors.New("id token was issued to another client")
+	}
+
+	identity := &OIDCIdentity{Issuer: o.config.Issuer}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.PreferredUsername, _ = claims["preferred_username"].(string)
+	identity.GivenName, _ = claims["given_name"].(string)
+	identity.FamilyName, _ = claims["family_name"].(string)
+
+	// Some providers send email_verified as a string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	}
+
+	switch groups := claims[o.config.GroupsClaim].(type) {
+	case []interface{}:
+		for _, group := range groups {
+			if name, ok := group.(string); ok {
+				identity.Groups = append(identity.Groups, name)
+			}
+		}
+	case string:
+		identity.Groups = []string{groups}
+	}
+
+	if identity.Subject == "" {
+		return nil, errors.New("id token has no subject")
+	}
+
+	return identity, nil
+}
+
+// discover loads the provider metadata on first use so the application starts even while the provider is unreachable
+func (o *oidcService) discover(ctx context.Context) (*oidcDiscovery, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.discovery != nil {
+		return o.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(o.config.Issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	discovery := new(oidcDiscovery)
+	if err := o.do(req, discovery); err != nil {
+		return nil, err
+	}
+	if discovery.Issuer != o.config.Issuer {
+		return nil, fmt.Errorf("provider issuer %q does not match the configured issuer %q", discovery.Issuer, o.config.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("provider metadata is incomplete")
+	}
+
+	o.discovery = discovery
+	return discovery, nil
+}
+
+// key returns the provider key with the given kid, the key set is fetched again when the kid is unknown to follow rotations
+func (o *oidcService) key(ctx context.Context, discovery *oidcDiscovery, kid string) (JSONWebKey, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if key, ok := o.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	if o.keys != nil && time.Since(o.keysFetched) < oidcKeyRefreshInterval {
+		return JSONWebKey{}, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery.JWKSURI, nil)
+	if err != nil {
+		return JSONWebKey{}, err
+	}
+
+	keySet := new(JSONWebKeySet)
+	if err := o.do(req, keySet); err != nil {
+		return JSONWebKey{}, err
+	}
+
+	o.keys = make(map[string]JSONWebKey, len(keySet.Keys))
+	for _, key := range keySet.Keys {
+		if key.Use == "" || key.Use == "sig" {
+			o.keys[key.Kid] = key
+		}
+	}
+	o.keysFetched = time.Now()
+
+	if key, ok := o.lookupKey(kid); ok {
+		return key, nil
+	}
+	return JSONWebKey{}, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookupKey accepts a token without kid only when the provider publishes a single key
+func (o *oidcService) lookupKey(kid string) (JSONWebKey, bool) {
+	if kid == "" && len(o.keys) == 1 {
+		for _, key := range o.keys {
+			return key, true
+		}
+	}
+	key, ok := o.keys[kid]
+	return key, ok
+}
+
+func (o *oidcService) do(req *http.Request, target interface{}) error {
+	res, err := o.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	// Error bodies are decoded as well so the caller can report the OAuth error code
+	decodeErr := json.Unmarshal(body, target)
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s returned status %d", req.Method, req.URL.Redacted(), res.StatusCode)
+	}
+	return decodeErr
+}
+
+// PKCEChallenge derives the S256 code challenge of a code verifier
+func PKCEChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordPolicy describes the rules a new password has to satisfy
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// HistorySize is the number of most recent passwords that can not be reused, 0 disables the check
+	HistorySize int
+}
+
+// Validate checks the password against the policy, the returned error is meant to be shown to the user
+func (p *PasswordPolicy) Validate(password, username, email string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters long", p.MinLength)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	switch {
+	case p.RequireUpper && !hasUpper:
+		return errors.New("password must contain an uppercase letter")
+	case p.RequireLower && !hasLower:
+		return errors.New("password must contain a lowercase letter")
+	case p.RequireDigit && !hasDigit:
+		return errors.New("password must contain a digit")
+	case p.RequireSymbol && !hasSymbol:
+		return errors.New("password must contain a symbol")
+	}
+
+	if strings.EqualFold(password, username) || strings.EqualFold(password, email) {
+		return errors.New("password must not be the same as the username or email")
+	}
+
+	return nil
+}
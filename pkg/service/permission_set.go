@@ -0,0 +1,70 @@
+package service
+
+import "strings"
+
+// PermissionWildcard stands for any resource or any action in a permission grant
+const PermissionWildcard = "*"
+
+// PermissionSet is the resolved set of `resource.action` grants of a principal, a grant may use the wildcard
+// as resource or action (`ship.*`, `*.index`, `*.*`)
+type PermissionSet map[string]bool
+
+func NewPermissionSet(grants []string) PermissionSet {
+	set := make(PermissionSet, len(grants))
+	for _, grant := range grants {
+		set[grant] = true
+	}
+	return set
+}
+
+// Allows reports whether any grant of the set covers the permission
+func (s PermissionSet) Allows(permission string) bool {
+	_, ok := s.Match(permission)
+	return ok
+}
+
+// Match returns the grant that covers the permission, from the exact grant to the widest wildcard
+func (s PermissionSet) Match(permission string) (string, bool) {
+	resource, action := splitPermission(permission)
+	for _, grant := range []string{
+		permission,
+		resource + "." + PermissionWildcard,
+		PermissionWildcard + "." + action,
+		PermissionWildcard + "." + PermissionWildcard,
+	} {
+		if s[grant] {
+			return grant, true
+		}
+	}
+	return "", false
+}
+
+// Grants returns the grant names of the set
+func (s PermissionSet) Grants() []string {
+	grants := make([]string, 0, len(s))
+	for grant := range s {
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+// Expand returns the permissions of the catalog the set allows, wildcard entries of the catalog are left out
+func (s PermissionSet) Expand(catalog []string) []string {
+	permissions := make([]string, 0, len(catalog))
+	for _, permission := range catalog {
+		if !IsWildcardPermission(permission) && s.Allows(permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
+
+func IsWildcardPermission(name string) bool {
+	resource, action := splitPermission(name)
+	return resource == PermissionWildcard || action == PermissionWildcard
+}
+
+func splitPermission(name string) (string, string) {
+	resource, action, _ := strings.Cut(name, ".")
+	return resource, action
+}
@@ -0,0 +1,54 @@
+package service
+
+import "testing"
+
+func TestPermissionSetMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		grants     []string
+		permission string
+		want       string
+		allowed    bool
+	}{
+		{name: "exact grant", grants: []string{"ship.index"}, permission: "ship.index", want: "ship.index", allowed: true},
+		{name: "resource wildcard", grants: []string{"ship.*"}, permission: "ship.delete", want: "ship.*", allowed: true},
+		{name: "action wildcard", grants: []string{"*.index"}, permission: "harbor.index", want: "*.index", allowed: true},
+		{name: "full wildcard", grants: []string{"*.*"}, permission: "user.delete", want: "*.*", allowed: true},
+		{name: "exact grant wins over wildcards", grants: []string{"*.*", "ship.*", "*.index", "ship.index"}, permission: "ship.index", want: "ship.index", allowed: true},
+		{name: "resource wildcard wins over action wildcard", grants: []string{"*.*", "*.index", "ship.*"}, permission: "ship.index", want: "ship.*", allowed: true},
+		{name: "action wildcard wins over full wildcard", grants: []string{"*.*", "*.index"}, permission: "ship.index", want: "*.index", allowed: true},
+		{name: "other action", grants: []string{"ship.index"}, permission: "ship.delete"},
+		{name: "other resource", grants: []string{"ship.*"}, permission: "shipment.index"},
+		{name: "action wildcard of another action", grants: []string{"*.index"}, permission: "ship.show"},
+		{name: "empty set", permission: "ship.index"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := NewPermissionSet(tt.grants)
+			got, ok := set.Match(tt.permission)
+			if got != tt.want || ok != tt.allowed {
+				t.Fatalf("Match(%q) = %q, %v, want %q, %v", tt.permission, got, ok, tt.want, tt.allowed)
+			}
+			if set.Allows(tt.permission) != tt.allowed {
+				t.Fatalf("Allows(%q) = %v, want %v", tt.permission, !tt.allowed, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestPermissionSetExpand(t *testing.T) {
+	set := NewPermissionSet([]string{"ship.*", "*.index"})
+	catalog := []string{"ship.index", "ship.delete", "harbor.index", "harbor.delete", "ship.*", "*.*"}
+
+	got := set.Expand(catalog)
+	want := []string{"ship.index", "ship.delete", "harbor.index"}
+	if len(got) != len(want) {
+		t.Fatalf("Expand = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expand = %v, want %v", got, want)
+		}
+	}
+}
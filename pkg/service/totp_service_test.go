@@ -0,0 +1,109 @@
+package service
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 seed of the RFC 6238 test vectors ("12345678901234567890") in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCodeMatchesRFC6238(t *testing.T) {
+	// The RFC lists 8 digit codes, a 6 digit code is their last 6 digits
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+
+	service, err := NewTOTPService("mkp", "key")
+	if err != nil {
+		t.Fatalf("new totp service: %v", err)
+	}
+	for _, tt := range tests {
+		step, ok := service.Validate(rfc6238Secret, tt.code, time.Unix(tt.unix, 0), 0)
+		if !ok || step != tt.unix/totpPeriod {
+			t.Fatalf("Validate(%s) at %d = %d, %v, want step %d", tt.code, tt.unix, step, ok, tt.unix/totpPeriod)
+		}
+	}
+}
+
+func TestTOTPValidateSkewAndReplay(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := now.Unix() / totpPeriod
+	key := decodeTestSecret(t, rfc6238Secret)
+
+	tests := []struct {
+		name         string
+		secret       string
+		code         string
+		lastUsedStep int64
+		wantStep     int64
+		ok           bool
+	}{
+		{name: "current step", code: totpCode(key, current), wantStep: current, ok: true},
+		{name: "previous step within the skew", code: totpCode(key, current-1), wantStep: current - 1, ok: true},
+		{name: "next step within the skew", code: totpCode(key, current+1), wantStep: current + 1, ok: true},
+		{name: "two steps behind", code: totpCode(key, current-2)},
+		{name: "two steps ahead", code: totpCode(key, current+2)},
+		{name: "replay of the used step", code: totpCode(key, current), lastUsedStep: current},
+		{name: "step before the used step", code: totpCode(key, current-1), lastUsedStep: current},
+		{name: "step after the used step", code: totpCode(key, current+1), lastUsedStep: current, wantStep: current + 1, ok: true},
+		{name: "code of an earlier login within the skew", code: totpCode(key, current-1), lastUsedStep: current - 1},
+		{name: "wrong code", code: "000000"},
+		{name: "short code", code: totpCode(key, current)[:5]},
+		{name: "invalid secret", secret: "not base32!", code: totpCode(key, current)},
+	}
+
+	service, err := NewTOTPService("mkp", "key")
+	if err != nil {
+		t.Fatalf("new totp service: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := tt.secret
+			if secret == "" {
+				secret = rfc6238Secret
+			}
+			step, ok := service.Validate(secret, tt.code, now, tt.lastUsedStep)
+			if ok != tt.ok || step != tt.wantStep {
+				t.Fatalf("Validate = %d, %v, want %d, %v", step, ok, tt.wantStep, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTOTPSealOpen(t *testing.T) {
+	service, err := NewTOTPService("mkp", "key")
+	if err != nil {
+		t.Fatalf("new totp service: %v", err)
+	}
+	sealed, err := service.Seal(rfc6238Secret)
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	if secret, err := service.Open(sealed); err != nil || secret != rfc6238Secret {
+		t.Fatalf("Open = %q, %v, want %q", secret, err, rfc6238Secret)
+	}
+
+	other, _ := NewTOTPService("mkp", "another key")
+	if _, err := other.Open(sealed); err == nil {
+		t.Fatalf("a secret sealed with another key was opened")
+	}
+}
+
+func decodeTestSecret(t *testing.T, secret string) []byte {
+	t.Helper()
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatalf("decode secret: %v", err)
+	}
+	return key
+}
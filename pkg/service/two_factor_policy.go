@@ -0,0 +1,41 @@
+package service
+
+import "time"
+
+// TwoFactorPolicy describes when a second login factor is required
+type TwoFactorPolicy struct {
+	// MandatoryForPrivileged requires users holding a privileged permission to enroll before they can use any permission
+	MandatoryForPrivileged bool
+	// ChallengeTTL is how long the second login step may take after the password was accepted
+	ChallengeTTL time.Duration
+}
+
+// privilegedResources are the resources whose permissions allow a user to change what others may do
+var privilegedResources = []string{"role", "permission"}
+
+// IsPrivilegedPermission also counts wildcard grants on any resource, they cover the privileged resources too
+func IsPrivilegedPermission(name string) bool {
+	resource, _ := splitPermission(name)
+	if resource == PermissionWildcard {
+		return true
+	}
+	for _, privileged := range privilegedResources {
+		if resource == privileged {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresEnrollment reports whether a user holding the given permissions must have two-factor authentication enabled
+func (p *TwoFactorPolicy) RequiresEnrollment(permissions []string) bool {
+	if !p.MandatoryForPrivileged {
+		return false
+	}
+	for _, permission := range permissions {
+		if IsPrivilegedPermission(permission) {
+			return true
+		}
+	}
+	return false
+}
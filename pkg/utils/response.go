@@ -76,6 +76,11 @@ func SendCreatedResponse[T any](ctx *fiber.Ctx, message string, data T) error {
 	return ctx.Status(fiber.StatusCreated).JSON(SuccessResponse(message, data))
 }
 
+// SendAcceptedResponse sends an accepted response (202)
+func SendAcceptedResponse[T any](ctx *fiber.Ctx, message string, data T) error {
+	return ctx.Status(fiber.StatusAccepted).JSON(SuccessResponse(message, data))
+}
+
 // SendErrorResponse sends an error response with appropriate HTTP status
 func SendErrorResponse(ctx *fiber.Ctx, statusCode int, message string, errors string) error {
 	return ctx.Status(statusCode).JSON(ErrorResponse(message, errors))
@@ -0,0 +1,106 @@
+// Package fakedb opens gorm without a database for unit tests of the use cases. Transactions begin, commit and roll
+// back without doing anything, and the statements the use case builds itself are recorded instead of being sent; the
+// repositories of the use case under test are replaced with in-memory fakes.
+package fakedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Statement is a statement the use case built, with the values bound to its placeholders
+type Statement struct {
+	SQL  string
+	Vars []interface{}
+}
+
+// Recorder keeps the statements built through a fake database in the order they were built
+type Recorder struct {
+	mu         sync.Mutex
+	statements []Statement
+}
+
+func (r *Recorder) record(db *gorm.DB) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statements = append(r.statements, Statement{
+		SQL:  db.Statement.SQL.String(),
+		Vars: append([]interface{}(nil), db.Statement.Vars...),
+	})
+}
+
+// Statements returns the statements recorded so far
+func (r *Recorder) Statements() []Statement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Statement(nil), r.statements...)
+}
+
+// Open returns a gorm connection to no database and the recorder of the statements built through it. Queries return
+// no rows and writes affect none.
+func Open() (*gorm.DB, *Recorder, error) {
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: connPool{}}), &gorm.Config{
+		DryRun:                 true,
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recorder := new(Recorder)
+	callbacks := db.Callback()
+	for _, register := range []error{
+		callbacks.Query().After("gorm:query").Register("fakedb:record", recorder.record),
+		callbacks.Row().After("gorm:row").Register("fakedb:record", recorder.record),
+		callbacks.Create().After("gorm:create").Register("fakedb:record", recorder.record),
+		callbacks.Update().After("gorm:update").Register("fakedb:record", recorder.record),
+		callbacks.Delete().After("gorm:delete").Register("fakedb:record", recorder.record),
+		callbacks.Raw().After("gorm:raw").Register("fakedb:record", recorder.record),
+	} {
+		if register != nil {
+			return nil, nil, register
+		}
+	}
+
+	return db, recorder, nil
+}
+
+var errNoDatabase = errors.New("fakedb has no database to send statements to")
+
+// connPool lets gorm begin transactions, a dry run never sends statements through it
+type connPool struct{}
+
+func (connPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errNoDatabase
+}
+
+func (connPool) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, errNoDatabase
+}
+
+func (connPool) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, errNoDatabase
+}
+
+func (connPool) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
+
+func (connPool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	return &transaction{}, nil
+}
+
+type transaction struct{ connPool }
+
+func (*transaction) Commit() error   { return nil }
+func (*transaction) Rollback() error { return nil }